@@ -0,0 +1,70 @@
+package amqp
+
+import (
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Dial подключается к брокеру и возвращает соединение, которое само переподключается
+// при открытии канала, если брокер перезапускался или соединение оборвалось
+func Dial(url string) (*Connection, error) {
+	c := &Connection{url: url}
+	if _, err := c.connection(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type Connection struct {
+	url string
+
+	mu     sync.Mutex
+	conn   *amqp.Connection
+	closed bool
+}
+
+// Channel открывает канал, при необходимости заново устанавливая соединение
+func (c *Connection) Channel() (*amqp.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, err := c.connection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to AMQP: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	return channel, nil
+}
+
+func (c *Connection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.conn == nil || c.conn.IsClosed() {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+func (c *Connection) connection() (*amqp.Connection, error) {
+	if c.closed {
+		return nil, amqp.ErrClosed
+	}
+	if c.conn != nil && !c.conn.IsClosed() {
+		return c.conn, nil
+	}
+
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	return conn, nil
+}
@@ -0,0 +1,218 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	log "github.com/sirupsen/logrus"
+
+	"common/inbox"
+)
+
+type ConsumerConfig struct {
+	// Queue - устойчивая очередь, общая для всех реплик сервиса
+	Queue string
+	// Source - appID сервиса-издателя, события которого нужно получать
+	Source     string
+	EventTypes []string
+	Prefetch   int
+	// RetryDelay - пауза перед возвратом в очередь сообщения, обработка которого упала
+	RetryDelay time.Duration
+	// ReconnectDelay - пауза между попытками заново подписаться на очередь после обрыва канала
+	ReconnectDelay time.Duration
+	// LagReportInterval - как часто писать в лог отставание от издателя
+	LagReportInterval time.Duration
+}
+
+// NewConsumer подписывает очередь на события вида "<Source>.<EventType>" из общего exchange.
+// Сообщение подтверждается только после успешной обработки, поэтому handler должен быть идемпотентным
+func NewConsumer(conn *Connection, config ConsumerConfig, handler inbox.Handler, logger *log.Logger) (*Consumer, error) {
+	c := &Consumer{
+		conn:    conn,
+		config:  config,
+		handler: handler,
+		logger:  logger.WithField("queue", config.Queue),
+	}
+
+	channel, err := c.subscribe()
+	if err != nil {
+		return nil, err
+	}
+	c.channel = channel
+	return c, nil
+}
+
+// Consumer обрабатывает сообщения очереди по одному, пока не будет отменён контекст.
+// Если брокер закрыл канал, Consumer открывает новый и подписывается на очередь заново
+type Consumer struct {
+	conn    *Connection
+	config  ConsumerConfig
+	handler inbox.Handler
+	logger  *log.Entry
+
+	channel   *amqp.Channel
+	processed int
+	maxLag    time.Duration
+}
+
+func (c *Consumer) Run(ctx context.Context) {
+	defer c.closeChannel()
+
+	ticker := time.NewTicker(c.config.LagReportInterval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		deliveries, err := c.consume(ctx)
+		if err != nil {
+			c.logger.WithError(err).Error("failed to start consuming, will resubscribe")
+			c.closeChannel()
+			c.wait(ctx, c.config.ReconnectDelay)
+			continue
+		}
+
+		c.drain(ctx, deliveries, ticker.C)
+		if ctx.Err() == nil {
+			c.logger.Warn("delivery channel closed, will resubscribe")
+			c.closeChannel()
+		}
+	}
+}
+
+// consume начинает чтение очереди, при необходимости заново открыв канал
+func (c *Consumer) consume(ctx context.Context) (<-chan amqp.Delivery, error) {
+	if c.channel == nil {
+		channel, err := c.subscribe()
+		if err != nil {
+			return nil, err
+		}
+		c.channel = channel
+	}
+	return c.channel.ConsumeWithContext(ctx, c.config.Queue, "", false, false, false, false, nil)
+}
+
+// drain обрабатывает доставки, пока не закроется канал или не будет отменён контекст
+func (c *Consumer) drain(ctx context.Context, deliveries <-chan amqp.Delivery, reportLag <-chan time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reportLag:
+			c.reportLag()
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return
+			}
+			c.handle(ctx, delivery)
+		}
+	}
+}
+
+// subscribe открывает канал и объявляет exchange, очередь и её привязки
+func (c *Consumer) subscribe() (*amqp.Channel, error) {
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.declare(channel); err != nil {
+		_ = channel.Close()
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (c *Consumer) declare(channel *amqp.Channel) error {
+	err := channel.ExchangeDeclare(ExchangeName, amqp.ExchangeTopic, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", ExchangeName, err)
+	}
+
+	_, err = channel.QueueDeclare(c.config.Queue, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", c.config.Queue, err)
+	}
+
+	for _, eventType := range c.config.EventTypes {
+		routingKey := c.config.Source + "." + eventType
+		if err = channel.QueueBind(c.config.Queue, routingKey, ExchangeName, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue %s to %s: %w", c.config.Queue, routingKey, err)
+		}
+	}
+
+	if err = channel.Qos(c.config.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set prefetch for queue %s: %w", c.config.Queue, err)
+	}
+	return nil
+}
+
+func (c *Consumer) closeChannel() {
+	if c.channel == nil {
+		return
+	}
+	if !c.channel.IsClosed() {
+		_ = c.channel.Close()
+	}
+	c.channel = nil
+}
+
+func (c *Consumer) wait(ctx context.Context, delay time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, delivery amqp.Delivery) {
+	lag := time.Since(delivery.Timestamp)
+	logger := c.logger.WithFields(log.Fields{
+		"event_id":   delivery.MessageId,
+		"event_type": delivery.Type,
+		"lag":        lag,
+	})
+
+	err := c.handler.Handle(ctx, inbox.Message{
+		ID:          delivery.MessageId,
+		Type:        delivery.Type,
+		Payload:     string(delivery.Body),
+		PublishedAt: delivery.Timestamp,
+	})
+	switch {
+	case err == nil:
+		c.processed++
+		c.maxLag = max(c.maxLag, lag)
+		logger.Debug("event processed")
+		err = delivery.Ack(false)
+	case errors.Is(err, inbox.ErrInvalidMessage):
+		logger.WithError(err).Error("event rejected")
+		err = delivery.Reject(false)
+	default:
+		logger.WithError(err).Warn("failed to process event, will retry")
+		c.wait(ctx, c.config.RetryDelay)
+		err = delivery.Nack(false, true)
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to settle delivery")
+	}
+}
+
+// reportLag пишет в лог число сообщений, ждущих в очереди, и наибольшую задержку
+// между публикацией и обработкой события за прошедший интервал
+func (c *Consumer) reportLag() {
+	queue, err := c.channel.QueueDeclarePassive(c.config.Queue, true, false, false, false, nil)
+	if err != nil {
+		c.logger.WithError(err).Error("failed to inspect queue")
+		return
+	}
+
+	c.logger.WithFields(log.Fields{
+		"pending":   queue.Messages,
+		"processed": c.processed,
+		"max_lag":   c.maxLag,
+	}).Info("consumer lag")
+
+	c.processed = 0
+	c.maxLag = 0
+}
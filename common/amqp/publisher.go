@@ -0,0 +1,108 @@
+package amqp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"common/outbox"
+)
+
+const ExchangeName = "domain_events"
+
+// NewPublisher публикует события в topic exchange с routing key вида "<appID>.<EventType>"
+// и дожидается подтверждения брокера, чтобы outbox пометил событие отправленным только после доставки.
+// Если канал закрылся (перезапуск брокера, обрыв соединения), следующая публикация откроет его заново
+func NewPublisher(conn *Connection, appID string) (outbox.Publisher, error) {
+	p := &publisher{
+		conn:  conn,
+		appID: appID,
+	}
+	if _, err := p.openChannel(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type publisher struct {
+	conn  *Connection
+	appID string
+
+	mu      sync.Mutex
+	channel *amqp.Channel
+	closed  chan *amqp.Error
+}
+
+func (p *publisher) Publish(ctx context.Context, event outbox.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	channel, err := p.openChannel()
+	if err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		ExchangeName,
+		p.appID+"."+event.Type,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    event.ID,
+			Type:         event.Type,
+			AppId:        p.appID,
+			Timestamp:    event.CreatedAt,
+			Body:         []byte(event.Payload),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait confirmation for event %s: %w", event.ID, err)
+	}
+	if !acked {
+		return fmt.Errorf("event %s was not acknowledged by broker", event.ID)
+	}
+
+	return nil
+}
+
+// openChannel возвращает текущий канал или открывает новый, если брокер закрыл прежний
+func (p *publisher) openChannel() (*amqp.Channel, error) {
+	if p.channel != nil {
+		select {
+		case <-p.closed:
+			p.channel = nil
+		default:
+			return p.channel, nil
+		}
+	}
+
+	channel, err := p.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	err = channel.ExchangeDeclare(ExchangeName, amqp.ExchangeTopic, true, false, false, false, nil)
+	if err != nil {
+		_ = channel.Close()
+		return nil, fmt.Errorf("failed to declare exchange %s: %w", ExchangeName, err)
+	}
+
+	if err = channel.Confirm(false); err != nil {
+		_ = channel.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	p.channel = channel
+	p.closed = channel.NotifyClose(make(chan *amqp.Error, 1))
+	return channel, nil
+}
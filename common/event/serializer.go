@@ -0,0 +1,52 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type Event interface {
+	Type() string
+}
+
+type Serializer[E Event] interface {
+	Serialize(event E) (string, error)
+	Deserialize(eventType string, payload string) (E, error)
+}
+
+// NewJSONSerializer сериализует события в JSON. Для десериализации передаются
+// нулевые значения всех известных сервису событий: тип выбирается по Type()
+func NewJSONSerializer[E Event](events ...E) Serializer[E] {
+	types := make(map[string]reflect.Type, len(events))
+	for _, event := range events {
+		types[event.Type()] = reflect.TypeOf(event)
+	}
+	return &jsonSerializer[E]{types: types}
+}
+
+type jsonSerializer[E Event] struct {
+	types map[string]reflect.Type
+}
+
+func (s *jsonSerializer[E]) Serialize(event E) (string, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize event %s: %w", event.Type(), err)
+	}
+	return string(payload), nil
+}
+
+func (s *jsonSerializer[E]) Deserialize(eventType string, payload string) (E, error) {
+	var zero E
+	eventReflectType, ok := s.types[eventType]
+	if !ok {
+		return zero, fmt.Errorf("unknown event type %q", eventType)
+	}
+
+	event := reflect.New(eventReflectType)
+	if err := json.Unmarshal([]byte(payload), event.Interface()); err != nil {
+		return zero, fmt.Errorf("failed to deserialize event %s: %w", eventType, err)
+	}
+	return event.Elem().Interface().(E), nil
+}
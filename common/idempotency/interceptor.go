@@ -0,0 +1,223 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// MetadataKey - заголовок gRPC-метаданных с ключом идемпотентности
+const MetadataKey = "idempotency-key"
+
+const maxKeyLength = 255
+
+// maxAcquireAttempts ограничивает повторы, когда запись с ключом удаляют и создают параллельно
+const maxAcquireAttempts = 3
+
+// Record - запрос, выполненный с ключом идемпотентности
+type Record struct {
+	Method      string
+	RequestHash string
+	// Response равен nil, пока первый запрос ещё выполняется
+	Response  []byte
+	CreatedAt time.Time
+}
+
+type Storage interface {
+	// Create сохраняет запись, если ключ свободен. Возвращает false, если ключ уже занят
+	Create(ctx context.Context, key string, record Record) (bool, error)
+	// Find возвращает nil, если записи нет
+	Find(ctx context.Context, key string) (*Record, error)
+	Complete(ctx context.Context, key string, response []byte) error
+	// Delete удаляет запись, только если она создана в createdAt, чтобы не удалить чужую более новую
+	Delete(ctx context.Context, key string, createdAt time.Time) error
+	// DeleteBefore удаляет не больше limit записей, созданных раньше before. Используется retention.Cleaner
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+// NewInterceptor применяет идемпотентность только к methods - полным именам изменяющих вызовов
+// вида "/Package.Service/Method". Ответы чтений не сохраняются, заголовок в них игнорируется
+func NewInterceptor(storage Storage, logger *log.Logger, retention, pendingTimeout time.Duration, methods []string) *Interceptor {
+	allowed := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		allowed[method] = struct{}{}
+	}
+	return &Interceptor{
+		storage:        storage,
+		logger:         logger,
+		retention:      retention,
+		pendingTimeout: pendingTimeout,
+		methods:        allowed,
+	}
+}
+
+// Interceptor сохраняет ответ первого успешного вызова с заголовком idempotency-key и возвращает
+// его на повторы с тем же ключом в течение retention. Ошибки не сохраняются: после ошибки запрос
+// с тем же ключом выполняется заново. Вызовы без заголовка и методы не из списка проходят без изменений
+type Interceptor struct {
+	storage        Storage
+	logger         *log.Logger
+	retention      time.Duration
+	pendingTimeout time.Duration
+	methods        map[string]struct{}
+}
+
+func (i *Interceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if _, ok := i.methods[info.FullMethod]; !ok {
+		return handler(ctx, req)
+	}
+	key, ok := keyFromContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+	if len(key) > maxKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", MetadataKey, maxKeyLength)
+	}
+
+	requestHash, err := hashRequest(info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+
+	record := Record{
+		Method:      info.FullMethod,
+		RequestHash: requestHash,
+		CreatedAt:   time.Now(),
+	}
+	replay, err := i.acquire(ctx, key, record)
+	if err != nil || replay != nil {
+		return replay, err
+	}
+
+	resp, err := handler(ctx, req)
+
+	// Запрос уже выполнен, поэтому запись сохраняется даже после отмены контекста клиентом
+	storeCtx := context.WithoutCancel(ctx)
+	if err != nil {
+		if deleteErr := i.storage.Delete(storeCtx, key, record.CreatedAt); deleteErr != nil {
+			i.logger.WithError(deleteErr).WithField("route", info.FullMethod).Error("failed to release idempotency key")
+		}
+		return nil, err
+	}
+
+	response, err := marshalResponse(resp)
+	if err == nil {
+		err = i.storage.Complete(storeCtx, key, response)
+	}
+	if err != nil {
+		// Ответ клиенту не зависит от сохранения записи: повтор с этим ключом выполнится заново
+		i.logger.WithError(err).WithField("route", info.FullMethod).Error("failed to store idempotent response")
+	}
+	return resp, nil
+}
+
+// acquire закрепляет ключ за запросом. Если ключ уже использован тем же запросом,
+// возвращает сохранённый ответ. Просроченные и брошенные записи заменяются новой
+func (i *Interceptor) acquire(ctx context.Context, key string, record Record) (interface{}, error) {
+	for attempt := 0; attempt < maxAcquireAttempts; attempt++ {
+		created, err := i.storage.Create(ctx, key, record)
+		if err != nil || created {
+			return nil, err
+		}
+
+		existing, err := i.storage.Find(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			// Запись успели удалить между Create и Find
+			continue
+		}
+
+		if i.isStale(existing, record.CreatedAt) {
+			if err = i.storage.Delete(ctx, key, existing.CreatedAt); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if existing.Method != record.Method || existing.RequestHash != record.RequestHash {
+			return nil, status.Errorf(codes.InvalidArgument, "%s was already used with a different request", MetadataKey)
+		}
+		if existing.Response == nil {
+			return nil, status.Errorf(codes.Aborted, "request with the same %s is in progress", MetadataKey)
+		}
+		return unmarshalResponse(existing.Response)
+	}
+	return nil, status.Errorf(codes.Aborted, "request with the same %s is in progress", MetadataKey)
+}
+
+// isStale - запись старше retention или выполняется дольше pendingTimeout (например, реплика упала)
+func (i *Interceptor) isStale(record *Record, now time.Time) bool {
+	age := now.Sub(record.CreatedAt)
+	if age > i.retention {
+		return true
+	}
+	return record.Response == nil && age > i.pendingTimeout
+}
+
+func keyFromContext(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+func hashRequest(method string, req interface{}) (string, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return "", status.Errorf(codes.Internal, "unexpected request type %T", req)
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to marshal request: %s", err)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func marshalResponse(resp interface{}) ([]byte, error) {
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected response type %T", resp)
+	}
+	response, err := anypb.New(message)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(response)
+}
+
+func unmarshalResponse(data []byte) (interface{}, error) {
+	var response anypb.Any
+	if err := proto.Unmarshal(data, &response); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal stored response: %s", err)
+	}
+	message, err := response.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal stored response: %s", err)
+	}
+	return message, nil
+}
@@ -0,0 +1,242 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"common/idempotency"
+)
+
+const (
+	retention      = time.Hour
+	pendingTimeout = time.Minute
+)
+
+var (
+	createOrderInfo = &grpc.UnaryServerInfo{FullMethod: "/Order.OrderInternalService/CreateOrder"}
+	getOrderInfo    = &grpc.UnaryServerInfo{FullMethod: "/Order.OrderInternalService/GetOrder"}
+)
+
+func TestInterceptor(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7()).String()
+
+	t.Run("Pass calls without key", func(t *testing.T) {
+		f := setup()
+
+		_, err := f.call(context.Background(), customerID)
+		require.NoError(t, err)
+		_, err = f.call(context.Background(), customerID)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, f.calls)
+		require.Empty(t, f.storage.records)
+	})
+
+	t.Run("Pass methods outside allow-list with key", func(t *testing.T) {
+		f := setup()
+		ctx := withKey("key-1")
+
+		_, err := f.callMethod(ctx, getOrderInfo, customerID)
+		require.NoError(t, err)
+		_, err = f.callMethod(ctx, getOrderInfo, customerID)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, f.calls)
+		require.Empty(t, f.storage.records)
+	})
+
+	t.Run("Replay response for repeated key", func(t *testing.T) {
+		f := setup()
+		ctx := withKey("key-1")
+
+		first, err := f.call(ctx, customerID)
+		require.NoError(t, err)
+		second, err := f.call(ctx, customerID)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, f.calls)
+		require.True(t, proto.Equal(first.(proto.Message), second.(proto.Message)))
+	})
+
+	t.Run("Execute different keys separately", func(t *testing.T) {
+		f := setup()
+
+		first, _ := f.call(withKey("key-1"), customerID)
+		second, _ := f.call(withKey("key-2"), customerID)
+
+		require.Equal(t, 2, f.calls)
+		require.NotEqual(t, first.(*wrapperspb.StringValue).Value, second.(*wrapperspb.StringValue).Value)
+	})
+
+	t.Run("Reject key reuse with different request", func(t *testing.T) {
+		f := setup()
+		ctx := withKey("key-1")
+		_, _ = f.call(ctx, customerID)
+
+		_, err := f.call(ctx, uuid.Must(uuid.NewV7()).String())
+
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, 1, f.calls)
+	})
+
+	t.Run("Release key after failed call", func(t *testing.T) {
+		f := setup()
+		ctx := withKey("key-1")
+		f.err = errors.New("unavailable")
+		_, err := f.call(ctx, customerID)
+		require.Error(t, err)
+		require.Empty(t, f.storage.records)
+
+		f.err = nil
+		_, err = f.call(ctx, customerID)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, f.calls)
+	})
+
+	t.Run("Abort while first call is in progress", func(t *testing.T) {
+		f := setup()
+		f.storage.records["key-1"] = &idempotency.Record{
+			Method:      createOrderInfo.FullMethod,
+			RequestHash: requestHash(t, customerID),
+			CreatedAt:   time.Now(),
+		}
+
+		_, err := f.call(withKey("key-1"), customerID)
+
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.Zero(t, f.calls)
+	})
+
+	t.Run("Take over abandoned call", func(t *testing.T) {
+		f := setup()
+		f.storage.records["key-1"] = &idempotency.Record{
+			Method:      createOrderInfo.FullMethod,
+			RequestHash: requestHash(t, customerID),
+			CreatedAt:   time.Now().Add(-2 * pendingTimeout),
+		}
+
+		_, err := f.call(withKey("key-1"), customerID)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, f.calls)
+	})
+
+	t.Run("Execute again after retention", func(t *testing.T) {
+		f := setup()
+		ctx := withKey("key-1")
+		_, _ = f.call(ctx, customerID)
+		f.storage.records["key-1"].CreatedAt = time.Now().Add(-2 * retention)
+
+		_, err := f.call(ctx, customerID)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, f.calls)
+	})
+}
+
+type fixture struct {
+	interceptor *idempotency.Interceptor
+	storage     *mockStorage
+	calls       int
+	err         error
+}
+
+func setup() *fixture {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	storage := &mockStorage{records: make(map[string]*idempotency.Record)}
+	return &fixture{
+		interceptor: idempotency.NewInterceptor(storage, logger, retention, pendingTimeout, []string{createOrderInfo.FullMethod}),
+		storage:     storage,
+	}
+}
+
+func (f *fixture) call(ctx context.Context, customerID string) (interface{}, error) {
+	return f.callMethod(ctx, createOrderInfo, customerID)
+}
+
+func (f *fixture) callMethod(ctx context.Context, info *grpc.UnaryServerInfo, customerID string) (interface{}, error) {
+	return f.interceptor.Intercept(ctx, wrapperspb.String(customerID), info, f.handle)
+}
+
+func (f *fixture) handle(context.Context, interface{}) (interface{}, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return wrapperspb.String(uuid.Must(uuid.NewV7()).String()), nil
+}
+
+// requestHash возвращает хэш запроса так, как его сохраняет Interceptor
+func requestHash(t *testing.T, customerID string) string {
+	probe := setup()
+	_, err := probe.call(withKey("probe"), customerID)
+	require.NoError(t, err)
+	return probe.storage.records["probe"].RequestHash
+}
+
+func withKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotency.MetadataKey, key))
+}
+
+var _ idempotency.Storage = &mockStorage{}
+
+type mockStorage struct {
+	records map[string]*idempotency.Record
+}
+
+func (m *mockStorage) Create(_ context.Context, key string, record idempotency.Record) (bool, error) {
+	if _, ok := m.records[key]; ok {
+		return false, nil
+	}
+	m.records[key] = &record
+	return true, nil
+}
+
+func (m *mockStorage) Find(_ context.Context, key string) (*idempotency.Record, error) {
+	record, ok := m.records[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (m *mockStorage) Complete(_ context.Context, key string, response []byte) error {
+	if record, ok := m.records[key]; ok {
+		record.Response = response
+	}
+	return nil
+}
+
+func (m *mockStorage) Delete(_ context.Context, key string, createdAt time.Time) error {
+	if record, ok := m.records[key]; ok && record.CreatedAt.Equal(createdAt) {
+		delete(m.records, key)
+	}
+	return nil
+}
+
+func (m *mockStorage) DeleteBefore(_ context.Context, before time.Time, limit int) (int, error) {
+	deleted := 0
+	for key, record := range m.records {
+		if record.CreatedAt.Before(before) && deleted < limit {
+			delete(m.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,21 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidMessage - сообщение невозможно обработать, повторная доставка не поможет
+var ErrInvalidMessage = errors.New("invalid message")
+
+type Message struct {
+	ID          string
+	Type        string
+	Payload     string
+	PublishedAt time.Time
+}
+
+type Handler interface {
+	Handle(ctx context.Context, message Message) error
+}
@@ -0,0 +1,155 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+	ErrInvalidAmount    = errors.New("invalid money amount")
+)
+
+const DefaultCurrency = "RUB"
+
+// minorUnitsPerMajor - все поддерживаемые валюты имеют две цифры после запятой,
+// что совпадает с DECIMAL(10,2) в базе данных
+const minorUnitsPerMajor = 100
+
+// Money хранит сумму в минимальных единицах валюты (копейках, центах),
+// чтобы арифметика не теряла точность как float64
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) (Money, error) {
+	if !IsValidCurrency(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// ParseMoney разбирает десятичную запись вида "123.45" без промежуточного перевода во float.
+// Допускается только ведущий минус и цифры, до двух знаков после точки
+func ParseMoney(value, currency string) (Money, error) {
+	if !IsValidCurrency(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+
+	digits, negative := strings.CutPrefix(value, "-")
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+	if !isDigits(whole) || (hasFraction && !isDigits(fraction)) || len(fraction) > 2 {
+		return Money{}, errors.Wrapf(ErrInvalidAmount, "%q", value)
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	major, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Money{}, errors.Wrapf(ErrInvalidAmount, "%q", value)
+	}
+	minor, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return Money{}, errors.Wrapf(ErrInvalidAmount, "%q", value)
+	}
+	if major > (math.MaxInt64-minor)/minorUnitsPerMajor {
+		return Money{}, errors.Wrapf(ErrInvalidAmount, "%q", value)
+	}
+
+	amount := major*minorUnitsPerMajor + minor
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Multiply(multiplier int64) Money {
+	return Money{Amount: m.Amount * multiplier, Currency: m.Currency}
+}
+
+// Compare возвращает -1, 0 или 1, если m меньше, равно или больше other
+func (m Money) Compare(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) LessThan(other Money) (bool, error) {
+	cmp, err := m.Compare(other)
+	return cmp < 0, err
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Decimal возвращает сумму в виде "123.45" для хранения в колонках DECIMAL
+func (m Money) Decimal() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/minorUnitsPerMajor, amount%minorUnitsPerMajor)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// IsValidCurrency проверяет, что код валюты состоит из трёх заглавных латинских букв (ISO 4217)
+func IsValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
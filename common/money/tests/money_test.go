@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"common/money"
+)
+
+func TestMoney(t *testing.T) {
+	t.Run("Parse decimal without losing cents", func(t *testing.T) {
+		for _, tc := range []struct {
+			value    string
+			expected int64
+		}{
+			{value: "0.1", expected: 10},
+			{value: "0.29", expected: 29},
+			{value: "123", expected: 12300},
+			{value: "99999999.99", expected: 9999999999},
+			{value: "-5.05", expected: -505},
+			{value: "007.5", expected: 750},
+			{value: "-0.01", expected: -1},
+		} {
+			parsed, err := money.ParseMoney(tc.value, money.DefaultCurrency)
+
+			require.NoError(t, err, tc.value)
+			require.Equal(t, tc.expected, parsed.Amount, tc.value)
+		}
+	})
+
+	t.Run("Fail to parse invalid amount", func(t *testing.T) {
+		for _, value := range []string{
+			"", "abc", "1.234", ".5", "1.", "-", "1.-5", "1.+5",
+			"+5", "--5", "-+5", " 5", "5 ", "1_000", "0x10", "1.5a",
+			"99999999999999999999",
+		} {
+			_, err := money.ParseMoney(value, money.DefaultCurrency)
+
+			require.ErrorIs(t, err, money.ErrInvalidAmount, value)
+			require.Equal(t, money.ErrInvalidAmount, errors.Cause(err), value)
+		}
+	})
+
+	t.Run("Fail to create money with invalid currency", func(t *testing.T) {
+		_, err := money.NewMoney(100, "rub")
+
+		require.ErrorIs(t, err, money.ErrInvalidCurrency)
+	})
+
+	t.Run("Format as decimal", func(t *testing.T) {
+		require.Equal(t, "0.07", money.Money{Amount: 7, Currency: "RUB"}.Decimal())
+		require.Equal(t, "-1.50", money.Money{Amount: -150, Currency: "RUB"}.Decimal())
+		require.Equal(t, "10.00 USD", money.Money{Amount: 1000, Currency: "USD"}.String())
+	})
+
+	t.Run("Arithmetic is exact", func(t *testing.T) {
+		balance := money.Money{Amount: 0, Currency: "RUB"}
+		dime := money.Money{Amount: 10, Currency: "RUB"}
+		for range 10 {
+			var err error
+			balance, err = balance.Add(dime)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, money.Money{Amount: 100, Currency: "RUB"}, balance)
+
+		rest, err := balance.Sub(dime.Multiply(3))
+
+		require.NoError(t, err)
+		require.Equal(t, int64(70), rest.Amount)
+	})
+
+	t.Run("Fail to mix currencies", func(t *testing.T) {
+		rub := money.Money{Amount: 100, Currency: "RUB"}
+		usd := money.Money{Amount: 100, Currency: "USD"}
+
+		_, addErr := rub.Add(usd)
+		_, subErr := rub.Sub(usd)
+		_, cmpErr := rub.Compare(usd)
+
+		require.ErrorIs(t, addErr, money.ErrCurrencyMismatch)
+		require.ErrorIs(t, subErr, money.ErrCurrencyMismatch)
+		require.ErrorIs(t, cmpErr, money.ErrCurrencyMismatch)
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		less, err := money.Money{Amount: 99, Currency: "RUB"}.LessThan(money.Money{Amount: 100, Currency: "RUB"})
+
+		require.NoError(t, err)
+		require.True(t, less)
+	})
+}
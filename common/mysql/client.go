@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ClientContext - общий интерфейс для *sqlx.DB, *sqlx.Conn и *sqlx.Tx
+type ClientContext interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// TransactionBeginner - общий интерфейс для *sqlx.DB и *sqlx.Conn
+type TransactionBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
+// ExecuteInTransaction коммитит транзакцию, если f завершилась без ошибки, и откатывает её при ошибке или панике
+func ExecuteInTransaction(ctx context.Context, beginner TransactionBeginner, f func(tx *sqlx.Tx) error) (err error) {
+	tx, err := beginner.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			err = fmt.Errorf("failed to commit transaction: %w", commitErr)
+		}
+	}()
+
+	return f(tx)
+}
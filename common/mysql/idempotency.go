@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"common/idempotency"
+)
+
+func NewIdempotencyStorage(db *sqlx.DB) idempotency.Storage {
+	return &idempotencyStorage{db: db}
+}
+
+type idempotencyStorage struct {
+	db *sqlx.DB
+}
+
+type IdempotencyKeyRow struct {
+	Method      string    `db:"method"`
+	RequestHash string    `db:"request_hash"`
+	Response    []byte    `db:"response"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (s *idempotencyStorage) Create(ctx context.Context, key string, record idempotency.Record) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT IGNORE INTO idempotency_keys (idempotency_key, method, request_hash, created_at)
+		VALUES (?, ?, ?, ?)`,
+		key,
+		record.Method,
+		record.RequestHash,
+		toCreatedAtColumn(record.CreatedAt),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (s *idempotencyStorage) Find(ctx context.Context, key string) (*idempotency.Record, error) {
+	var row IdempotencyKeyRow
+	err := s.db.GetContext(ctx, &row, `
+		SELECT method, request_hash, response, created_at
+		FROM idempotency_keys
+		WHERE idempotency_key = ?`,
+		key,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	return &idempotency.Record{
+		Method:      row.Method,
+		RequestHash: row.RequestHash,
+		Response:    row.Response,
+		CreatedAt:   row.CreatedAt,
+	}, nil
+}
+
+func (s *idempotencyStorage) Complete(ctx context.Context, key string, response []byte) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE idempotency_keys SET response = ? WHERE idempotency_key = ?",
+		response,
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+func (s *idempotencyStorage) Delete(ctx context.Context, key string, createdAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE idempotency_key = ? AND created_at = ?",
+		key,
+		toCreatedAtColumn(createdAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (s *idempotencyStorage) DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE created_at < ? LIMIT ?",
+		before,
+		limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(affected), nil
+}
+
+// toCreatedAtColumn обрезает время до микросекунд DATETIME(6), чтобы Delete находил запись по точному совпадению
+func toCreatedAtColumn(createdAt time.Time) time.Time {
+	return createdAt.Truncate(time.Microsecond)
+}
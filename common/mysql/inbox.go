@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"common/retention"
+)
+
+// NewInbox записывает обработанные события в таблицу inbox в той же транзакции, что и изменения агрегата
+func NewInbox(ctx context.Context, client ClientContext) *Inbox {
+	return &Inbox{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type Inbox struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+// MarkProcessed вставляет событие через INSERT IGNORE. Параллельная обработка той же доставки
+// ждёт блокировки строки до коммита первой транзакции и затем видит событие обработанным
+func (i *Inbox) MarkProcessed(eventID, eventType string) (bool, error) {
+	result, err := i.client.ExecContext(i.ctx, `
+		INSERT IGNORE INTO inbox (event_id, event_type, processed_at)
+		VALUES (?, ?, ?)`,
+		eventID,
+		eventType,
+		time.Now(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to store event %s in inbox: %w", eventID, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// NewInboxStorage удаляет из inbox события, обработанные дольше срока хранения назад.
+// Срок должен превышать время, за которое брокер может повторно доставить событие
+func NewInboxStorage(db *sqlx.DB) retention.Storage {
+	return &inboxStorage{db: db}
+}
+
+type inboxStorage struct {
+	db *sqlx.DB
+}
+
+func (s *inboxStorage) DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM inbox WHERE processed_at < ? LIMIT ?",
+		before,
+		limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete processed inbox events: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(affected), nil
+}
@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ExecuteWithLock выполняет f в транзакции под именованной блокировкой MySQL (GET_LOCK).
+// Блокировка держится на выделенном соединении и снимается только после коммита,
+// чтобы следующая транзакция гарантированно увидела результат предыдущей.
+// Если блокировку не удалось получить за lockTimeout, возвращается errLockTimeout
+func ExecuteWithLock(
+	ctx context.Context,
+	db *sqlx.DB,
+	lockName string,
+	lockTimeout time.Duration,
+	errLockTimeout error,
+	f func(tx *sqlx.Tx) error,
+) (err error) {
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	err = conn.GetContext(ctx, &acquired, "SELECT GET_LOCK(?, ?)", lockName, lockTimeoutSeconds(lockTimeout))
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock %q: %w", lockName, err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return errLockTimeout
+	}
+
+	defer func() {
+		// Снимаем блокировку даже если контекст запроса уже отменён
+		_, releaseErr := conn.ExecContext(context.WithoutCancel(ctx), "DO RELEASE_LOCK(?)", lockName)
+		if releaseErr != nil && err == nil {
+			err = fmt.Errorf("failed to release lock %q: %w", lockName, releaseErr)
+		}
+	}()
+
+	return ExecuteInTransaction(ctx, conn, f)
+}
+
+// lockTimeoutSeconds округляет таймаут вверх: GET_LOCK принимает секунды,
+// и отбрасывание дробной части превратило бы 500ms в 0, то есть в отказ без ожидания
+func lockTimeoutSeconds(timeout time.Duration) int {
+	return int(math.Ceil(timeout.Seconds()))
+}
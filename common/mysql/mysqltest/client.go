@@ -0,0 +1,36 @@
+package mysqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"common/mysql"
+)
+
+var _ mysql.ClientContext = &Client{}
+
+type Exec struct {
+	Query string
+	Args  []interface{}
+}
+
+// Client - ClientContext для тестов репозиториев: записывает выполненные запросы
+// и отвечает на каждый из них RowsAffected. Чтение всегда возвращает пустой результат
+type Client struct {
+	RowsAffected int64
+	Execs        []Exec
+}
+
+func (c *Client) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.Execs = append(c.Execs, Exec{Query: query, Args: args})
+	return driver.RowsAffected(c.RowsAffected), nil
+}
+
+func (c *Client) GetContext(context.Context, interface{}, string, ...interface{}) error {
+	return sql.ErrNoRows
+}
+
+func (c *Client) SelectContext(context.Context, interface{}, string, ...interface{}) error {
+	return nil
+}
@@ -0,0 +1,118 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"common/event"
+	"common/outbox"
+)
+
+// NewEventDispatcher сохраняет события в таблицу outbox в той же транзакции, что и агрегат
+func NewEventDispatcher[E event.Event](ctx context.Context, client ClientContext, serializer event.Serializer[E]) *EventDispatcher[E] {
+	return &EventDispatcher[E]{
+		ctx:        ctx,
+		client:     client,
+		serializer: serializer,
+	}
+}
+
+type EventDispatcher[E event.Event] struct {
+	ctx        context.Context
+	client     ClientContext
+	serializer event.Serializer[E]
+}
+
+func (d *EventDispatcher[E]) Dispatch(e E) error {
+	payload, err := d.serializer.Serialize(e)
+	if err != nil {
+		return err
+	}
+
+	eventID, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	_, err = d.client.ExecContext(d.ctx, `
+		INSERT INTO outbox (event_id, event_type, payload, created_at)
+		VALUES (?, ?, ?, ?)
+	`, eventID.String(), e.Type(), payload, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to store event %s in outbox: %w", e.Type(), err)
+	}
+
+	return nil
+}
+
+func NewOutboxStorage(db *sqlx.DB) outbox.Storage {
+	return &outboxStorage{db: db}
+}
+
+type outboxStorage struct {
+	db *sqlx.DB
+}
+
+type OutboxRow struct {
+	ID        int64     `db:"id"`
+	EventID   string    `db:"event_id"`
+	EventType string    `db:"event_type"`
+	Payload   string    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ProcessPending блокирует пачку неотправленных событий (SKIP LOCKED позволяет нескольким репликам
+// работать параллельно), передаёт их в handler и помечает отправленными при успехе
+func (s *outboxStorage) ProcessPending(ctx context.Context, limit int, handler func(events []outbox.Event) error) (int, error) {
+	processed := 0
+	err := ExecuteInTransaction(ctx, s.db, func(tx *sqlx.Tx) error {
+		var rows []OutboxRow
+		err := tx.SelectContext(ctx, &rows, `
+			SELECT id, event_id, event_type, payload, created_at
+			FROM outbox
+			WHERE sent_at IS NULL
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		`, limit)
+		if err != nil {
+			return fmt.Errorf("failed to select pending events: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		events := make([]outbox.Event, 0, len(rows))
+		placeholders := make([]string, 0, len(rows))
+		args := make([]interface{}, 0, len(rows)+1)
+		args = append(args, time.Now())
+		for _, row := range rows {
+			events = append(events, outbox.Event{
+				ID:        row.EventID,
+				Type:      row.EventType,
+				Payload:   row.Payload,
+				CreatedAt: row.CreatedAt,
+			})
+			placeholders = append(placeholders, "?")
+			args = append(args, row.ID)
+		}
+
+		if err = handler(events); err != nil {
+			return err
+		}
+
+		query := fmt.Sprintf("UPDATE outbox SET sent_at = ? WHERE id IN (%s)", strings.Join(placeholders, ", "))
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to mark events as sent: %w", err)
+		}
+
+		processed = len(rows)
+		return nil
+	})
+	return processed, err
+}
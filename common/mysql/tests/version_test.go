@@ -0,0 +1,23 @@
+package tests
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"common/mysql"
+)
+
+func TestCheckVersionedUpdate(t *testing.T) {
+	errConflict := errors.New("version conflict")
+
+	t.Run("Pass when row was updated", func(t *testing.T) {
+		require.NoError(t, mysql.CheckVersionedUpdate(driver.RowsAffected(1), errConflict))
+	})
+
+	t.Run("Return conflict when row was not updated", func(t *testing.T) {
+		require.ErrorIs(t, mysql.CheckVersionedUpdate(driver.RowsAffected(0), errConflict), errConflict)
+	})
+}
@@ -0,0 +1,20 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CheckVersionedUpdate проверяет результат UPDATE ... WHERE id = ? AND version = ?.
+// Если строка не обновилась, её изменили или удалили после того, как она была прочитана,
+// и возвращается errConflict - ошибка конфликта версий из доменной модели сервиса
+func CheckVersionedUpdate(result sql.Result, errConflict error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errConflict
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type Event struct {
+	ID        string
+	Type      string
+	Payload   string
+	CreatedAt time.Time
+}
+
+type Storage interface {
+	ProcessPending(ctx context.Context, limit int, handler func(events []Event) error) (int, error)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+func NewRelay(
+	storage Storage,
+	publisher Publisher,
+	logger *log.Logger,
+	interval time.Duration,
+	batchSize int,
+) *Relay {
+	return &Relay{
+		storage:   storage,
+		publisher: publisher,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Relay периодически публикует события из outbox, пока не будет отменён контекст
+type Relay struct {
+	storage   Storage
+	publisher Publisher
+	logger    *log.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := r.storage.ProcessPending(ctx, r.batchSize, func(events []Event) error {
+			for _, event := range events {
+				if err := r.publisher.Publish(ctx, event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			r.logger.WithError(err).Error("failed to relay outbox events")
+			return
+		}
+		if processed > 0 {
+			r.logger.WithField("count", processed).Debug("outbox events relayed")
+		}
+		if processed < r.batchSize {
+			return
+		}
+	}
+}
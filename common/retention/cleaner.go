@@ -0,0 +1,65 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const cleanupBatchSize = 1000
+
+type Storage interface {
+	// DeleteBefore удаляет не больше limit записей старше before и возвращает число удалённых
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+func NewCleaner(storage Storage, logger log.FieldLogger, interval, retention time.Duration) *Cleaner {
+	return &Cleaner{
+		storage:   storage,
+		logger:    logger,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Cleaner периодически удаляет записи старше retention пачками по cleanupBatchSize,
+// пока не будет отменён контекст
+type Cleaner struct {
+	storage   Storage
+	logger    log.FieldLogger
+	interval  time.Duration
+	retention time.Duration
+}
+
+func (c *Cleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Clean(ctx)
+		}
+	}
+}
+
+// Clean удаляет все записи старше retention
+func (c *Cleaner) Clean(ctx context.Context) {
+	before := time.Now().Add(-c.retention)
+	for ctx.Err() == nil {
+		deleted, err := c.storage.DeleteBefore(ctx, before, cleanupBatchSize)
+		if err != nil {
+			c.logger.WithError(err).Error("failed to delete expired records")
+			return
+		}
+		if deleted > 0 {
+			c.logger.WithField("count", deleted).Debug("expired records deleted")
+		}
+		if deleted < cleanupBatchSize {
+			return
+		}
+	}
+}
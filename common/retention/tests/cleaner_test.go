@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"common/retention"
+)
+
+func TestCleaner(t *testing.T) {
+	t.Run("Delete in batches until storage is drained", func(t *testing.T) {
+		storage := &fakeStorage{remaining: 2500}
+		cleaner := retention.NewCleaner(storage, log.New(), time.Hour, 24*time.Hour)
+
+		startedAt := time.Now()
+		cleaner.Clean(context.Background())
+
+		require.Equal(t, 0, storage.remaining)
+		require.Len(t, storage.calls, 3)
+		for _, before := range storage.calls {
+			require.WithinDuration(t, startedAt.Add(-24*time.Hour), before, time.Second)
+		}
+	})
+
+	t.Run("Stop when storage fails", func(t *testing.T) {
+		storage := &fakeStorage{remaining: 2500, err: context.DeadlineExceeded}
+		cleaner := retention.NewCleaner(storage, log.New(), time.Hour, time.Hour)
+
+		cleaner.Clean(context.Background())
+
+		require.Len(t, storage.calls, 1)
+	})
+}
+
+type fakeStorage struct {
+	remaining int
+	err       error
+	calls     []time.Time
+}
+
+func (s *fakeStorage) DeleteBefore(_ context.Context, before time.Time, limit int) (int, error) {
+	s.calls = append(s.calls, before)
+	if s.err != nil {
+		return 0, s.err
+	}
+	deleted := min(s.remaining, limit)
+	s.remaining -= deleted
+	return deleted, nil
+}
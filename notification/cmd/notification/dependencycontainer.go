@@ -1,16 +1,46 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"common/amqp"
+	commonmysql "common/mysql"
+	"common/outbox"
+
+	appservice "notification/pkg/application/service"
+	"notification/pkg/infrastructure/event"
+	"notification/pkg/infrastructure/mysql"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
+	logger *log.Logger,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	uow := mysql.NewUnitOfWork(connContainer.db, event.NewJSONSerializer())
+
+	publisher, err := amqp.NewPublisher(connContainer.amqpConnection, appID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		db:                  connContainer.db,
+		NotificationService: appservice.NewNotificationService(uow),
+		OutboxRelay: outbox.NewRelay(
+			commonmysql.NewOutboxStorage(connContainer.db),
+			publisher,
+			logger,
+			config.OutboxRelayInterval,
+			config.OutboxBatchSize,
+		),
 	}, nil
 }
 
 type dependencyContainer struct {
 	db *sqlx.DB
+
+	NotificationService appservice.NotificationService
+	OutboxRelay         *outbox.Relay
 }
@@ -30,10 +30,13 @@ func service(
 				return errors.Wrap(err, "failed to init connections")
 			}
 
-			container, err := newDependencyContainer(config, connContainer)
+			container, err := newDependencyContainer(config, logger, connContainer)
 			if err != nil {
 				return errors.Wrap(err, "failed to init dependencies")
 			}
+
+			go container.OutboxRelay.Run(c.Context)
+
 			return startGRPCServer(c.Context, config, logger, container)
 		},
 	}
@@ -43,11 +46,11 @@ func startGRPCServer(
 	ctx context.Context,
 	config *config,
 	logger *log.Logger,
-	_ *dependencyContainer,
+	container *dependencyContainer,
 ) error {
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)))
 
-	api.RegisterNotificationInternalServiceServer(grpcServer, transport.NewInternalAPI())
+	api.RegisterNotificationInternalServiceServer(grpcServer, transport.NewInternalAPI(container.NotificationService))
 
 	listener, err := net.Listen("tcp", config.ServeGRPCAddress)
 	if err != nil {
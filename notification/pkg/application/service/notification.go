@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"notification/pkg/domain/model"
+	domainservice "notification/pkg/domain/service"
+)
+
+type NotificationService interface {
+	RegisterRecipient(ctx context.Context, userID uuid.UUID, email, tg *string) error
+	ScheduleNotification(ctx context.Context, userID uuid.UUID, channel model.NotificationChannel, message string) (uuid.UUID, error)
+	SendNotification(ctx context.Context, notificationID uuid.UUID) error
+	MarkAsFailed(ctx context.Context, notificationID uuid.UUID, reason string) error
+}
+
+func NewNotificationService(uow UnitOfWork) NotificationService {
+	return &notificationService{
+		uow: uow,
+	}
+}
+
+type notificationService struct {
+	uow UnitOfWork
+}
+
+func (s *notificationService) RegisterRecipient(ctx context.Context, userID uuid.UUID, email, tg *string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RegisterRecipient(userID, email, tg)
+	})
+}
+
+func (s *notificationService) ScheduleNotification(ctx context.Context, userID uuid.UUID, channel model.NotificationChannel, message string) (notificationID uuid.UUID, err error) {
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		notificationID, err = s.domainService(ctx, provider).ScheduleNotification(userID, channel, message)
+		return err
+	})
+	return notificationID, err
+}
+
+func (s *notificationService) SendNotification(ctx context.Context, notificationID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SendNotification(notificationID)
+	})
+}
+
+func (s *notificationService) MarkAsFailed(ctx context.Context, notificationID uuid.UUID, reason string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).MarkAsFailed(notificationID, reason)
+	})
+}
+
+func (s *notificationService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Notification {
+	return domainservice.NewNotificationService(provider.NotificationRepository(ctx), provider.EventDispatcher(ctx))
+}
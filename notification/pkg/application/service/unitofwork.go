@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"notification/pkg/domain/model"
+	domainservice "notification/pkg/domain/service"
+)
+
+type RepositoryProvider interface {
+	NotificationRepository(ctx context.Context) model.NotificationRepository
+	EventDispatcher(ctx context.Context) domainservice.EventDispatcher
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
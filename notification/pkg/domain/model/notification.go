@@ -11,6 +11,8 @@ var (
 	ErrNotificationNotFound = errors.New("notification not found")
 	ErrRecipientNotFound    = errors.New("recipient not found")
 	ErrUnsupportedChannel   = errors.New("recipient does not support this channel")
+	// ErrVersionConflict - уведомление изменили после того, как его прочитали, операцию можно повторить
+	ErrVersionConflict = errors.New("notification was modified concurrently")
 )
 
 type NotificationStatus int
@@ -38,6 +40,8 @@ type Notification struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	SentAt        *time.Time
+	// Version защищает смену статуса отправки от гонки между повторными попытками, 0 - уведомление ещё не сохранено
+	Version int
 }
 
 type Recipient struct {
@@ -0,0 +1,17 @@
+package event
+
+import (
+	commonevent "common/event"
+	"notification/pkg/domain/model"
+	"notification/pkg/domain/service"
+)
+
+type Serializer = commonevent.Serializer[service.Event]
+
+func NewJSONSerializer() Serializer {
+	return commonevent.NewJSONSerializer[service.Event](
+		model.NotificationCreated{},
+		model.NotificationSent{},
+		model.NotificationFailed{},
+	)
+}
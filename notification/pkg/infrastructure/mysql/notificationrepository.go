@@ -1,42 +1,36 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
+	commonmysql "common/mysql"
 	"notification/pkg/domain/model"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 type NotificationRepository struct {
-	db *sqlx.DB
+	ctx    context.Context
+	client ClientContext
 }
 
-func NewNotificationRepository(db *sqlx.DB) *NotificationRepository {
-	return &NotificationRepository{db: db}
+func NewNotificationRepository(ctx context.Context, client ClientContext) *NotificationRepository {
+	return &NotificationRepository{
+		ctx:    ctx,
+		client: client,
+	}
 }
 
 func (r *NotificationRepository) NextID() (uuid.UUID, error) {
 	return uuid.NewUUID()
 }
 
+// StoreNotification сохраняет уведомление, проверяя, что с момента чтения его версия не изменилась.
+// При расхождении версий возвращает model.ErrVersionConflict
 func (r *NotificationRepository) StoreNotification(notification *model.Notification) error {
-	query := `
-		INSERT INTO notifications (id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			recipient_id = VALUES(recipient_id),
-			channel = VALUES(channel),
-			message = VALUES(message),
-			status = VALUES(status),
-			failure_reason = VALUES(failure_reason),
-			updated_at = VALUES(updated_at),
-			sent_at = VALUES(sent_at)
-	`
-
 	failureReason := (*string)(nil)
 	if notification.FailureReason != nil {
 		failureReason = notification.FailureReason
@@ -47,34 +41,61 @@ func (r *NotificationRepository) StoreNotification(notification *model.Notificat
 		sentAt = notification.SentAt
 	}
 
-	_, err := r.db.Exec(query,
-		notification.ID.String(),
-		notification.RecipientID.String(),
-		int(notification.Channel),
-		notification.Message,
-		int(notification.Status),
-		failureReason,
-		notification.CreatedAt,
-		notification.UpdatedAt,
-		sentAt,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to store notification: %w", err)
+	if notification.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO notifications (id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			notification.ID.String(),
+			notification.RecipientID.String(),
+			int(notification.Channel),
+			notification.Message,
+			int(notification.Status),
+			failureReason,
+			notification.CreatedAt,
+			notification.UpdatedAt,
+			sentAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store notification: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE notifications
+			SET recipient_id = ?, channel = ?, message = ?, status = ?, failure_reason = ?, updated_at = ?, sent_at = ?,
+				version = version + 1
+			WHERE id = ? AND version = ?`,
+			notification.RecipientID.String(),
+			int(notification.Channel),
+			notification.Message,
+			int(notification.Status),
+			failureReason,
+			notification.UpdatedAt,
+			sentAt,
+			notification.ID.String(),
+			notification.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store notification: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
 	}
+	notification.Version++
 
 	return nil
 }
 
 func (r *NotificationRepository) FindNotification(id uuid.UUID) (*model.Notification, error) {
 	query := `
-		SELECT id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at
+		SELECT id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at, version
 		FROM notifications
 		WHERE id = ?
 	`
 
 	var notification NotificationRow
 
-	err := r.db.Get(notification, query, id.String())
+	err := r.client.GetContext(r.ctx, &notification, query, id.String())
 	if err == sql.ErrNoRows {
 		return nil, model.ErrNotificationNotFound
 	}
@@ -105,7 +126,7 @@ func (r *NotificationRepository) StoreRecipient(recipient *model.Recipient) erro
 		tg = recipient.Tg
 	}
 
-	_, err := r.db.Exec(query,
+	_, err := r.client.ExecContext(r.ctx, query,
 		recipient.UserID.String(),
 		email,
 		tg,
@@ -127,7 +148,7 @@ func (r *NotificationRepository) FindRecipientByUserID(userID uuid.UUID) (*model
 
 	var recipient RecipientRow
 
-	err := r.db.Get(&recipient, query, userID.String())
+	err := r.client.GetContext(r.ctx, &recipient, query, userID.String())
 	if err == sql.ErrNoRows {
 		return nil, model.ErrRecipientNotFound
 	}
@@ -141,14 +162,14 @@ func (r *NotificationRepository) FindRecipientByUserID(userID uuid.UUID) (*model
 // GetPendingNotifications получает ожидающие отправки уведомления
 func (r *NotificationRepository) GetPendingNotifications() ([]*model.Notification, error) {
 	query := `
-		SELECT id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at
+		SELECT id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at, version
 		FROM notifications
 		WHERE status = ?
 		ORDER BY created_at ASC
 	`
 
 	var notifications []NotificationRow
-	err := r.db.Select(notifications, query, int(model.Pending))
+	err := r.client.SelectContext(r.ctx, &notifications, query, int(model.Pending))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending notifications: %w", err)
 	}
@@ -165,7 +186,7 @@ func (r *NotificationRepository) GetPendingNotifications() ([]*model.Notificatio
 func (r *NotificationRepository) UpdateNotificationStatus(id uuid.UUID, status model.NotificationStatus, failureReason *string, sentAt *time.Time) error {
 	query := `
 		UPDATE notifications 
-		SET status = ?, failure_reason = ?, sent_at = ?, updated_at = NOW()
+		SET status = ?, failure_reason = ?, sent_at = ?, updated_at = NOW(), version = version + 1
 		WHERE id = ?
 	`
 
@@ -179,7 +200,7 @@ func (r *NotificationRepository) UpdateNotificationStatus(id uuid.UUID, status m
 		sentAtValue = sentAt
 	}
 
-	_, err := r.db.Exec(query, int(status), failureReasonValue, sentAtValue, id.String())
+	_, err := r.client.ExecContext(r.ctx, query, int(status), failureReasonValue, sentAtValue, id.String())
 	if err != nil {
 		return fmt.Errorf("failed to update notification status: %w", err)
 	}
@@ -190,14 +211,14 @@ func (r *NotificationRepository) UpdateNotificationStatus(id uuid.UUID, status m
 // GetNotificationsByRecipientID получает уведомления по ID получателя
 func (r *NotificationRepository) GetNotificationsByRecipientID(recipientID uuid.UUID) ([]*model.Notification, error) {
 	query := `
-		SELECT id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at
+		SELECT id, recipient_id, channel, message, status, failure_reason, created_at, updated_at, sent_at, version
 		FROM notifications
 		WHERE recipient_id = ?
 		ORDER BY created_at DESC
 	`
 
 	var notifications []NotificationRow
-	err := r.db.Select(notifications, query, recipientID.String())
+	err := r.client.SelectContext(r.ctx, &notifications, query, recipientID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notifications by recipient: %w", err)
 	}
@@ -220,6 +241,7 @@ type NotificationRow struct {
 	CreatedAt     time.Time      `db:"created_at"`
 	UpdatedAt     time.Time      `db:"updated_at"`
 	SentAt        sql.NullTime   `db:"sent_at"`
+	Version       int            `db:"version"`
 }
 
 type RecipientRow struct {
@@ -254,6 +276,7 @@ func (r *NotificationRepository) rowToNotification(row *NotificationRow) *model.
 		CreatedAt:     row.CreatedAt,
 		UpdatedAt:     row.UpdatedAt,
 		SentAt:        sentAt,
+		Version:       row.Version,
 	}
 }
 
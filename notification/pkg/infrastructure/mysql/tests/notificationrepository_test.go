@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"common/mysql/mysqltest"
+	"notification/pkg/domain/model"
+	"notification/pkg/infrastructure/mysql"
+)
+
+func TestNotificationRepositoryStoreNotification(t *testing.T) {
+	newNotification := func(version int) *model.Notification {
+		return &model.Notification{
+			ID:          uuid.New(),
+			RecipientID: uuid.New(),
+			Message:     "Order paid",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+			Version:     version,
+		}
+	}
+
+	t.Run("Insert new notification with first version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		notification := newNotification(0)
+
+		err := mysql.NewNotificationRepository(context.Background(), client).StoreNotification(notification)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "INSERT INTO notifications")
+		require.Equal(t, 1, notification.Version)
+	})
+
+	t.Run("Update notification read at current version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		notification := newNotification(1)
+
+		err := mysql.NewNotificationRepository(context.Background(), client).StoreNotification(notification)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "WHERE id = ? AND version = ?")
+		require.Equal(t, 1, client.Execs[0].Args[len(client.Execs[0].Args)-1])
+		require.Equal(t, 2, notification.Version)
+	})
+
+	t.Run("Fail on version conflict", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 0}
+		notification := newNotification(1)
+
+		err := mysql.NewNotificationRepository(context.Background(), client).StoreNotification(notification)
+
+		require.ErrorIs(t, err, model.ErrVersionConflict)
+		require.Equal(t, 1, notification.Version)
+	})
+}
@@ -3,14 +3,23 @@ package transport
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	api "notification/api/server/notificationinternal"
+	"notification/pkg/application/service"
+	"notification/pkg/domain/model"
 )
 
-func NewInternalAPI() api.NotificationInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(notificationService service.NotificationService) api.NotificationInternalServiceServer {
+	return &internalAPI{
+		notificationService: notificationService,
+	}
 }
 
 type internalAPI struct {
+	notificationService service.NotificationService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +27,55 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) RegisterRecipient(
+	ctx context.Context,
+	request *api.RegisterRecipientRequest,
+) (*api.RegisterRecipientResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.notificationService.RegisterRecipient(ctx, userID, request.Email, request.Tg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RegisterRecipientResponse{}, nil
+}
+
+func (i *internalAPI) ScheduleNotification(
+	ctx context.Context,
+	request *api.ScheduleNotificationRequest,
+) (*api.ScheduleNotificationResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Message == "" {
+		return nil, status.Error(codes.InvalidArgument, "message is required")
+	}
+
+	notificationID, err := i.notificationService.ScheduleNotification(
+		ctx,
+		userID,
+		model.NotificationChannel(request.Channel),
+		request.Message,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ScheduleNotificationResponse{
+		NotificationID: notificationID.String(),
+	}, nil
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q: %s", value, err)
+	}
+	return id, nil
+}
@@ -9,6 +9,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"common/amqp"
 )
 
 type multiCloser struct {
@@ -47,7 +49,6 @@ func newConnectionsContainer(
 		if err != nil {
 			return fmt.Errorf("failed to init DB for migrations: %w", err)
 		}
-		defer db.Close()
 
 		if err = applyMigrations(db.DB, pathToMigrations); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
@@ -56,17 +57,52 @@ func newConnectionsContainer(
 		multiCloser.Add(db)
 		container.db = db
 
-		// TODO: это конекшены к другим сервисам (в данном случае - gRPC)
-		testConnection, err := grpc.NewClient(
-			config.TestGRPCAddress,
+		amqpConnection, err := amqp.Dial(config.AMQPURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to AMQP: %w", err)
+		}
+		multiCloser.Add(amqpConnection)
+		container.amqpConnection = amqpConnection
+
+		productConnection, err := grpc.NewClient(
+			config.ProductGRPCAddress,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create product service client: %w", err)
+		}
+		multiCloser.Add(productConnection)
+		container.productConnection = productConnection
+
+		paymentConnection, err := grpc.NewClient(
+			config.PaymentGRPCAddress,
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
 		)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to create payment service client: %w", err)
 		}
+		multiCloser.Add(paymentConnection)
+		container.paymentConnection = paymentConnection
 
-		multiCloser.Add(testConnection)
-		container.testConnection = testConnection
+		notificationConnection, err := grpc.NewClient(
+			config.NotificationGRPCAddress,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create notification service client: %w", err)
+		}
+		multiCloser.Add(notificationConnection)
+		container.notificationConnection = notificationConnection
+
+		userConnection, err := grpc.NewClient(
+			config.UserGRPCAddress,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create user service client: %w", err)
+		}
+		multiCloser.Add(userConnection)
+		container.userConnection = userConnection
 
 		return nil
 	}
@@ -75,8 +111,12 @@ func newConnectionsContainer(
 }
 
 type connectionsContainer struct {
-	db             *sqlx.DB
-	testConnection grpc.ClientConnInterface
+	db                     *sqlx.DB
+	amqpConnection         *amqp.Connection
+	productConnection      *grpc.ClientConn
+	paymentConnection      *grpc.ClientConn
+	notificationConnection *grpc.ClientConn
+	userConnection         *grpc.ClientConn
 }
 
 func InitMySQL(cfg *config) (db *sqlx.DB, err error) {
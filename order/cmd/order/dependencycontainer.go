@@ -1,18 +1,155 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
 
-// TODO: добавить зависимости
+	commonamqp "common/amqp"
+	"common/idempotency"
+	commonmysql "common/mysql"
+	"common/outbox"
+	"common/retention"
+
+	"order/pkg/application/query"
+	appservice "order/pkg/application/service"
+	"order/pkg/infrastructure/checkout"
+	"order/pkg/infrastructure/event"
+	"order/pkg/infrastructure/expiry"
+	"order/pkg/infrastructure/grpcclient"
+	"order/pkg/infrastructure/inbox"
+	"order/pkg/infrastructure/mysql"
+	"order/pkg/infrastructure/transport"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
+	logger *log.Logger,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	serializer := event.NewJSONSerializer()
+	uow := mysql.NewUnitOfWork(connContainer.db, serializer)
+	luow := mysql.NewLockableUnitOfWork(connContainer.db, serializer, config.LockTimeout)
+	productCatalog := grpcclient.NewProductCatalog(connContainer.productConnection)
+	customerDirectory := grpcclient.NewCachedCustomerDirectory(
+		grpcclient.NewCustomerDirectory(connContainer.userConnection),
+		config.CustomerCacheTTL,
+	)
+	checkoutService := appservice.NewCheckoutService(
+		uow,
+		luow,
+		grpcclient.NewPaymentGateway(connContainer.paymentConnection),
+		grpcclient.NewNotificationGateway(connContainer.notificationConnection),
+		config.CheckoutMaxAttempts,
+	)
+
+	idempotencyStorage := commonmysql.NewIdempotencyStorage(connContainer.db)
+
+	publisher, err := commonamqp.NewPublisher(connContainer.amqpConnection, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentEventConsumer, err := commonamqp.NewConsumer(
+		connContainer.amqpConnection,
+		commonamqp.ConsumerConfig{
+			Queue:             config.PaymentEventsQueue,
+			Source:            "payment",
+			EventTypes:        inbox.PaymentEventTypes,
+			Prefetch:          config.ConsumerPrefetch,
+			RetryDelay:        config.ConsumerRetryDelay,
+			ReconnectDelay:    config.ConsumerReconnectDelay,
+			LagReportInterval: config.ConsumerLagReportInterval,
+		},
+		inbox.NewPaymentEventHandler(appservice.NewPaymentEventService(luow)),
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		db:                    connContainer.db,
+		OrderService:          appservice.NewOrderService(uow, luow, productCatalog, customerDirectory),
+		OrderQueryService:     mysql.NewOrderQueryService(connContainer.db),
+		CheckoutService:       checkoutService,
+		CheckoutQueryService:  mysql.NewCheckoutQueryService(connContainer.db),
+		PromoCodeService:      appservice.NewPromoCodeService(uow, luow),
+		PromoCodeQueryService: mysql.NewPromoCodeQueryService(connContainer.db),
+		TaxRuleService:        appservice.NewTaxRuleService(uow),
+		TaxRuleQueryService:   mysql.NewTaxRuleQueryService(connContainer.db),
+		ReturnService:         appservice.NewReturnService(uow, luow),
+		ReturnQueryService:    mysql.NewReturnQueryService(connContainer.db),
+		ShipmentService:       appservice.NewShipmentService(uow, luow),
+		ShipmentQueryService:  mysql.NewShipmentQueryService(connContainer.db),
+		OutboxRelay: outbox.NewRelay(
+			commonmysql.NewOutboxStorage(connContainer.db),
+			publisher,
+			logger,
+			config.OutboxRelayInterval,
+			config.OutboxBatchSize,
+		),
+		InboxCleaner: retention.NewCleaner(
+			commonmysql.NewInboxStorage(connContainer.db),
+			logger.WithField("table", "inbox"),
+			config.InboxCleanupInterval,
+			config.InboxRetention,
+		),
+		PaymentEventConsumer: paymentEventConsumer,
+		OrderExpiryWorker: expiry.NewWorker(
+			appservice.NewOrderExpiryService(
+				uow,
+				// Нулевой таймаут: заказ, заблокированный другой репликой, пропускается, а не ожидается
+				mysql.NewLockableUnitOfWork(connContainer.db, serializer, 0),
+			),
+			logger,
+			config.OrderExpiryInterval,
+			config.OrderExpiryTTL,
+			config.OrderExpiryBatchSize,
+		),
+		CheckoutResumer: checkout.NewResumer(
+			checkoutService,
+			logger,
+			config.CheckoutResumeInterval,
+			config.CheckoutRetryDelay,
+			config.CheckoutBatchSize,
+		),
+		IdempotencyInterceptor: idempotency.NewInterceptor(
+			idempotencyStorage,
+			logger,
+			config.IdempotencyRetention,
+			config.IdempotencyPendingTimeout,
+			transport.IdempotentMethods,
+		),
+		IdempotencyCleaner: retention.NewCleaner(
+			idempotencyStorage,
+			logger.WithField("table", "idempotency_keys"),
+			config.IdempotencyCleanupInterval,
+			config.IdempotencyRetention,
+		),
 	}, nil
 }
 
 type dependencyContainer struct {
 	db *sqlx.DB
+
+	OrderService          appservice.OrderService
+	OrderQueryService     query.OrderQueryService
+	CheckoutService       appservice.CheckoutService
+	CheckoutQueryService  query.CheckoutQueryService
+	PromoCodeService      appservice.PromoCodeService
+	PromoCodeQueryService query.PromoCodeQueryService
+	TaxRuleService        appservice.TaxRuleService
+	TaxRuleQueryService   query.TaxRuleQueryService
+	ReturnService         appservice.ReturnService
+	ReturnQueryService    query.ReturnQueryService
+	ShipmentService       appservice.ShipmentService
+	ShipmentQueryService  query.ShipmentQueryService
+	OutboxRelay           *outbox.Relay
+	PaymentEventConsumer  *commonamqp.Consumer
+	InboxCleaner          *retention.Cleaner
+	OrderExpiryWorker     *expiry.Worker
+	CheckoutResumer       *checkout.Resumer
+
+	IdempotencyInterceptor *idempotency.Interceptor
+	IdempotencyCleaner     *retention.Cleaner
 }
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"order/pkg/application/query"
+	"order/pkg/infrastructure/export"
+	"order/pkg/infrastructure/mysql"
+)
+
+// exportDateLayouts - форматы границ периода: дата (полночь UTC) или момент времени
+var exportDateLayouts = []string{time.DateOnly, time.RFC3339}
+
+func exportOrders(
+	config *config,
+	logger *log.Logger,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export orders with their items to CSV or JSON Lines",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "export orders created at or after this date (YYYY-MM-DD or RFC 3339)",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "export orders created before this date (YYYY-MM-DD or RFC 3339)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "status",
+				Usage: "export only orders in these statuses: open, pending, paid, cancelled",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "output format: csv or jsonl",
+				Value: string(export.FormatCSV),
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "output file, stdout if empty or -",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			spec, err := parseExportSpec(c)
+			if err != nil {
+				return err
+			}
+			format, err := export.ParseFormat(c.String("format"))
+			if err != nil {
+				return err
+			}
+
+			db, err := InitMySQL(config)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			output, closeOutput, err := openExportOutput(c.String("output"))
+			if err != nil {
+				return err
+			}
+			writer, err := export.NewWriter(format, output)
+			if err != nil {
+				_ = closeOutput()
+				return err
+			}
+
+			exported, err := export.NewExporter(mysql.NewOrderQueryService(db)).Export(c.Context, spec, writer)
+			if closeErr := closeOutput(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return fmt.Errorf("export failed after %d orders: %w", exported, err)
+			}
+
+			logger.WithFields(log.Fields{
+				"exported": exported,
+				"format":   format,
+			}).Info("Orders exported")
+			return nil
+		},
+	}
+}
+
+func parseExportSpec(c *cli.Context) (query.ListOrdersSpec, error) {
+	var spec query.ListOrdersSpec
+	var err error
+	if spec.CreatedFrom, err = parseExportDate(c.String("from")); err != nil {
+		return spec, fmt.Errorf("invalid --from: %w", err)
+	}
+	if spec.CreatedTo, err = parseExportDate(c.String("to")); err != nil {
+		return spec, fmt.Errorf("invalid --to: %w", err)
+	}
+	if spec.CreatedFrom != nil && spec.CreatedTo != nil && !spec.CreatedFrom.Before(*spec.CreatedTo) {
+		return spec, fmt.Errorf("--from must be before --to")
+	}
+
+	for _, value := range c.StringSlice("status") {
+		status, err := export.ParseStatus(value)
+		if err != nil {
+			return spec, err
+		}
+		spec.Statuses = append(spec.Statuses, status)
+	}
+	return spec, nil
+}
+
+func parseExportDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range exportDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return &date, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is neither YYYY-MM-DD nor RFC 3339", value)
+}
+
+// openExportOutput возвращает поток вывода и функцию его закрытия. Буферизацию делает export.Writer
+func openExportOutput(path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return file, file.Close, nil
+}
@@ -23,7 +23,8 @@ func main() {
 	defer stop()
 
 	if err := godotenv.Load(".env"); err != nil {
-		fmt.Println("Error loading .env file")
+		// stderr, чтобы не смешивать сообщение с выгрузкой export в stdout
+		fmt.Fprintln(os.Stderr, "Error loading .env file")
 	}
 
 	cnf, err := parseEnv()
@@ -64,6 +65,8 @@ func runApp(
 		Commands: []*cli.Command{
 			service(config, logger, closer),
 			migrate(config, logger),
+			purge(config, logger),
+			exportOrders(config, logger),
 		},
 	}
 
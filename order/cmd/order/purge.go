@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"order/pkg/infrastructure/mysql"
+)
+
+func purge(
+	config *config,
+	logger *log.Logger,
+) *cli.Command {
+	return &cli.Command{
+		Name:  "purge",
+		Usage: "Permanently remove orders deleted longer ago than the retention period",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:  "retention",
+				Usage: "how long deleted orders are kept",
+				Value: config.PurgeRetention,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			retention := c.Duration("retention")
+			if retention <= 0 {
+				return fmt.Errorf("retention must be positive, got %s", retention)
+			}
+
+			db, err := InitMySQL(config)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			deletedBefore := time.Now().Add(-retention)
+			purged, err := mysql.NewOrderPurger(db, config.PurgeBatchSize).Purge(c.Context, deletedBefore)
+			if err != nil {
+				return err
+			}
+
+			logger.WithFields(log.Fields{
+				"purged":        purged,
+				"deletedBefore": deletedBefore,
+			}).Info("Deleted orders purged")
+			return nil
+		},
+	}
+}
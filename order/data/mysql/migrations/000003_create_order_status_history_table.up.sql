@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS order_status_history
+(
+    `id`         BIGINT NOT NULL AUTO_INCREMENT,
+    `order_id`   CHAR(36) NOT NULL,
+    `old_status` INT NOT NULL,
+    `new_status` INT NOT NULL,
+    `changed_by` VARCHAR(255) NOT NULL,
+    `reason`     VARCHAR(255) NOT NULL DEFAULT '',
+    `changed_at` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_order_id_changed_at` (`order_id`, `changed_at`),
+    FOREIGN KEY (`order_id`) REFERENCES `orders`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
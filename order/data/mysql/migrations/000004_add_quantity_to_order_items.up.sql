@@ -0,0 +1,2 @@
+ALTER TABLE order_items
+    ADD COLUMN `quantity` INT NOT NULL DEFAULT 1 AFTER `price`;
@@ -0,0 +1,2 @@
+ALTER TABLE order_items
+    ADD COLUMN `currency` CHAR(3) NOT NULL DEFAULT 'RUB' AFTER `price`;
@@ -0,0 +1,2 @@
+ALTER TABLE order_items
+    DROP COLUMN `product_name`;
\ No newline at end of file
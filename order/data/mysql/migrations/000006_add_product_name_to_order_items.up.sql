@@ -0,0 +1,2 @@
+ALTER TABLE order_items
+    ADD COLUMN `product_name` VARCHAR(100) NOT NULL DEFAULT '' AFTER `product_id`;
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    DROP INDEX `idx_customer_id_created_at`;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    ADD INDEX `idx_customer_id_created_at` (`customer_id`, `created_at`, `id`);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS checkout_sagas
+(
+    `id`             CHAR(36) NOT NULL,
+    `order_id`       CHAR(36) NOT NULL,
+    `customer_id`    CHAR(36) NOT NULL,
+    `amount`         DECIMAL(12,2) NOT NULL,
+    `currency`       CHAR(3) NOT NULL,
+    `state`          INT NOT NULL,
+    `payment_id`     CHAR(36) NULL DEFAULT NULL,
+    `failure_reason` TEXT NULL DEFAULT NULL,
+    `attempts`       INT NOT NULL DEFAULT 0,
+    `created_at`     DATETIME(6) NOT NULL,
+    `updated_at`     DATETIME(6) NOT NULL,
+    `version`        INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    INDEX `idx_order_id` (`order_id`),
+    INDEX `idx_state_updated_at` (`state`, `updated_at`),
+    FOREIGN KEY (`order_id`) REFERENCES `orders`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
@@ -0,0 +1,8 @@
+ALTER TABLE orders
+    DROP INDEX `idx_promo_code_customer_id`,
+    DROP COLUMN `promo_code`,
+    DROP COLUMN `discount_percent`,
+    DROP COLUMN `discount_amount`,
+    DROP COLUMN `discount_currency`;
+
+DROP TABLE IF EXISTS promo_codes;
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS promo_codes
+(
+    `code`                  VARCHAR(50) NOT NULL,
+    `discount_percent`      INT NULL DEFAULT NULL,
+    `discount_amount`       DECIMAL(12,2) NULL DEFAULT NULL,
+    `discount_currency`     CHAR(3) NULL DEFAULT NULL,
+    `valid_from`            DATETIME NULL DEFAULT NULL,
+    `valid_to`              DATETIME NULL DEFAULT NULL,
+    `max_uses`              INT NOT NULL DEFAULT 0,
+    `max_uses_per_customer` INT NOT NULL DEFAULT 0,
+    `created_at`            DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `version`               INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (`code`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE orders
+    ADD COLUMN `promo_code`        VARCHAR(50) NULL DEFAULT NULL,
+    ADD COLUMN `discount_percent`  INT NULL DEFAULT NULL,
+    ADD COLUMN `discount_amount`   DECIMAL(12,2) NULL DEFAULT NULL,
+    ADD COLUMN `discount_currency` CHAR(3) NULL DEFAULT NULL,
+    ADD INDEX `idx_promo_code_customer_id` (`promo_code`, `customer_id`);
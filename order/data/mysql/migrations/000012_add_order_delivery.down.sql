@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    DROP COLUMN `shipping_method`,
+    DROP COLUMN `shipping_cost`,
+    DROP COLUMN `shipping_currency`,
+    DROP COLUMN `delivery_address`;
\ No newline at end of file
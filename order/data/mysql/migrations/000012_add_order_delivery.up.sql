@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    ADD COLUMN `shipping_method`   TINYINT NULL DEFAULT NULL,
+    ADD COLUMN `shipping_cost`     DECIMAL(10,2) NULL DEFAULT NULL,
+    ADD COLUMN `shipping_currency` CHAR(3) NULL DEFAULT NULL,
+    ADD COLUMN `delivery_address`  JSON NULL DEFAULT NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE orders
+    DROP COLUMN `net_total`,
+    DROP COLUMN `tax_total`,
+    DROP COLUMN `gross_total`,
+    DROP COLUMN `tax_currency`,
+    DROP COLUMN `tax_lines`;
+
+ALTER TABLE order_items
+    DROP COLUMN `category`;
+
+DROP TABLE IF EXISTS tax_rules;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tax_rules
+(
+    `category` VARCHAR(64) NOT NULL DEFAULT '',
+    `country`  CHAR(2) NOT NULL DEFAULT '',
+    `rate`     SMALLINT UNSIGNED NOT NULL,
+    `pricing`  TINYINT NOT NULL,
+    `rounding` TINYINT NOT NULL,
+    PRIMARY KEY (`country`, `category`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE order_items
+    ADD COLUMN `category` VARCHAR(64) NOT NULL DEFAULT '' AFTER `product_name`;
+
+ALTER TABLE orders
+    ADD COLUMN `net_total`    DECIMAL(12,2) NULL DEFAULT NULL,
+    ADD COLUMN `tax_total`    DECIMAL(12,2) NULL DEFAULT NULL,
+    ADD COLUMN `gross_total`  DECIMAL(12,2) NULL DEFAULT NULL,
+    ADD COLUMN `tax_currency` CHAR(3) NULL DEFAULT NULL,
+    ADD COLUMN `tax_lines`    JSON NULL DEFAULT NULL;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS returns
+(
+    `id`          CHAR(36) NOT NULL,
+    `order_id`    CHAR(36) NOT NULL,
+    `customer_id` CHAR(36) NOT NULL,
+    `status`      INT NOT NULL,
+    `reason`      VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at`  DATETIME(6) NOT NULL,
+    `updated_at`  DATETIME(6) NOT NULL,
+    `version`     INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    INDEX `idx_order_id_created_at` (`order_id`, `created_at`),
+    FOREIGN KEY (`order_id`) REFERENCES `orders`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS return_items
+(
+    `return_id`    CHAR(36) NOT NULL,
+    `item_id`      CHAR(36) NOT NULL,
+    `product_id`   CHAR(36) NOT NULL,
+    `product_name` VARCHAR(255) NOT NULL DEFAULT '',
+    `price`        DECIMAL(12,2) NOT NULL,
+    `quantity`     INT NOT NULL,
+    `refund`       DECIMAL(12,2) NOT NULL,
+    `currency`     CHAR(3) NOT NULL,
+    PRIMARY KEY (`return_id`, `item_id`),
+    FOREIGN KEY (`return_id`) REFERENCES `returns`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS return_status_history
+(
+    `id`         BIGINT NOT NULL AUTO_INCREMENT,
+    `return_id`  CHAR(36) NOT NULL,
+    `old_status` INT NOT NULL,
+    `new_status` INT NOT NULL,
+    `changed_by` VARCHAR(255) NOT NULL,
+    `reason`     VARCHAR(255) NOT NULL DEFAULT '',
+    `changed_at` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_return_id_changed_at` (`return_id`, `changed_at`),
+    FOREIGN KEY (`return_id`) REFERENCES `returns`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
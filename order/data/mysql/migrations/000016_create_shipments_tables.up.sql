@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS shipments
+(
+    `id`              CHAR(36) NOT NULL,
+    `order_id`        CHAR(36) NOT NULL,
+    `customer_id`     CHAR(36) NOT NULL,
+    `status`          INT NOT NULL,
+    `carrier`         VARCHAR(255) NOT NULL DEFAULT '',
+    `tracking_number` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at`      DATETIME(6) NOT NULL,
+    `updated_at`      DATETIME(6) NOT NULL,
+    `shipped_at`      DATETIME(6) NULL DEFAULT NULL,
+    `delivered_at`    DATETIME(6) NULL DEFAULT NULL,
+    `version`         INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    INDEX `idx_order_id_created_at` (`order_id`, `created_at`),
+    FOREIGN KEY (`order_id`) REFERENCES `orders`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS shipment_items
+(
+    `shipment_id`  CHAR(36) NOT NULL,
+    `item_id`      CHAR(36) NOT NULL,
+    `product_id`   CHAR(36) NOT NULL,
+    `product_name` VARCHAR(255) NOT NULL DEFAULT '',
+    `price`        DECIMAL(12,2) NOT NULL,
+    `quantity`     INT NOT NULL,
+    `currency`     CHAR(3) NOT NULL,
+    PRIMARY KEY (`shipment_id`, `item_id`),
+    FOREIGN KEY (`shipment_id`) REFERENCES `shipments`(`id`) ON DELETE CASCADE
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP INDEX `idx_status_pending_at`,
+    DROP COLUMN `pending_at`;
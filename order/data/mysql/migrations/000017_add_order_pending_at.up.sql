@@ -0,0 +1,15 @@
+ALTER TABLE orders
+    ADD COLUMN `pending_at` DATETIME(6) NULL DEFAULT NULL,
+    ADD INDEX `idx_status_pending_at` (`status`, `pending_at`);
+
+UPDATE orders o
+SET o.pending_at = (
+    SELECT MAX(h.changed_at)
+    FROM order_status_history h
+    WHERE h.order_id = o.id AND h.new_status = 1
+)
+WHERE o.status = 1;
+
+UPDATE orders
+SET pending_at = updated_at
+WHERE status = 1 AND pending_at IS NULL;
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+type Checkout struct {
+	ID         uuid.UUID
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Amount     model.Money
+	State      model.CheckoutSagaState
+	// PaymentID равен uuid.Nil, пока платёж не создан
+	PaymentID     uuid.UUID
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type CheckoutQueryService interface {
+	FindCheckout(ctx context.Context, checkoutID uuid.UUID) (*Checkout, error)
+}
@@ -0,0 +1,49 @@
+package query
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// OrderCursor указывает на последний заказ страницы. Заказы упорядочены по
+// (created_at, id) по убыванию, поэтому пара однозначно задаёт начало следующей страницы
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (c OrderCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseOrderCursor(value string) (*OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAtValue, idValue, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtValue)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(idValue)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &OrderCursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}, nil
+}
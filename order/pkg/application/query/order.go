@@ -0,0 +1,110 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+type Order struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Status     model.OrderStatus
+	Items      []Item
+	// PromoCode равен nil, если промокод не применён
+	PromoCode *model.AppliedPromoCode
+	// Delivery равен nil, пока доставка не указана
+	Delivery *model.Delivery
+	// Tax равен nil у заказов, созданных до появления налоговых правил
+	Tax       *model.OrderTax
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Subtotal, Discount, ShippingCost и Total считаются так же, как у model.Order
+func (o *Order) Subtotal() (model.Money, error) {
+	return o.toModel().Subtotal()
+}
+
+func (o *Order) Discount() (model.Money, error) {
+	return o.toModel().Discount()
+}
+
+func (o *Order) ShippingCost() (model.Money, error) {
+	return o.toModel().ShippingCost()
+}
+
+func (o *Order) Total() (model.Money, error) {
+	return o.toModel().Total()
+}
+
+func (o *Order) toModel() *model.Order {
+	items := make([]model.Item, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, model.Item{
+			Price:    item.Price,
+			Quantity: item.Quantity,
+		})
+	}
+	return &model.Order{
+		Items:     items,
+		PromoCode: o.PromoCode,
+		Delivery:  o.Delivery,
+		Tax:       o.Tax,
+	}
+}
+
+type Item struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Category  string
+	Price     model.Money
+	Quantity  int
+}
+
+func (i Item) Total() model.Money {
+	return i.Price.Multiply(int64(i.Quantity))
+}
+
+type StatusChange struct {
+	OldStatus model.OrderStatus
+	NewStatus model.OrderStatus
+	ChangedBy string
+	Reason    string
+	ChangedAt time.Time
+}
+
+const (
+	DefaultOrdersPageSize = 50
+	MaxOrdersPageSize     = 500
+)
+
+// ListOrdersSpec - фильтры и параметры страницы для ListOrders. Пустые поля не фильтруют
+type ListOrdersSpec struct {
+	CustomerID *uuid.UUID
+	Statuses   []model.OrderStatus
+	// CreatedFrom включительно, CreatedTo не включительно
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	PageSize int
+	// After - курсор из NextCursor предыдущей страницы, nil для первой страницы
+	After *OrderCursor
+}
+
+type OrderPage struct {
+	Orders []Order
+	// NextCursor равен nil на последней странице
+	NextCursor *OrderCursor
+}
+
+type OrderQueryService interface {
+	FindOrder(ctx context.Context, orderID uuid.UUID) (*Order, error)
+	// ListOrders возвращает неудалённые заказы от новых к старым
+	ListOrders(ctx context.Context, spec ListOrdersSpec) (*OrderPage, error)
+	FindStatusHistory(ctx context.Context, orderID uuid.UUID) ([]StatusChange, error)
+}
@@ -0,0 +1,24 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"order/pkg/domain/model"
+)
+
+type PromoCode struct {
+	Code               string
+	Discount           model.Discount
+	ValidFrom          *time.Time
+	ValidTo            *time.Time
+	MaxUses            int
+	MaxUsesPerCustomer int
+	// Uses - число неудалённых и неотменённых заказов с этим кодом
+	Uses      int
+	CreatedAt time.Time
+}
+
+type PromoCodeQueryService interface {
+	FindPromoCode(ctx context.Context, code string) (*PromoCode, error)
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+type Return struct {
+	ID         uuid.UUID
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Status     model.ReturnStatus
+	Items      []ReturnItem
+	Reason     string
+	// RefundAmount - сумма к возврату покупателю по всем позициям
+	RefundAmount model.Money
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ReturnItem struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Price     model.Money
+	Quantity  int
+	Refund    model.Money
+}
+
+type ReturnStatusChange struct {
+	OldStatus model.ReturnStatus
+	NewStatus model.ReturnStatus
+	ChangedBy string
+	Reason    string
+	ChangedAt time.Time
+}
+
+type ReturnQueryService interface {
+	FindReturn(ctx context.Context, returnID uuid.UUID) (*Return, error)
+	// ListOrderReturns возвращает все возвраты неудалённого заказа от старых к новым
+	ListOrderReturns(ctx context.Context, orderID uuid.UUID) ([]Return, error)
+	FindReturnStatusHistory(ctx context.Context, returnID uuid.UUID) ([]ReturnStatusChange, error)
+}
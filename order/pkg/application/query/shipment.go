@@ -0,0 +1,38 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+type Shipment struct {
+	ID             uuid.UUID
+	OrderID        uuid.UUID
+	CustomerID     uuid.UUID
+	Status         model.ShipmentStatus
+	Items          []ShipmentItem
+	Carrier        string
+	TrackingNumber string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ShippedAt      *time.Time
+	DeliveredAt    *time.Time
+}
+
+type ShipmentItem struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Price     model.Money
+	Quantity  int
+}
+
+type ShipmentQueryService interface {
+	FindShipment(ctx context.Context, shipmentID uuid.UUID) (*Shipment, error)
+	// ListOrderShipments возвращает все отправления неудалённого заказа от старых к новым
+	ListOrderShipments(ctx context.Context, orderID uuid.UUID) ([]Shipment, error)
+}
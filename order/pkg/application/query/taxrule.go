@@ -0,0 +1,12 @@
+package query
+
+import (
+	"context"
+
+	"order/pkg/domain/model"
+)
+
+type TaxRuleQueryService interface {
+	// ListTaxRules возвращает все правила, упорядоченные по стране и категории
+	ListTaxRules(ctx context.Context) ([]model.TaxRule, error)
+}
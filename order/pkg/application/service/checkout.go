@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+)
+
+var (
+	// ErrPaymentRejected - сервис payment отклонил запрос, повторять его бессмысленно
+	ErrPaymentRejected = errors.New("payment rejected")
+	// ErrNotificationRejected - сервис notification не может доставить уведомление, например, получатель не зарегистрирован
+	ErrNotificationRejected = errors.New("notification rejected")
+)
+
+type PaymentResult struct {
+	Completed     bool
+	FailureReason string
+}
+
+// PaymentGateway - вызовы сервиса payment. Все методы можно безопасно повторять.
+// Ошибки, которые не исчезнут при повторе, оборачивают ErrPaymentRejected
+type PaymentGateway interface {
+	InitiatePayment(ctx context.Context, orderID, customerID uuid.UUID, amount model.Money) (uuid.UUID, error)
+	ProcessPayment(ctx context.Context, paymentID uuid.UUID) (PaymentResult, error)
+	RefundPayment(ctx context.Context, paymentID uuid.UUID) error
+}
+
+type NotificationGateway interface {
+	NotifyCustomer(ctx context.Context, customerID uuid.UUID, message string) error
+}
+
+// CheckoutService проводит оформление заказа через сервисы payment и notification.
+// Состояние саги сохраняется после каждого шага, поэтому прерванное оформление
+// продолжается с того же шага через ResumeCheckouts
+type CheckoutService interface {
+	// Checkout начинает оформление и сразу пытается его завершить.
+	// Если внешний сервис недоступен, возвращает ID незавершённой саги без ошибки
+	Checkout(ctx context.Context, orderID uuid.UUID) (uuid.UUID, error)
+	// ResumeCheckouts продолжает незавершённые саги, которые не менялись с updatedBefore
+	ResumeCheckouts(ctx context.Context, updatedBefore time.Time, limit int) (int, error)
+}
+
+func NewCheckoutService(
+	uow UnitOfWork,
+	luow LockableUnitOfWork,
+	payments PaymentGateway,
+	notifications NotificationGateway,
+	maxStepAttempts int,
+) CheckoutService {
+	return &checkoutService{
+		uow:             uow,
+		luow:            luow,
+		payments:        payments,
+		notifications:   notifications,
+		maxStepAttempts: maxStepAttempts,
+	}
+}
+
+type checkoutService struct {
+	uow             UnitOfWork
+	luow            LockableUnitOfWork
+	payments        PaymentGateway
+	notifications   NotificationGateway
+	maxStepAttempts int
+}
+
+func (s *checkoutService) Checkout(ctx context.Context, orderID uuid.UUID) (sagaID uuid.UUID, err error) {
+	err = s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		sagaID, err = s.domainService(ctx, provider).StartCheckout(orderID)
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return sagaID, s.run(ctx, sagaID)
+}
+
+func (s *checkoutService) ResumeCheckouts(ctx context.Context, updatedBefore time.Time, limit int) (int, error) {
+	var sagaIDs []uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) (err error) {
+		sagaIDs, err = provider.CheckoutSagaRepository(ctx).FindUnfinished(updatedBefore, limit)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, sagaID := range sagaIDs {
+		if err = s.run(ctx, sagaID); err != nil {
+			return i, err
+		}
+	}
+	return len(sagaIDs), nil
+}
+
+// run выполняет шаги саги, пока она не завершится или очередной шаг не будет отложен
+func (s *checkoutService) run(ctx context.Context, sagaID uuid.UUID) error {
+	for {
+		saga, err := s.findSaga(ctx, sagaID)
+		if err != nil {
+			return err
+		}
+		if saga.State.IsFinished() {
+			return nil
+		}
+
+		err = s.step(ctx, saga)
+		if err == nil {
+			continue
+		}
+		if !isCheckoutTakenOver(err) {
+			err = s.stepFailed(ctx, saga, err)
+		}
+		if isCheckoutTakenOver(err) {
+			// Сагу уже продвинул другой экземпляр сервиса, он же её и продолжит
+			return nil
+		}
+		return err
+	}
+}
+
+func (s *checkoutService) step(ctx context.Context, saga *model.CheckoutSaga) error {
+	switch saga.State {
+	case model.CheckoutOrderPending:
+		paymentID, err := s.payments.InitiatePayment(ctx, saga.OrderID, saga.CustomerID, saga.Amount)
+		if errors.Is(err, ErrPaymentRejected) {
+			return s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+				return checkout.AbortCheckout(saga.ID, err.Error())
+			})
+		}
+		if err != nil {
+			return err
+		}
+		return s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+			return checkout.PaymentInitiated(saga.ID, paymentID)
+		})
+	case model.CheckoutPaymentInitiated:
+		result, err := s.payments.ProcessPayment(ctx, saga.PaymentID)
+		if errors.Is(err, ErrPaymentRejected) {
+			result = PaymentResult{FailureReason: err.Error()}
+		} else if err != nil {
+			return err
+		}
+		return s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+			if result.Completed {
+				return checkout.PaymentCompleted(saga.ID)
+			}
+			return checkout.PaymentDeclined(saga.ID, result.FailureReason)
+		})
+	case model.CheckoutOrderPaid:
+		err := s.notifications.NotifyCustomer(ctx, saga.CustomerID, fmt.Sprintf("Order %s has been paid: %s", saga.OrderID, saga.Amount))
+		// Клиент без адреса для уведомлений не должен мешать оформлению заказа
+		if err != nil && !errors.Is(err, ErrNotificationRejected) {
+			return err
+		}
+		return s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+			return checkout.CustomerNotified(saga.ID)
+		})
+	case model.CheckoutRefundingPayment:
+		if err := s.payments.RefundPayment(ctx, saga.PaymentID); err != nil {
+			return err
+		}
+		return s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+			return checkout.PaymentRefunded(saga.ID)
+		})
+	default:
+		return domainservice.ErrInvalidCheckoutState
+	}
+}
+
+// stepFailed откладывает шаг до следующего запуска. Когда попытки исчерпаны, шаги,
+// которые можно пропустить или компенсировать, завершаются, остальные повторяются дальше:
+// проведённый платёж нельзя бросить без возврата
+func (s *checkoutService) stepFailed(ctx context.Context, saga *model.CheckoutSaga, stepErr error) error {
+	var attempts int
+	err := s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+		failed, err := checkout.StepFailed(saga.ID, stepErr.Error())
+		if err != nil {
+			return err
+		}
+		attempts = failed.Attempts
+		return nil
+	})
+	if err != nil || attempts < s.maxStepAttempts {
+		return err
+	}
+
+	return s.execute(ctx, saga, func(checkout domainservice.Checkout) error {
+		switch saga.State {
+		case model.CheckoutOrderPending:
+			return checkout.AbortCheckout(saga.ID, stepErr.Error())
+		case model.CheckoutOrderPaid:
+			return checkout.CustomerNotified(saga.ID)
+		default:
+			return nil
+		}
+	})
+}
+
+// execute выполняет локальный шаг саги под блокировкой заказа, как и остальные изменения заказа
+func (s *checkoutService) execute(ctx context.Context, saga *model.CheckoutSaga, f func(checkout domainservice.Checkout) error) error {
+	return s.luow.Execute(ctx, orderLockName(saga.OrderID), func(provider RepositoryProvider) error {
+		return f(s.domainService(ctx, provider))
+	})
+}
+
+func isCheckoutTakenOver(err error) bool {
+	return errors.Is(err, domainservice.ErrInvalidCheckoutState) || errors.Is(err, model.ErrVersionConflict)
+}
+
+func (s *checkoutService) findSaga(ctx context.Context, sagaID uuid.UUID) (saga *model.CheckoutSaga, err error) {
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		saga, err = provider.CheckoutSagaRepository(ctx).Find(sagaID)
+		return err
+	})
+	return saga, err
+}
+
+func (s *checkoutService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Checkout {
+	return domainservice.NewCheckoutService(
+		provider.OrderRepository(ctx),
+		provider.CheckoutSagaRepository(ctx),
+		provider.PromoCodeRepository(ctx),
+		newOrderDomainService(ctx, provider),
+	)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// CustomerDirectory - проверка покупателей по сервису user.
+// Для неизвестных покупателей возвращает model.ErrCustomerNotFound, для удалённых - model.ErrCustomerDeleted
+type CustomerDirectory interface {
+	CheckCustomer(ctx context.Context, customerID uuid.UUID) error
+}
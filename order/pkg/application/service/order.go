@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+)
+
+type OrderService interface {
+	// CreateOrder создаёт заказ только для существующего и неудалённого покупателя
+	CreateOrder(ctx context.Context, customerID uuid.UUID, delivery *model.Delivery) (uuid.UUID, error)
+	DeleteOrder(ctx context.Context, orderID uuid.UUID) error
+	RestoreOrder(ctx context.Context, orderID uuid.UUID) error
+	SetStatus(ctx context.Context, orderID uuid.UUID, status model.OrderStatus, changedBy, reason string) error
+	CancelOrder(ctx context.Context, orderID uuid.UUID, cancelledBy, reason string) error
+
+	AddItem(ctx context.Context, orderID, productID uuid.UUID, quantity int) (uuid.UUID, error)
+	UpdateItemQuantity(ctx context.Context, orderID, itemID uuid.UUID, quantity int) error
+	DeleteItem(ctx context.Context, orderID, itemID uuid.UUID) error
+
+	SetDelivery(ctx context.Context, orderID uuid.UUID, delivery model.Delivery) error
+
+	// ReorderFrom создаёт открытый заказ с позициями заказа orderID по текущим ценам каталога.
+	// Товары, которых больше нет в каталоге, пропускаются и попадают в ReorderResult.Dropped
+	ReorderFrom(ctx context.Context, orderID uuid.UUID) (domainservice.ReorderResult, error)
+}
+
+func NewOrderService(
+	uow UnitOfWork,
+	luow LockableUnitOfWork,
+	productCatalog ProductCatalog,
+	customerDirectory CustomerDirectory,
+) OrderService {
+	return &orderService{
+		uow:               uow,
+		luow:              luow,
+		productCatalog:    productCatalog,
+		customerDirectory: customerDirectory,
+	}
+}
+
+type orderService struct {
+	uow               UnitOfWork
+	luow              LockableUnitOfWork
+	productCatalog    ProductCatalog
+	customerDirectory CustomerDirectory
+}
+
+func (s *orderService) CreateOrder(
+	ctx context.Context,
+	customerID uuid.UUID,
+	delivery *model.Delivery,
+) (orderID uuid.UUID, err error) {
+	if err = s.customerDirectory.CheckCustomer(ctx, customerID); err != nil {
+		return uuid.Nil, err
+	}
+
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		orderID, err = s.domainService(ctx, provider).CreateOrder(customerID, delivery)
+		return err
+	})
+	return orderID, err
+}
+
+func (s *orderService) DeleteOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteOrder(orderID)
+	})
+}
+
+func (s *orderService) RestoreOrder(ctx context.Context, orderID uuid.UUID) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RestoreOrder(orderID)
+	})
+}
+
+func (s *orderService) SetStatus(ctx context.Context, orderID uuid.UUID, status model.OrderStatus, changedBy, reason string) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SetStatus(orderID, status, changedBy, reason)
+	})
+}
+
+func (s *orderService) CancelOrder(ctx context.Context, orderID uuid.UUID, cancelledBy, reason string) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).CancelOrder(orderID, cancelledBy, reason)
+	})
+}
+
+// AddItem берёт цену и название товара из каталога, а не от клиента.
+// Каталог опрашивается до взятия блокировки, чтобы не держать её на время сетевого вызова
+func (s *orderService) AddItem(ctx context.Context, orderID, productID uuid.UUID, quantity int) (itemID uuid.UUID, err error) {
+	product, err := s.productCatalog.FindProduct(ctx, productID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	err = s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		itemID, err = s.domainService(ctx, provider).AddItem(orderID, *product, quantity)
+		return err
+	})
+	return itemID, err
+}
+
+func (s *orderService) UpdateItemQuantity(ctx context.Context, orderID, itemID uuid.UUID, quantity int) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).UpdateItemQuantity(orderID, itemID, quantity)
+	})
+}
+
+func (s *orderService) DeleteItem(ctx context.Context, orderID, itemID uuid.UUID) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteItem(orderID, itemID)
+	})
+}
+
+// ReorderFrom опрашивает каталог до транзакции, как и AddItem. Исходный заказ не блокируется:
+// он только читается, а позиции, добавленные в него после запроса к каталогу, считаются пропавшими
+func (s *orderService) ReorderFrom(ctx context.Context, orderID uuid.UUID) (result domainservice.ReorderResult, err error) {
+	var source *model.Order
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		source, err = provider.OrderRepository(ctx).Find(orderID)
+		return err
+	})
+	if err != nil {
+		return domainservice.ReorderResult{}, err
+	}
+
+	if err = s.customerDirectory.CheckCustomer(ctx, source.CustomerID); err != nil {
+		return domainservice.ReorderResult{}, err
+	}
+
+	products := make(map[uuid.UUID]model.Product, len(source.Items))
+	for _, item := range source.Items {
+		product, err := s.productCatalog.FindProduct(ctx, item.ProductID)
+		if errors.Is(err, model.ErrProductNotFound) {
+			continue
+		}
+		if err != nil {
+			return domainservice.ReorderResult{}, err
+		}
+		products[product.ID] = *product
+	}
+
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		result, err = s.domainService(ctx, provider).ReorderFrom(orderID, products)
+		return err
+	})
+	return result, err
+}
+
+func (s *orderService) SetDelivery(ctx context.Context, orderID uuid.UUID, delivery model.Delivery) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SetDelivery(orderID, delivery)
+	})
+}
+
+func (s *orderService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Order {
+	return newOrderDomainService(ctx, provider)
+}
+
+func newOrderDomainService(ctx context.Context, provider RepositoryProvider) domainservice.Order {
+	return domainservice.NewOrderService(
+		provider.OrderRepository(ctx),
+		provider.OrderStatusHistoryRepository(ctx),
+		provider.TaxRuleRepository(ctx),
+		provider.ShipmentRepository(ctx),
+		provider.EventDispatcher(ctx),
+	)
+}
+
+func orderLockName(orderID uuid.UUID) string {
+	return "order_" + orderID.String()
+}
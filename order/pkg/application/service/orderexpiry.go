@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+// OrderExpiryService отменяет заказы, которые слишком долго ждут оплаты
+type OrderExpiryService interface {
+	// ExpirePendingOrders отменяет до limit заказов, перешедших в Pending раньше pendingBefore,
+	// и возвращает число отменённых. Заказы, заблокированные другим экземпляром сервиса, пропускаются
+	ExpirePendingOrders(ctx context.Context, pendingBefore time.Time, limit int) (int, error)
+}
+
+// NewOrderExpiryService ожидает luow без ожидания блокировки: заказ, который сейчас
+// обрабатывает другая реплика или запрос пользователя, будет проверен в следующий раз
+func NewOrderExpiryService(uow UnitOfWork, luow LockableUnitOfWork) OrderExpiryService {
+	return &orderExpiryService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type orderExpiryService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+func (s *orderExpiryService) ExpirePendingOrders(ctx context.Context, pendingBefore time.Time, limit int) (int, error) {
+	var orderIDs []uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) (err error) {
+		orderIDs, err = provider.OrderRepository(ctx).FindPendingBefore(pendingBefore, limit)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	expiredCount := 0
+	for _, orderID := range orderIDs {
+		var expired bool
+		err = s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) (err error) {
+			expired, err = newOrderDomainService(ctx, provider).ExpireOrder(orderID, pendingBefore)
+			return err
+		})
+		if errors.Is(err, ErrLockTimeout) || errors.Is(err, model.ErrVersionConflict) || errors.Is(err, model.ErrOrderNotFound) {
+			continue
+		}
+		if err != nil {
+			return expiredCount, err
+		}
+		if expired {
+			expiredCount++
+		}
+	}
+	return expiredCount, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+// paymentActor - автор смены статуса заказа по событию сервиса payment
+const paymentActor = "payment"
+
+// PaymentEventService применяет к заказам события сервиса payment. Каждое событие
+// обрабатывается один раз: его ID фиксируется в inbox в одной транзакции со сменой статуса
+type PaymentEventService interface {
+	PaymentCompleted(ctx context.Context, eventID string, orderID uuid.UUID) error
+	PaymentFailed(ctx context.Context, eventID string, orderID uuid.UUID, reason string) error
+}
+
+func NewPaymentEventService(luow LockableUnitOfWork) PaymentEventService {
+	return &paymentEventService{luow: luow}
+}
+
+type paymentEventService struct {
+	luow LockableUnitOfWork
+}
+
+func (s *paymentEventService) PaymentCompleted(ctx context.Context, eventID string, orderID uuid.UUID) error {
+	return s.setStatus(ctx, eventID, "PaymentCompleted", orderID, model.Paid, "payment completed")
+}
+
+func (s *paymentEventService) PaymentFailed(ctx context.Context, eventID string, orderID uuid.UUID, reason string) error {
+	return s.setStatus(ctx, eventID, "PaymentFailed", orderID, model.Cancelled, "payment failed: "+reason)
+}
+
+// setStatus меняет статус только у заказа, ожидающего оплаты. Удалённый заказ или заказ,
+// который уже ушёл из Pending, пропускается: его статус сменили пользователь или сага оформления,
+// а компенсацию проведённого платежа выполняет сага
+func (s *paymentEventService) setStatus(
+	ctx context.Context,
+	eventID, eventType string,
+	orderID uuid.UUID,
+	status model.OrderStatus,
+	reason string,
+) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		isNew, err := provider.Inbox(ctx).MarkProcessed(eventID, eventType)
+		if err != nil || !isNew {
+			return err
+		}
+
+		order, err := provider.OrderRepository(ctx).Find(orderID)
+		if errors.Is(err, model.ErrOrderNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if order.Status != model.Pending {
+			return nil
+		}
+
+		return newOrderDomainService(ctx, provider).SetStatus(orderID, status, paymentActor, reason)
+	})
+}
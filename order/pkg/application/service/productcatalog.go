@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+// ProductCatalog - источник актуальных цен и названий товаров.
+// Для удалённых и неизвестных товаров возвращает model.ErrProductNotFound
+type ProductCatalog interface {
+	FindProduct(ctx context.Context, productID uuid.UUID) (*model.Product, error)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+)
+
+type PromoCodeService interface {
+	CreatePromoCode(ctx context.Context, promoCode model.PromoCode) error
+	ApplyPromoCode(ctx context.Context, orderID uuid.UUID, code string) error
+	RemovePromoCode(ctx context.Context, orderID uuid.UUID) error
+}
+
+func NewPromoCodeService(uow UnitOfWork, luow LockableUnitOfWork) PromoCodeService {
+	return &promoCodeService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type promoCodeService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+func (s *promoCodeService) CreatePromoCode(ctx context.Context, promoCode model.PromoCode) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).CreatePromoCode(promoCode)
+	})
+}
+
+func (s *promoCodeService) ApplyPromoCode(ctx context.Context, orderID uuid.UUID, code string) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ApplyPromoCode(orderID, code)
+	})
+}
+
+func (s *promoCodeService) RemovePromoCode(ctx context.Context, orderID uuid.UUID) error {
+	return s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RemovePromoCode(orderID)
+	})
+}
+
+func (s *promoCodeService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.PromoCode {
+	return domainservice.NewPromoCodeService(
+		provider.OrderRepository(ctx),
+		provider.PromoCodeRepository(ctx),
+		provider.TaxRuleRepository(ctx),
+		provider.EventDispatcher(ctx),
+	)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domainservice "order/pkg/domain/service"
+)
+
+type ReturnService interface {
+	RequestReturn(
+		ctx context.Context,
+		orderID uuid.UUID,
+		items []domainservice.RequestedReturnItem,
+		reason, requestedBy string,
+	) (uuid.UUID, error)
+	ApproveReturn(ctx context.Context, returnID uuid.UUID, changedBy, reason string) error
+	RejectReturn(ctx context.Context, returnID uuid.UUID, changedBy, reason string) error
+	ReceiveReturn(ctx context.Context, returnID uuid.UUID, changedBy, reason string) error
+}
+
+func NewReturnService(uow UnitOfWork, luow LockableUnitOfWork) ReturnService {
+	return &returnService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type returnService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+// RequestReturn выполняется под блокировкой заказа, чтобы параллельные возвраты
+// не вернули одни и те же единицы дважды
+func (s *returnService) RequestReturn(
+	ctx context.Context,
+	orderID uuid.UUID,
+	items []domainservice.RequestedReturnItem,
+	reason, requestedBy string,
+) (returnID uuid.UUID, err error) {
+	err = s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		returnID, err = s.domainService(ctx, provider).RequestReturn(orderID, items, reason, requestedBy)
+		return err
+	})
+	return returnID, err
+}
+
+func (s *returnService) ApproveReturn(ctx context.Context, returnID uuid.UUID, changedBy, reason string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ApproveReturn(returnID, changedBy, reason)
+	})
+}
+
+func (s *returnService) RejectReturn(ctx context.Context, returnID uuid.UUID, changedBy, reason string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RejectReturn(returnID, changedBy, reason)
+	})
+}
+
+func (s *returnService) ReceiveReturn(ctx context.Context, returnID uuid.UUID, changedBy, reason string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ReceiveReturn(returnID, changedBy, reason)
+	})
+}
+
+func (s *returnService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Return {
+	return domainservice.NewReturnService(
+		provider.OrderRepository(ctx),
+		provider.ReturnRepository(ctx),
+		provider.ReturnStatusHistoryRepository(ctx),
+		provider.ShipmentRepository(ctx),
+		provider.EventDispatcher(ctx),
+	)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domainservice "order/pkg/domain/service"
+)
+
+type ShipmentService interface {
+	CreateShipment(ctx context.Context, orderID uuid.UUID, items []domainservice.RequestedShipmentItem) (uuid.UUID, error)
+	ShipShipment(ctx context.Context, shipmentID uuid.UUID, carrier, trackingNumber string) error
+	DeliverShipment(ctx context.Context, shipmentID uuid.UUID) error
+}
+
+func NewShipmentService(uow UnitOfWork, luow LockableUnitOfWork) ShipmentService {
+	return &shipmentService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type shipmentService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+// CreateShipment выполняется под блокировкой заказа, чтобы параллельные отправления
+// не включили одни и те же единицы дважды
+func (s *shipmentService) CreateShipment(
+	ctx context.Context,
+	orderID uuid.UUID,
+	items []domainservice.RequestedShipmentItem,
+) (shipmentID uuid.UUID, err error) {
+	err = s.luow.Execute(ctx, orderLockName(orderID), func(provider RepositoryProvider) error {
+		shipmentID, err = s.domainService(ctx, provider).CreateShipment(orderID, items)
+		return err
+	})
+	return shipmentID, err
+}
+
+func (s *shipmentService) ShipShipment(ctx context.Context, shipmentID uuid.UUID, carrier, trackingNumber string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ShipShipment(shipmentID, carrier, trackingNumber)
+	})
+}
+
+func (s *shipmentService) DeliverShipment(ctx context.Context, shipmentID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeliverShipment(shipmentID)
+	})
+}
+
+func (s *shipmentService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Shipment {
+	return domainservice.NewShipmentService(
+		provider.OrderRepository(ctx),
+		provider.ShipmentRepository(ctx),
+		provider.EventDispatcher(ctx),
+	)
+}
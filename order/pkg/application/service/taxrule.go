@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+)
+
+type TaxRuleService interface {
+	SetTaxRule(ctx context.Context, rule model.TaxRule) error
+	DeleteTaxRule(ctx context.Context, category, country string) error
+}
+
+func NewTaxRuleService(uow UnitOfWork) TaxRuleService {
+	return &taxRuleService{
+		uow: uow,
+	}
+}
+
+type taxRuleService struct {
+	uow UnitOfWork
+}
+
+func (s *taxRuleService) SetTaxRule(ctx context.Context, rule model.TaxRule) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SetTaxRule(rule)
+	})
+}
+
+func (s *taxRuleService) DeleteTaxRule(ctx context.Context, category, country string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteTaxRule(category, country)
+	})
+}
+
+func (s *taxRuleService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.TaxRule {
+	return domainservice.NewTaxRuleService(provider.TaxRuleRepository(ctx))
+}
@@ -2,11 +2,33 @@ package service
 
 import (
 	"context"
+	"errors"
+
 	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+)
+
+var (
+	ErrLockTimeout = errors.New("timed out waiting for lock")
 )
 
 type RepositoryProvider interface {
 	OrderRepository(ctx context.Context) model.OrderRepository
+	OrderStatusHistoryRepository(ctx context.Context) model.OrderStatusHistoryRepository
+	CheckoutSagaRepository(ctx context.Context) model.CheckoutSagaRepository
+	PromoCodeRepository(ctx context.Context) model.PromoCodeRepository
+	TaxRuleRepository(ctx context.Context) model.TaxRuleRepository
+	ReturnRepository(ctx context.Context) model.ReturnRepository
+	ReturnStatusHistoryRepository(ctx context.Context) model.ReturnStatusHistoryRepository
+	ShipmentRepository(ctx context.Context) model.ShipmentRepository
+	EventDispatcher(ctx context.Context) domainservice.EventDispatcher
+	Inbox(ctx context.Context) Inbox
+}
+
+// Inbox - учёт обработанных входящих событий для идемпотентной обработки повторных доставок
+type Inbox interface {
+	// MarkProcessed запоминает событие и возвращает false, если оно уже было обработано
+	MarkProcessed(eventID, eventType string) (bool, error)
 }
 
 type LockableUnitOfWork interface {
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/query"
+)
+
+func TestOrderCursor(t *testing.T) {
+	t.Run("Decode encoded cursor", func(t *testing.T) {
+		for _, cursor := range []query.OrderCursor{
+			{
+				CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+				ID:        uuid.MustParse("0192f0c8-5d6e-7a1b-9c2d-3e4f5a6b7c8d"),
+			},
+			{
+				CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC),
+				ID:        uuid.MustParse("0192f0c8-5d6e-7a1b-9c2d-3e4f5a6b7c8e"),
+			},
+			{
+				CreatedAt: time.Date(2024, 5, 1, 15, 30, 0, 500, time.FixedZone("MSK", 3*60*60)),
+				ID:        uuid.Nil,
+			},
+		} {
+			decoded, err := query.ParseOrderCursor(cursor.Encode())
+
+			require.NoError(t, err)
+			require.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt), cursor.CreatedAt.String())
+			require.Equal(t, cursor.ID, decoded.ID)
+		}
+	})
+
+	t.Run("Reject invalid cursor", func(t *testing.T) {
+		encode := func(raw string) string {
+			return base64.RawURLEncoding.EncodeToString([]byte(raw))
+		}
+
+		for name, value := range map[string]string{
+			"empty":             "",
+			"not base64":        "not a cursor!",
+			"missing separator": encode("2024-05-01T12:30:00Z"),
+			"invalid time":      encode("yesterday|0192f0c8-5d6e-7a1b-9c2d-3e4f5a6b7c8d"),
+			"invalid id":        encode("2024-05-01T12:30:00Z|42"),
+			"empty id":          encode("2024-05-01T12:30:00Z|"),
+		} {
+			_, err := query.ParseOrderCursor(value)
+
+			require.ErrorIs(t, err, query.ErrInvalidCursor, name)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+)
+
+type mockUnitOfWork struct {
+	provider *mockProvider
+}
+
+func (m *mockUnitOfWork) Execute(_ context.Context, f func(provider service.RepositoryProvider) error) error {
+	return f(m.provider)
+}
+
+type mockLockableUnitOfWork struct {
+	provider *mockProvider
+	// locked - блокировки, которые держит другой экземпляр сервиса
+	locked map[string]bool
+}
+
+func (m *mockLockableUnitOfWork) Execute(_ context.Context, lockName string, f func(provider service.RepositoryProvider) error) error {
+	if m.locked[lockName] {
+		return service.ErrLockTimeout
+	}
+	return f(m.provider)
+}
+
+func newMockProvider() *mockProvider {
+	return &mockProvider{
+		orders: make(map[uuid.UUID]*model.Order),
+		inbox:  make(map[string]struct{}),
+	}
+}
+
+type mockProvider struct {
+	orders   map[uuid.UUID]*model.Order
+	history  []*model.StatusChange
+	inbox    map[string]struct{}
+	taxRules []model.TaxRule
+}
+
+func (m *mockProvider) OrderRepository(context.Context) model.OrderRepository {
+	return (*mockOrderRepository)(m)
+}
+
+func (m *mockProvider) OrderStatusHistoryRepository(context.Context) model.OrderStatusHistoryRepository {
+	return (*mockOrderStatusHistoryRepository)(m)
+}
+
+func (m *mockProvider) CheckoutSagaRepository(context.Context) model.CheckoutSagaRepository {
+	return nil
+}
+
+func (m *mockProvider) PromoCodeRepository(context.Context) model.PromoCodeRepository {
+	return nil
+}
+
+func (m *mockProvider) TaxRuleRepository(context.Context) model.TaxRuleRepository {
+	return (*mockTaxRuleRepository)(m)
+}
+
+func (m *mockProvider) ReturnRepository(context.Context) model.ReturnRepository {
+	return nil
+}
+
+func (m *mockProvider) ReturnStatusHistoryRepository(context.Context) model.ReturnStatusHistoryRepository {
+	return nil
+}
+
+func (m *mockProvider) ShipmentRepository(context.Context) model.ShipmentRepository {
+	return nil
+}
+
+func (m *mockProvider) EventDispatcher(context.Context) domainservice.EventDispatcher {
+	return mockEventDispatcher{}
+}
+
+func (m *mockProvider) Inbox(context.Context) service.Inbox {
+	return (*mockInbox)(m)
+}
+
+type mockOrderRepository mockProvider
+
+func (m *mockOrderRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockOrderRepository) Store(order *model.Order) error {
+	m.orders[order.ID] = order
+	return nil
+}
+
+func (m *mockOrderRepository) Find(id uuid.UUID) (*model.Order, error) {
+	if order, ok := m.orders[id]; ok {
+		return order, nil
+	}
+	return nil, model.ErrOrderNotFound
+}
+
+func (m *mockOrderRepository) Delete(uuid.UUID) error {
+	return nil
+}
+
+func (m *mockOrderRepository) Restore(uuid.UUID) error {
+	return nil
+}
+
+func (m *mockOrderRepository) FindPendingBefore(pendingBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, order := range m.orders {
+		if order.Status == model.Pending && order.PendingAt != nil && order.PendingAt.Before(pendingBefore) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type mockOrderStatusHistoryRepository mockProvider
+
+func (m *mockOrderStatusHistoryRepository) Append(change *model.StatusChange) error {
+	m.history = append(m.history, change)
+	return nil
+}
+
+type mockInbox mockProvider
+
+func (m *mockInbox) MarkProcessed(eventID, _ string) (bool, error) {
+	if _, ok := m.inbox[eventID]; ok {
+		return false, nil
+	}
+	m.inbox[eventID] = struct{}{}
+	return true, nil
+}
+
+type mockEventDispatcher struct{}
+
+func (mockEventDispatcher) Dispatch(domainservice.Event) error {
+	return nil
+}
+
+type mockTaxRuleRepository mockProvider
+
+func (m *mockTaxRuleRepository) Store(rule *model.TaxRule) error {
+	m.taxRules = append(m.taxRules, *rule)
+	return nil
+}
+
+func (m *mockTaxRuleRepository) Delete(string, string) error {
+	return model.ErrTaxRuleNotFound
+}
+
+func (m *mockTaxRuleRepository) FindByCountry(string) ([]model.TaxRule, error) {
+	return m.taxRules, nil
+}
+
+// mockCustomerDirectory - покупатели, которых нет в errs, существуют
+type mockCustomerDirectory struct {
+	errs map[uuid.UUID]error
+}
+
+func (m *mockCustomerDirectory) CheckCustomer(_ context.Context, customerID uuid.UUID) error {
+	return m.errs[customerID]
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func TestOrderExpiryService(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Now().Add(-time.Hour)
+
+	addOrder := func(provider *mockProvider, status model.OrderStatus, createdAt, pendingAt time.Time) uuid.UUID {
+		orderID := uuid.Must(uuid.NewV7())
+		provider.orders[orderID] = &model.Order{ID: orderID, Status: status, CreatedAt: createdAt, PendingAt: &pendingAt}
+		return orderID
+	}
+
+	t.Run("Expire stale pending orders", func(t *testing.T) {
+		provider := newMockProvider()
+		staleOrderID := addOrder(provider, model.Pending, createdAt, createdAt)
+		freshOrderID := addOrder(provider, model.Pending, time.Now(), time.Now())
+		paidOrderID := addOrder(provider, model.Paid, createdAt, createdAt)
+		expiryService := service.NewOrderExpiryService(&mockUnitOfWork{provider: provider}, &mockLockableUnitOfWork{provider: provider})
+
+		expired, err := expiryService.ExpirePendingOrders(ctx, time.Now().Add(-time.Minute), 10)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, expired)
+		require.Equal(t, model.Cancelled, provider.orders[staleOrderID].Status)
+		require.Equal(t, model.Pending, provider.orders[freshOrderID].Status)
+		require.Equal(t, model.Paid, provider.orders[paidOrderID].Status)
+	})
+
+	t.Run("Keep old order checked out recently", func(t *testing.T) {
+		provider := newMockProvider()
+		orderID := addOrder(provider, model.Pending, createdAt, time.Now())
+		expiryService := service.NewOrderExpiryService(&mockUnitOfWork{provider: provider}, &mockLockableUnitOfWork{provider: provider})
+
+		expired, err := expiryService.ExpirePendingOrders(ctx, time.Now().Add(-time.Minute), 10)
+
+		require.NoError(t, err)
+		require.Zero(t, expired)
+		require.Equal(t, model.Pending, provider.orders[orderID].Status)
+	})
+
+	t.Run("Skip order locked by another replica", func(t *testing.T) {
+		provider := newMockProvider()
+		lockedOrderID := addOrder(provider, model.Pending, createdAt, createdAt)
+		luow := &mockLockableUnitOfWork{provider: provider, locked: map[string]bool{"order_" + lockedOrderID.String(): true}}
+		expiryService := service.NewOrderExpiryService(&mockUnitOfWork{provider: provider}, luow)
+
+		expired, err := expiryService.ExpirePendingOrders(ctx, time.Now(), 10)
+
+		require.NoError(t, err)
+		require.Zero(t, expired)
+		require.Equal(t, model.Pending, provider.orders[lockedOrderID].Status)
+	})
+}
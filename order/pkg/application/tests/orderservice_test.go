@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func TestOrderService(t *testing.T) {
+	ctx := context.Background()
+
+	newOrderService := func(provider *mockProvider, customers *mockCustomerDirectory) service.OrderService {
+		return service.NewOrderService(
+			&mockUnitOfWork{provider: provider},
+			&mockLockableUnitOfWork{provider: provider},
+			nil,
+			customers,
+		)
+	}
+
+	t.Run("Create order for existing customer", func(t *testing.T) {
+		provider := newMockProvider()
+		customerID := uuid.New()
+
+		orderID, err := newOrderService(provider, &mockCustomerDirectory{}).CreateOrder(ctx, customerID, nil)
+
+		require.NoError(t, err)
+		require.Equal(t, customerID, provider.orders[orderID].CustomerID)
+	})
+
+	t.Run("Refuse order for unknown or deleted customer", func(t *testing.T) {
+		provider := newMockProvider()
+		unknownCustomerID := uuid.New()
+		deletedCustomerID := uuid.New()
+		orderService := newOrderService(provider, &mockCustomerDirectory{errs: map[uuid.UUID]error{
+			unknownCustomerID: model.ErrCustomerNotFound,
+			deletedCustomerID: model.ErrCustomerDeleted,
+		}})
+
+		_, err := orderService.CreateOrder(ctx, unknownCustomerID, nil)
+		require.ErrorIs(t, err, model.ErrCustomerNotFound)
+
+		_, err = orderService.CreateOrder(ctx, deletedCustomerID, nil)
+		require.ErrorIs(t, err, model.ErrCustomerDeleted)
+
+		require.Empty(t, provider.orders)
+	})
+
+	t.Run("Refuse reorder for deleted customer", func(t *testing.T) {
+		provider := newMockProvider()
+		customerID := uuid.New()
+		sourceID := uuid.New()
+		provider.orders[sourceID] = &model.Order{ID: sourceID, CustomerID: customerID, Status: model.Paid}
+		orderService := newOrderService(provider, &mockCustomerDirectory{errs: map[uuid.UUID]error{
+			customerID: model.ErrCustomerDeleted,
+		}})
+
+		_, err := orderService.ReorderFrom(ctx, sourceID)
+
+		require.ErrorIs(t, err, model.ErrCustomerDeleted)
+		require.Len(t, provider.orders, 1)
+	})
+}
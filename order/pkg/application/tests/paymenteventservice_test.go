@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func TestPaymentEventService(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(status model.OrderStatus) (service.PaymentEventService, *mockProvider, uuid.UUID) {
+		provider := newMockProvider()
+		orderID := uuid.Must(uuid.NewV7())
+		provider.orders[orderID] = &model.Order{ID: orderID, Status: status}
+		return service.NewPaymentEventService(&mockLockableUnitOfWork{provider: provider}), provider, orderID
+	}
+
+	t.Run("Mark order paid", func(t *testing.T) {
+		paymentEventService, provider, orderID := setup(model.Pending)
+
+		err := paymentEventService.PaymentCompleted(ctx, uuid.NewString(), orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Paid, provider.orders[orderID].Status)
+		require.Len(t, provider.history, 1)
+		require.Equal(t, "payment", provider.history[0].ChangedBy)
+	})
+
+	t.Run("Cancel order when payment failed", func(t *testing.T) {
+		paymentEventService, provider, orderID := setup(model.Pending)
+
+		err := paymentEventService.PaymentFailed(ctx, uuid.NewString(), orderID, "insufficient funds")
+
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, provider.orders[orderID].Status)
+		require.Equal(t, "payment failed: insufficient funds", provider.history[0].Reason)
+	})
+
+	t.Run("Skip redelivered event", func(t *testing.T) {
+		paymentEventService, provider, orderID := setup(model.Pending)
+		eventID := uuid.NewString()
+		_ = paymentEventService.PaymentCompleted(ctx, eventID, orderID)
+		provider.orders[orderID].Status = model.Pending
+
+		err := paymentEventService.PaymentCompleted(ctx, eventID, orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Pending, provider.orders[orderID].Status)
+		require.Len(t, provider.history, 1)
+	})
+
+	t.Run("Skip order that is not pending", func(t *testing.T) {
+		paymentEventService, provider, orderID := setup(model.Open)
+
+		err := paymentEventService.PaymentFailed(ctx, uuid.NewString(), orderID, "insufficient funds")
+
+		require.NoError(t, err)
+		require.Equal(t, model.Open, provider.orders[orderID].Status)
+		require.Empty(t, provider.history)
+	})
+
+	t.Run("Skip unknown order", func(t *testing.T) {
+		paymentEventService, provider, _ := setup(model.Pending)
+
+		err := paymentEventService.PaymentCompleted(ctx, uuid.NewString(), uuid.Must(uuid.NewV7()))
+
+		require.NoError(t, err)
+		require.Len(t, provider.inbox, 1)
+	})
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrCheckoutSagaNotFound = errors.New("checkout saga not found")
+
+// CheckoutSagaState - шаг оформления заказа. Прямой путь:
+// OrderPending -> PaymentInitiated -> OrderPaid -> Completed.
+// Компенсации: платёж не создан - заказ возвращается в Open (Failed);
+// платёж отклонён - заказ отменяется (Cancelled);
+// платёж проведён, но заказ уже нельзя оплатить - платёж возвращается (RefundingPayment -> Failed)
+type CheckoutSagaState int
+
+const (
+	CheckoutOrderPending CheckoutSagaState = iota
+	CheckoutPaymentInitiated
+	CheckoutOrderPaid
+	CheckoutRefundingPayment
+	CheckoutCompleted
+	CheckoutCancelled
+	CheckoutFailed
+)
+
+// UnfinishedCheckoutSagaStates - состояния, из которых сагу нужно продолжить
+var UnfinishedCheckoutSagaStates = []CheckoutSagaState{
+	CheckoutOrderPending,
+	CheckoutPaymentInitiated,
+	CheckoutOrderPaid,
+	CheckoutRefundingPayment,
+}
+
+func (s CheckoutSagaState) IsFinished() bool {
+	switch s {
+	case CheckoutCompleted, CheckoutCancelled, CheckoutFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+type CheckoutSaga struct {
+	ID         uuid.UUID
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Amount     Money
+	State      CheckoutSagaState
+	// PaymentID заполняется после создания платежа в сервисе payment
+	PaymentID     uuid.UUID
+	FailureReason string
+	// Attempts - число неудачных попыток текущего шага, сбрасывается при переходе к следующему
+	Attempts  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   int
+}
+
+type CheckoutSagaRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(saga *CheckoutSaga) error
+	Find(id uuid.UUID) (*CheckoutSaga, error)
+	// FindUnfinished возвращает ID незавершённых саг, которые не менялись с updatedBefore
+	FindUnfinished(updatedBefore time.Time, limit int) ([]uuid.UUID, error)
+}
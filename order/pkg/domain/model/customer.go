@@ -0,0 +1,9 @@
+package model
+
+import "errors"
+
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	// ErrCustomerDeleted - покупатель удалён в сервисе user, новые заказы для него не создаются
+	ErrCustomerDeleted = errors.New("customer is deleted")
+)
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidDeliveryAddress = errors.New(
+		"delivery address requires recipient, phone, country (ISO 3166-1 alpha-2), city, street, building and postal code",
+	)
+	ErrInvalidShippingMethod = errors.New("invalid shipping method")
+)
+
+type ShippingMethod int
+
+const (
+	ShippingStandard ShippingMethod = iota + 1
+	ShippingExpress
+	ShippingPickup
+)
+
+func (m ShippingMethod) IsValid() bool {
+	return m >= ShippingStandard && m <= ShippingPickup
+}
+
+// shippingRates - тарифы доставки в валюте по умолчанию
+var shippingRates = map[ShippingMethod]Money{
+	ShippingStandard: {Amount: 30000, Currency: DefaultCurrency},
+	ShippingExpress:  {Amount: 50000, Currency: DefaultCurrency},
+	ShippingPickup:   {Amount: 0, Currency: DefaultCurrency},
+}
+
+// Cost возвращает стоимость доставки этим способом по тарифу сервиса
+func (m ShippingMethod) Cost() (Money, error) {
+	if !m.IsValid() {
+		return Money{}, ErrInvalidShippingMethod
+	}
+	return shippingRates[m], nil
+}
+
+// maxDeliveryFieldLength ограничивает длину каждого поля адреса
+const maxDeliveryFieldLength = 255
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	phonePattern       = regexp.MustCompile(`^\+?[0-9]{5,15}$`)
+)
+
+// DeliveryAddress - адрес доставки. Apartment и Comment необязательны
+type DeliveryAddress struct {
+	Recipient  string
+	Phone      string
+	Country    string
+	City       string
+	Street     string
+	Building   string
+	Apartment  string
+	PostalCode string
+	Comment    string
+}
+
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "")
+
+// Normalize убирает пробелы по краям полей, разделители из телефона и приводит код страны к верхнему регистру
+func (a DeliveryAddress) Normalize() DeliveryAddress {
+	return DeliveryAddress{
+		Recipient:  strings.TrimSpace(a.Recipient),
+		Phone:      phoneSeparators.Replace(a.Phone),
+		Country:    strings.ToUpper(strings.TrimSpace(a.Country)),
+		City:       strings.TrimSpace(a.City),
+		Street:     strings.TrimSpace(a.Street),
+		Building:   strings.TrimSpace(a.Building),
+		Apartment:  strings.TrimSpace(a.Apartment),
+		PostalCode: strings.TrimSpace(a.PostalCode),
+		Comment:    strings.TrimSpace(a.Comment),
+	}
+}
+
+func (a DeliveryAddress) Validate() error {
+	required := []string{a.Recipient, a.Phone, a.City, a.Street, a.Building, a.PostalCode}
+	for _, field := range required {
+		if field == "" {
+			return ErrInvalidDeliveryAddress
+		}
+	}
+	for _, field := range append(required, a.Apartment, a.Comment) {
+		if utf8.RuneCountInString(field) > maxDeliveryFieldLength {
+			return ErrInvalidDeliveryAddress
+		}
+	}
+	if !countryCodePattern.MatchString(a.Country) || !phonePattern.MatchString(a.Phone) {
+		return ErrInvalidDeliveryAddress
+	}
+	return nil
+}
+
+// Delivery - куда и каким способом доставить заказ. Cost входит в сумму заказа
+// и всегда рассчитывается сервисом по Method, а не передаётся клиентом
+type Delivery struct {
+	Address DeliveryAddress
+	Method  ShippingMethod
+	Cost    Money
+}
+
+func (d Delivery) Validate() error {
+	if !d.Method.IsValid() {
+		return ErrInvalidShippingMethod
+	}
+	return d.Address.Validate()
+}
@@ -1,10 +1,78 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// OrderEventSchemaVersion - текущая версия схемы событий заказа. Новые версии только добавляют
+// поля: имена и типы существующих полей не меняются, чтобы потребители, знающие старую версию,
+// могли читать новые события. У событий первой версии SchemaVersion и OccurredAt не заполнены
+const OrderEventSchemaVersion = 2
+
+// EventMetadata - общие поля всех событий заказа
+type EventMetadata struct {
+	SchemaVersion int
+	OccurredAt    time.Time
+}
+
+func NewEventMetadata(occurredAt time.Time) EventMetadata {
+	return EventMetadata{
+		SchemaVersion: OrderEventSchemaVersion,
+		OccurredAt:    occurredAt,
+	}
+}
+
+// EventItem - снимок позиции заказа в событии
+type EventItem struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Price     Money
+	Quantity  int
+}
+
+func NewEventItem(item Item) EventItem {
+	return EventItem{
+		ItemID:    item.ID,
+		ProductID: item.ProductID,
+		Name:      item.Name,
+		Price:     item.Price,
+		Quantity:  item.Quantity,
+	}
+}
+
+// NewShipmentEventItem - снимок отправляемых единиц позиции, Quantity - количество в отправлении
+func NewShipmentEventItem(item ShipmentItem) EventItem {
+	return EventItem{
+		ItemID:    item.ItemID,
+		ProductID: item.ProductID,
+		Name:      item.Name,
+		Price:     item.Price,
+		Quantity:  item.Quantity,
+	}
+}
+
+// NewReturnEventItem - снимок возвращаемых единиц позиции, Quantity - количество в возврате
+func NewReturnEventItem(item ReturnItem) EventItem {
+	return EventItem{
+		ItemID:    item.ItemID,
+		ProductID: item.ProductID,
+		Name:      item.Name,
+		Price:     item.Price,
+		Quantity:  item.Quantity,
+	}
+}
+
+// Delivery в событиях равен nil, если доставка ещё не указана.
+// Total во всех событиях заказа - сумма к оплате после изменения
 type OrderCreated struct {
+	EventMetadata
 	OrderID    uuid.UUID
 	CustomerID uuid.UUID
+	Delivery   *Delivery
+	Total      Money
 }
 
 func (e OrderCreated) Type() string {
@@ -12,17 +80,35 @@ func (e OrderCreated) Type() string {
 }
 
 type OrderDeleted struct {
+	EventMetadata
 	OrderID uuid.UUID
+	Total   Money
 }
 
 func (e OrderDeleted) Type() string {
 	return "OrderDeleted"
 }
 
+type OrderRestored struct {
+	EventMetadata
+	OrderID uuid.UUID
+	Total   Money
+}
+
+func (e OrderRestored) Type() string {
+	return "OrderRestored"
+}
+
+// Items содержит позиции из AddedItems и ChangedItems после изменения,
+// а позиции из RemovedItems - в том виде, в каком они были до удаления
 type OrderItemChanged struct {
+	EventMetadata
 	OrderID      uuid.UUID
 	AddedItems   []uuid.UUID
+	ChangedItems []uuid.UUID
 	RemovedItems []uuid.UUID
+	Items        []EventItem
+	Total        Money
 }
 
 func (e OrderItemChanged) Type() string {
@@ -30,11 +116,151 @@ func (e OrderItemChanged) Type() string {
 }
 
 type OrderStatusChanged struct {
+	EventMetadata
 	OrderID   uuid.UUID
 	OldStatus OrderStatus
 	NewStatus OrderStatus
+	Delivery  *Delivery
+	Total     Money
 }
 
 func (e OrderStatusChanged) Type() string {
 	return "OrderStatusChanged"
 }
+
+// OrderCancelled публикуется при любой отмене заказа. RefundRequired выставляется,
+// если заказ был оплачен: сервис payment по нему возвращает деньги на кошелёк покупателя
+type OrderCancelled struct {
+	EventMetadata
+	OrderID        uuid.UUID
+	CustomerID     uuid.UUID
+	Total          Money
+	Reason         string
+	CancelledBy    string
+	RefundRequired bool
+}
+
+func (e OrderCancelled) Type() string {
+	return "OrderCancelled"
+}
+
+type OrderPromoCodeApplied struct {
+	EventMetadata
+	OrderID uuid.UUID
+	Code    string
+	Total   Money
+}
+
+func (e OrderPromoCodeApplied) Type() string {
+	return "OrderPromoCodeApplied"
+}
+
+type OrderPromoCodeRemoved struct {
+	EventMetadata
+	OrderID uuid.UUID
+	Code    string
+	Total   Money
+}
+
+func (e OrderPromoCodeRemoved) Type() string {
+	return "OrderPromoCodeRemoved"
+}
+
+type OrderDeliveryChanged struct {
+	EventMetadata
+	OrderID  uuid.UUID
+	Delivery Delivery
+	Total    Money
+}
+
+func (e OrderDeliveryChanged) Type() string {
+	return "OrderDeliveryChanged"
+}
+
+// OrderReturnRequested публикуется при открытии возврата. Items содержит возвращаемые единицы
+// по ценам заказа, RefundAmount во всех событиях возврата - сумма к возврату покупателю
+type OrderReturnRequested struct {
+	EventMetadata
+	ReturnID     uuid.UUID
+	OrderID      uuid.UUID
+	CustomerID   uuid.UUID
+	Items        []EventItem
+	Reason       string
+	RefundAmount Money
+}
+
+func (e OrderReturnRequested) Type() string {
+	return "OrderReturnRequested"
+}
+
+type OrderReturnStatusChanged struct {
+	EventMetadata
+	ReturnID     uuid.UUID
+	OrderID      uuid.UUID
+	OldStatus    ReturnStatus
+	NewStatus    ReturnStatus
+	ChangedBy    string
+	Reason       string
+	RefundAmount Money
+}
+
+func (e OrderReturnStatusChanged) Type() string {
+	return "OrderReturnStatusChanged"
+}
+
+// OrderReturnRefundRequested публикуется, когда возвращённые товары получены:
+// по нему сервис payment возвращает RefundAmount из платежа заказа на кошелёк покупателя
+type OrderReturnRefundRequested struct {
+	EventMetadata
+	ReturnID     uuid.UUID
+	OrderID      uuid.UUID
+	CustomerID   uuid.UUID
+	Items        []EventItem
+	RefundAmount Money
+}
+
+func (e OrderReturnRefundRequested) Type() string {
+	return "OrderReturnRefundRequested"
+}
+
+// OrderShipmentPacked публикуется, когда отправление собрано. Во всех событиях отправления
+// Items содержит отправляемые единицы по ценам заказа
+type OrderShipmentPacked struct {
+	EventMetadata
+	ShipmentID uuid.UUID
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Items      []EventItem
+}
+
+func (e OrderShipmentPacked) Type() string {
+	return "OrderShipmentPacked"
+}
+
+type OrderShipmentShipped struct {
+	EventMetadata
+	ShipmentID     uuid.UUID
+	OrderID        uuid.UUID
+	CustomerID     uuid.UUID
+	Items          []EventItem
+	Carrier        string
+	TrackingNumber string
+}
+
+func (e OrderShipmentShipped) Type() string {
+	return "OrderShipmentShipped"
+}
+
+type OrderShipmentDelivered struct {
+	EventMetadata
+	ShipmentID     uuid.UUID
+	OrderID        uuid.UUID
+	CustomerID     uuid.UUID
+	Items          []EventItem
+	Carrier        string
+	TrackingNumber string
+}
+
+func (e OrderShipmentDelivered) Type() string {
+	return "OrderShipmentDelivered"
+}
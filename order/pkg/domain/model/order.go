@@ -10,6 +10,8 @@ import (
 var (
 	ErrOrderNotFound = errors.New("order not found")
 	ErrItemNotFound  = errors.New("item not found")
+	// ErrVersionConflict - заказ изменили после того, как его прочитали, операцию можно повторить
+	ErrVersionConflict = errors.New("order was modified concurrently")
 )
 
 type OrderStatus int
@@ -21,6 +23,23 @@ const (
 	Cancelled
 )
 
+// orderStatusTransitions описывает допустимые переходы между статусами заказа
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	Open:      {Pending, Cancelled},
+	Pending:   {Open, Paid, Cancelled},
+	Paid:      {Cancelled},
+	Cancelled: {},
+}
+
+func (s OrderStatus) CanTransitionTo(status OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
 type Order struct {
 	ID         uuid.UUID
 	CustomerID uuid.UUID
@@ -29,17 +48,119 @@ type Order struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	DeletedAt  *time.Time
+	// PendingAt - момент последнего перехода в Pending, nil у заказов, которые ещё не оформлялись
+	PendingAt *time.Time
+	// PromoCode равен nil, если промокод не применён
+	PromoCode *AppliedPromoCode
+	// Delivery равен nil, пока адрес и способ доставки не указаны
+	Delivery *Delivery
+	// Tax пересчитывается при каждом изменении позиций, скидки и доставки.
+	// Равен nil у заказов, созданных до появления налоговых правил
+	Tax *OrderTax
+	// Version - версия для оптимистичной блокировки, 0 у ещё не сохранённого заказа
+	Version int
 }
 
 type Item struct {
 	ID        uuid.UUID
 	ProductID uuid.UUID
-	Price     float64
+	Name      string
+	// Category - налоговая категория товара на момент добавления в заказ
+	Category string
+	Price    Money
+	Quantity int
+}
+
+// Subtotal - сумма всех позиций заказа без скидки. Позиции и доставка заказа всегда в одной валюте
+func (o *Order) Subtotal() (Money, error) {
+	if len(o.Items) == 0 {
+		if o.Delivery != nil {
+			return Money{Currency: o.Delivery.Cost.Currency}, nil
+		}
+		return Money{Currency: DefaultCurrency}, nil
+	}
+
+	subtotal := Money{Currency: o.Items[0].Price.Currency}
+	for _, item := range o.Items {
+		var err error
+		subtotal, err = subtotal.Add(item.Total())
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return subtotal, nil
+}
+
+// Discount - скидка по применённому промокоду
+func (o *Order) Discount() (Money, error) {
+	subtotal, err := o.Subtotal()
+	if err != nil || o.PromoCode == nil {
+		return Money{Currency: subtotal.Currency}, err
+	}
+	return o.PromoCode.Discount.Apply(subtotal)
+}
+
+// ShippingCost - стоимость доставки, нулевая, пока доставка не указана. Скидка на неё не распространяется
+func (o *Order) ShippingCost() (Money, error) {
+	subtotal, err := o.Subtotal()
+	if err != nil || o.Delivery == nil {
+		return Money{Currency: subtotal.Currency}, err
+	}
+	return o.Delivery.Cost, nil
+}
+
+// Total - сумма к оплате с учётом скидки, доставки и налога, если цены указаны без него
+func (o *Order) Total() (Money, error) {
+	subtotal, err := o.Subtotal()
+	if err != nil {
+		return Money{}, err
+	}
+	discount, err := o.Discount()
+	if err != nil {
+		return Money{}, err
+	}
+	shippingCost, err := o.ShippingCost()
+	if err != nil {
+		return Money{}, err
+	}
+	total, err := subtotal.Sub(discount)
+	if err != nil {
+		return Money{}, err
+	}
+	total, err = total.Add(shippingCost)
+	if err != nil || o.Tax == nil {
+		return total, err
+	}
+	return total.Add(o.Tax.AddedTax())
+}
+
+func (i Item) Total() Money {
+	return i.Price.Multiply(int64(i.Quantity))
+}
+
+type StatusChange struct {
+	OrderID   uuid.UUID
+	OldStatus OrderStatus
+	NewStatus OrderStatus
+	ChangedBy string
+	Reason    string
+	ChangedAt time.Time
 }
 
 type OrderRepository interface {
 	NextID() (uuid.UUID, error)
 	Store(order *Order) error
+	// Find не возвращает удалённые заказы
 	Find(id uuid.UUID) (*Order, error)
+	// Delete помечает заказ удалённым, физически строки удаляет только purge
 	Delete(id uuid.UUID) error
+	// Restore снимает пометку об удалении, для неудалённого заказа возвращает ErrOrderNotFound
+	Restore(id uuid.UUID) error
+	// FindPendingBefore возвращает ID неудалённых заказов, перешедших в Pending раньше pendingBefore,
+	// начиная с самых давних
+	FindPendingBefore(pendingBefore time.Time, limit int) ([]uuid.UUID, error)
+}
+
+type OrderStatusHistoryRepository interface {
+	Append(change *StatusChange) error
 }
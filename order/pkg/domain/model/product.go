@@ -0,0 +1,17 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrProductNotFound = errors.New("product not found")
+
+// Product - снимок товара из каталога (сервиса product) на момент добавления в заказ
+type Product struct {
+	ID       uuid.UUID
+	Name     string
+	Category string
+	Price    Money
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPromoCodeNotFound = errors.New("promo code not found")
+	ErrPromoCodeExists   = errors.New("promo code already exists")
+	ErrInvalidPromoCode  = errors.New("invalid promo code")
+	ErrInvalidDiscount   = errors.New("discount must be either a percentage from 1 to 100 or a positive amount")
+)
+
+// Discount - скидка в процентах (Percent) или фиксированной суммой (Amount), заполнено ровно одно поле
+type Discount struct {
+	Percent int
+	Amount  *Money
+}
+
+func (d Discount) Validate() error {
+	switch {
+	case d.Amount == nil && d.Percent >= 1 && d.Percent <= 100:
+		return nil
+	case d.Amount != nil && d.Percent == 0 && d.Amount.Amount > 0:
+		return nil
+	default:
+		return ErrInvalidDiscount
+	}
+}
+
+// Apply возвращает размер скидки для суммы subtotal. Процентная скидка округляется вниз
+// до копейки, фиксированная не превышает subtotal
+func (d Discount) Apply(subtotal Money) (Money, error) {
+	if d.Amount == nil {
+		return Money{
+			Amount:   subtotal.Amount * int64(d.Percent) / 100,
+			Currency: subtotal.Currency,
+		}, nil
+	}
+
+	if subtotal.IsZero() {
+		return subtotal, nil
+	}
+	exceeds, err := subtotal.LessThan(*d.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	if exceeds {
+		return subtotal, nil
+	}
+	return *d.Amount, nil
+}
+
+// AppliedPromoCode - промокод, применённый к заказу. Скидка копируется в заказ,
+// чтобы последующие изменения промокода не меняли сумму уже оформляемых заказов
+type AppliedPromoCode struct {
+	Code     string
+	Discount Discount
+}
+
+type PromoCode struct {
+	// Code хранится в верхнем регистре, см. NormalizePromoCode
+	Code     string
+	Discount Discount
+	// ValidFrom и ValidTo ограничивают срок действия, nil - без ограничения. ValidTo не включительно
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+	// MaxUses и MaxUsesPerCustomer - сколько неотменённых заказов может использовать код, 0 - без ограничения
+	MaxUses            int
+	MaxUsesPerCustomer int
+	CreatedAt          time.Time
+	// Version - версия для оптимистичной блокировки, 0 у ещё не сохранённого промокода.
+	// Применение кода повышает версию, чтобы параллельные применения не превысили лимиты
+	Version int
+}
+
+func (p *PromoCode) IsActive(at time.Time) bool {
+	if p.ValidFrom != nil && at.Before(*p.ValidFrom) {
+		return false
+	}
+	if p.ValidTo != nil && !at.Before(*p.ValidTo) {
+		return false
+	}
+	return true
+}
+
+type PromoCodeRepository interface {
+	Store(promoCode *PromoCode) error
+	Find(code string) (*PromoCode, error)
+	// CountUses возвращает число неудалённых заказов с этим кодом в статусе Pending или Paid:
+	// открытый заказ код ещё не расходует, а отменённый освобождает
+	CountUses(code string) (int, error)
+	CountCustomerUses(code string, customerID uuid.UUID) (int, error)
+}
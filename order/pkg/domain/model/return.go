@@ -0,0 +1,137 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReturnNotFound = errors.New("return not found")
+	// ErrInvalidReturnItems - пустой список позиций, повторяющиеся позиции или неположительное количество
+	ErrInvalidReturnItems = errors.New("invalid return items")
+)
+
+// ReturnStatus - шаг возврата. Прямой путь: Requested -> Approved -> Received.
+// Отклонённый возврат (Rejected) освобождает позиции для новых возвратов
+type ReturnStatus int
+
+const (
+	ReturnRequested ReturnStatus = iota
+	ReturnApproved
+	ReturnRejected
+	ReturnReceived
+)
+
+var returnStatusTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived},
+	ReturnRejected:  {},
+	ReturnReceived:  {},
+}
+
+func (s ReturnStatus) CanTransitionTo(status ReturnStatus) bool {
+	for _, allowed := range returnStatusTransitions[s] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ReturnItem - возвращаемое количество позиции заказа. Название и цена копируются из позиции,
+// Refund - часть оплаченной за позицию суммы с учётом скидки и налога, приходящаяся на Quantity
+type ReturnItem struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Price     Money
+	Quantity  int
+	Refund    Money
+}
+
+type Return struct {
+	ID         uuid.UUID
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Status     ReturnStatus
+	Items      []ReturnItem
+	Reason     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// Version сериализует приёмку и отклонение возврата, 0 - возврат ещё не сохранён
+	Version int
+}
+
+// RefundAmount - сумма к возврату покупателю. Доставка не возвращается
+func (r *Return) RefundAmount() (Money, error) {
+	if len(r.Items) == 0 {
+		return Money{Currency: DefaultCurrency}, nil
+	}
+
+	amount := Money{Currency: r.Items[0].Refund.Currency}
+	for _, item := range r.Items {
+		var err error
+		amount, err = amount.Add(item.Refund)
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return amount, nil
+}
+
+// IsActive - позиции возврата зарезервированы: их нельзя вернуть повторно
+func (r *Return) IsActive() bool {
+	return r.Status != ReturnRejected
+}
+
+type ReturnStatusChange struct {
+	ReturnID  uuid.UUID
+	OldStatus ReturnStatus
+	NewStatus ReturnStatus
+	ChangedBy string
+	Reason    string
+	ChangedAt time.Time
+}
+
+type ReturnRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(r *Return) error
+	Find(id uuid.UUID) (*Return, error)
+	// FindByOrder возвращает все возвраты заказа от старых к новым
+	FindByOrder(orderID uuid.UUID) ([]Return, error)
+}
+
+type ReturnStatusHistoryRepository interface {
+	Append(change *ReturnStatusChange) error
+}
+
+// PaidItemAmounts - сколько покупатель заплатил за каждую позицию заказа: сумма позиции
+// за вычетом доли скидки плюс налог, если цены указаны без него. Для заказов без налога
+// сумма считается так же, но без налога
+func PaidItemAmounts(order *Order) (map[uuid.UUID]Money, error) {
+	tax := order.Tax
+	if tax == nil {
+		var err error
+		tax, err = CalculateTax(order, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	amounts := make(map[uuid.UUID]Money, len(tax.Lines))
+	for _, line := range tax.Lines {
+		amounts[line.ItemID] = line.Gross
+	}
+	return amounts, nil
+}
+
+// ItemRefund - часть суммы paid, оплаченной за quantity единиц позиции, приходящаяся на returning
+// единиц, если returned единиц уже возвращено. Суммы округляются так, что возврат всех единиц
+// по частям в сумме даёт ровно paid
+func ItemRefund(paid Money, quantity, returned, returning int) Money {
+	before, _ := mulDiv(paid.Amount, int64(returned), int64(quantity))
+	after, _ := mulDiv(paid.Amount, int64(returned+returning), int64(quantity))
+	return Money{Amount: after - before, Currency: paid.Currency}
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrShipmentNotFound = errors.New("shipment not found")
+	// ErrInvalidShipmentItems - пустой список позиций, повторяющиеся позиции или неположительное количество
+	ErrInvalidShipmentItems = errors.New("invalid shipment items")
+)
+
+// ShipmentStatus - шаг доставки отправления: Packed -> Shipped -> Delivered
+type ShipmentStatus int
+
+const (
+	ShipmentPacked ShipmentStatus = iota
+	ShipmentShipped
+	ShipmentDelivered
+)
+
+var shipmentStatusTransitions = map[ShipmentStatus][]ShipmentStatus{
+	ShipmentPacked:    {ShipmentShipped},
+	ShipmentShipped:   {ShipmentDelivered},
+	ShipmentDelivered: {},
+}
+
+func (s ShipmentStatus) CanTransitionTo(status ShipmentStatus) bool {
+	for _, allowed := range shipmentStatusTransitions[s] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ShipmentItem - отправляемое количество позиции заказа. Название и цена копируются из позиции
+type ShipmentItem struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Name      string
+	Price     Money
+	Quantity  int
+}
+
+// Shipment - отправление с частью или всеми позициями оплаченного заказа
+type Shipment struct {
+	ID         uuid.UUID
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Status     ShipmentStatus
+	Items      []ShipmentItem
+	// Carrier и TrackingNumber пустые, пока отправление не передано перевозчику
+	Carrier        string
+	TrackingNumber string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ShippedAt      *time.Time
+	DeliveredAt    *time.Time
+	// Version не даёт дважды перевести отправление в следующий статус, 0 - отправление ещё не сохранено
+	Version int
+}
+
+type ShipmentRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(shipment *Shipment) error
+	Find(id uuid.UUID) (*Shipment, error)
+	// FindByOrder возвращает все отправления заказа от старых к новым
+	FindByOrder(orderID uuid.UUID) ([]Shipment, error)
+}
@@ -0,0 +1,310 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidTaxRule  = errors.New("invalid tax rule")
+	ErrTaxRuleNotFound = errors.New("tax rule not found")
+)
+
+// TaxRate - ставка налога в сотых долях процента: 2000 - это 20%
+type TaxRate int
+
+// MaxTaxRate - 100%
+const MaxTaxRate TaxRate = 10000
+
+func (r TaxRate) String() string {
+	return fmt.Sprintf("%d.%02d%%", r/100, r%100)
+}
+
+type TaxPricing int
+
+const (
+	// TaxExclusive - цены указаны без налога, налог добавляется к сумме заказа
+	TaxExclusive TaxPricing = iota
+	// TaxInclusive - налог уже входит в цены и выделяется из них
+	TaxInclusive
+)
+
+type TaxRounding int
+
+const (
+	// TaxRoundPerLine - налог округляется в каждой позиции, налог заказа - сумма округлённых
+	TaxRoundPerLine TaxRounding = iota
+	// TaxRoundPerOrder - налог округляется один раз по сумме всех позиций правила
+	// и затем делится между ними пропорционально их суммам
+	TaxRoundPerOrder
+)
+
+const maxTaxCategoryLength = 64
+
+// TaxRule - ставка для товаров категории Category при доставке в страну Country.
+// Пустые Category и Country подходят к любой категории и любой стране
+type TaxRule struct {
+	Category string
+	// Country - код ISO 3166-1 alpha-2
+	Country  string
+	Rate     TaxRate
+	Pricing  TaxPricing
+	Rounding TaxRounding
+}
+
+// NormalizeTaxCategory приводит категорию к виду, в котором её хранит сервис product
+func NormalizeTaxCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+func (r TaxRule) Normalize() TaxRule {
+	r.Category = NormalizeTaxCategory(r.Category)
+	r.Country = strings.ToUpper(strings.TrimSpace(r.Country))
+	return r
+}
+
+func (r TaxRule) Validate() error {
+	if len(r.Category) > maxTaxCategoryLength ||
+		(r.Country != "" && !isCountryCode(r.Country)) ||
+		r.Rate < 0 || r.Rate > MaxTaxRate ||
+		(r.Pricing != TaxExclusive && r.Pricing != TaxInclusive) ||
+		(r.Rounding != TaxRoundPerLine && r.Rounding != TaxRoundPerOrder) {
+		return ErrInvalidTaxRule
+	}
+	return nil
+}
+
+// specificity - правило с категорией точнее правила со страной, а оба точнее правила по умолчанию
+func (r TaxRule) specificity() int {
+	specificity := 0
+	if r.Category != "" {
+		specificity += 2
+	}
+	if r.Country != "" {
+		specificity++
+	}
+	return specificity
+}
+
+func (r TaxRule) matches(category, country string) bool {
+	return (r.Category == "" || r.Category == category) && (r.Country == "" || r.Country == country)
+}
+
+// FindTaxRule возвращает самое точное из подходящих правил или nil, если не подходит ни одно
+func FindTaxRule(rules []TaxRule, category, country string) *TaxRule {
+	var found *TaxRule
+	for i := range rules {
+		rule := &rules[i]
+		if rule.matches(category, country) && (found == nil || rule.specificity() > found.specificity()) {
+			found = rule
+		}
+	}
+	return found
+}
+
+// TaxLine - налог по позиции заказа с учётом её доли скидки. Net + Tax = Gross
+type TaxLine struct {
+	ItemID  uuid.UUID
+	Rate    TaxRate
+	Pricing TaxPricing
+	Net     Money
+	Tax     Money
+	Gross   Money
+}
+
+// OrderTax - налог по заказу. Net и Gross включают доставку, которая налогом не облагается,
+// поэтому Gross совпадает с суммой к оплате
+type OrderTax struct {
+	Lines []TaxLine
+	Net   Money
+	Tax   Money
+	Gross Money
+}
+
+// AddedTax - налог позиций с ценами без налога, который добавляется к сумме заказа
+func (t *OrderTax) AddedTax() Money {
+	added := Money{Currency: t.Tax.Currency}
+	for _, line := range t.Lines {
+		if line.Pricing == TaxExclusive {
+			added.Amount += line.Tax.Amount
+		}
+	}
+	return added
+}
+
+type TaxRuleRepository interface {
+	// Store создаёт правило или заменяет правило с теми же категорией и страной
+	Store(rule *TaxRule) error
+	Delete(category, country string) error
+	// FindByCountry возвращает правила для страны country и правила без страны
+	FindByCountry(country string) ([]TaxRule, error)
+}
+
+// CalculateTax считает налог по позициям заказа. Налоговая база позиции - её сумма за вычетом
+// доли скидки, скидка делится между позициями пропорционально их суммам.
+// Страна берётся из адреса доставки, без доставки подходят только правила без страны
+func CalculateTax(order *Order, rules []TaxRule) (*OrderTax, error) {
+	subtotal, err := order.Subtotal()
+	if err != nil {
+		return nil, err
+	}
+	discount, err := order.Discount()
+	if err != nil {
+		return nil, err
+	}
+	shippingCost, err := order.ShippingCost()
+	if err != nil {
+		return nil, err
+	}
+
+	if shippingCost.Currency != subtotal.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	country := ""
+	if order.Delivery != nil {
+		country = order.Delivery.Address.Country
+	}
+
+	currency := subtotal.Currency
+	bases := make([]int64, len(order.Items))
+	for i, item := range order.Items {
+		bases[i] = item.Total().Amount
+	}
+	for i, share := range allocate(discount.Amount, bases) {
+		bases[i] -= share
+	}
+
+	lineRules := make([]*TaxRule, len(order.Items))
+	taxes := make([]int64, len(order.Items))
+	perOrderGroups := map[*TaxRule][]int{}
+	for i, item := range order.Items {
+		rule := FindTaxRule(rules, item.Category, country)
+		lineRules[i] = rule
+		switch {
+		case rule == nil:
+		case rule.Rounding == TaxRoundPerOrder:
+			perOrderGroups[rule] = append(perOrderGroups[rule], i)
+		default:
+			taxes[i] = lineTax(bases[i], rule.Rate, rule.Pricing)
+		}
+	}
+	for rule, lines := range perOrderGroups {
+		groupBases := make([]int64, len(lines))
+		groupBase := int64(0)
+		for j, i := range lines {
+			groupBases[j] = bases[i]
+			groupBase += bases[i]
+		}
+		for j, share := range allocate(lineTax(groupBase, rule.Rate, rule.Pricing), groupBases) {
+			taxes[lines[j]] = share
+		}
+	}
+
+	tax := &OrderTax{
+		Lines: make([]TaxLine, 0, len(order.Items)),
+		Net:   shippingCost,
+		Tax:   Money{Currency: currency},
+		Gross: shippingCost,
+	}
+	for i, item := range order.Items {
+		line := TaxLine{
+			ItemID: item.ID,
+			Net:    Money{Amount: bases[i], Currency: currency},
+			Tax:    Money{Amount: taxes[i], Currency: currency},
+			Gross:  Money{Amount: bases[i], Currency: currency},
+		}
+		if rule := lineRules[i]; rule != nil {
+			line.Rate = rule.Rate
+			line.Pricing = rule.Pricing
+		}
+		if line.Pricing == TaxInclusive {
+			line.Net.Amount -= line.Tax.Amount
+		} else {
+			line.Gross.Amount += line.Tax.Amount
+		}
+
+		tax.Lines = append(tax.Lines, line)
+		tax.Net.Amount += line.Net.Amount
+		tax.Tax.Amount += line.Tax.Amount
+		tax.Gross.Amount += line.Gross.Amount
+	}
+	return tax, nil
+}
+
+// allocate делит total между позициями пропорционально weights методом наибольших остатков:
+// каждая позиция получает округлённую вниз долю, а оставшиеся копейки достаются позициям
+// с наибольшей отброшенной дробной частью. Если total не больше суммы weights,
+// доля позиции не превышает её вес
+func allocate(total int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	sum := int64(0)
+	for _, weight := range weights {
+		sum += weight
+	}
+	if total == 0 || sum == 0 {
+		return shares
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		shares[i], remainders[i] = mulDiv(weight, total, sum)
+		allocated += shares[i]
+	}
+
+	byRemainder := make([]int, len(weights))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	sort.SliceStable(byRemainder, func(a, b int) bool {
+		return remainders[byRemainder[a]].Cmp(remainders[byRemainder[b]]) > 0
+	})
+	for _, i := range byRemainder[:total-allocated] {
+		shares[i]++
+	}
+	return shares
+}
+
+// lineTax считает налог с суммы amount, округляя половину копейки вверх
+func lineTax(amount int64, rate TaxRate, pricing TaxPricing) int64 {
+	if pricing == TaxInclusive {
+		return divRound(amount*int64(rate), int64(MaxTaxRate+rate))
+	}
+	return divRound(amount*int64(rate), int64(MaxTaxRate))
+}
+
+// mulDiv возвращает частное и остаток от a*b/c без переполнения int64
+func mulDiv(a, b, c int64) (int64, *big.Int) {
+	quotient, remainder := new(big.Int).QuoRem(
+		new(big.Int).Mul(big.NewInt(a), big.NewInt(b)),
+		big.NewInt(c),
+		new(big.Int),
+	)
+	return quotient.Int64(), remainder
+}
+
+// divRound делит с округлением половины от нуля
+func divRound(numerator, denominator int64) int64 {
+	if numerator < 0 {
+		return -divRound(-numerator, denominator)
+	}
+	return (2*numerator + denominator) / (2 * denominator)
+}
+
+func isCountryCode(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
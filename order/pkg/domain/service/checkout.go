@@ -0,0 +1,249 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+var (
+	ErrEmptyOrder           = errors.New("order has no items")
+	ErrInvalidCheckoutState = errors.New("checkout saga is not in the expected state")
+)
+
+// checkoutActor - автор смены статуса заказа в истории, когда статус меняет сага
+const checkoutActor = "checkout"
+
+// Checkout - локальные шаги саги оформления заказа. Каждый шаг проверяет текущее
+// состояние саги, поэтому повторный или запоздавший вызов шага не меняет данные
+type Checkout interface {
+	StartCheckout(orderID uuid.UUID) (uuid.UUID, error)
+	// PaymentInitiated фиксирует созданный в сервисе payment платёж
+	PaymentInitiated(sagaID, paymentID uuid.UUID) error
+	// PaymentCompleted переводит оплаченный заказ в Paid. Если заказ за это время
+	// вышел из Pending, сага переходит к возврату платежа
+	PaymentCompleted(sagaID uuid.UUID) error
+	// PaymentDeclined отменяет заказ, платёж по которому отклонён
+	PaymentDeclined(sagaID uuid.UUID, reason string) error
+	// AbortCheckout - компенсация до создания платежа: заказ возвращается в Open
+	AbortCheckout(sagaID uuid.UUID, reason string) error
+	PaymentRefunded(sagaID uuid.UUID) error
+	CustomerNotified(sagaID uuid.UUID) error
+	// StepFailed фиксирует неудачную попытку текущего шага, чтобы повторить его позже
+	StepFailed(sagaID uuid.UUID, reason string) (*model.CheckoutSaga, error)
+}
+
+func NewCheckoutService(
+	orderRepo model.OrderRepository,
+	sagaRepo model.CheckoutSagaRepository,
+	promoCodeRepo model.PromoCodeRepository,
+	orderService Order,
+) Checkout {
+	return &checkoutService{
+		orderRepo:     orderRepo,
+		sagaRepo:      sagaRepo,
+		promoCodeRepo: promoCodeRepo,
+		orderService:  orderService,
+	}
+}
+
+type checkoutService struct {
+	orderRepo     model.OrderRepository
+	sagaRepo      model.CheckoutSagaRepository
+	promoCodeRepo model.PromoCodeRepository
+	orderService  Order
+}
+
+func (s *checkoutService) StartCheckout(orderID uuid.UUID) (uuid.UUID, error) {
+	order, err := s.orderRepo.Find(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if order.Status != model.Open {
+		return uuid.Nil, ErrInvalidOrderStatus
+	}
+	if len(order.Items) == 0 {
+		return uuid.Nil, ErrEmptyOrder
+	}
+	if err = s.checkPromoCode(order); err != nil {
+		return uuid.Nil, err
+	}
+
+	err = s.orderService.SetStatus(orderID, model.Pending, checkoutActor, "checkout started")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// Сумма берётся после перевода в Pending, так как при этом пересчитывается налог
+	order, err = s.orderRepo.Find(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	amount, err := order.Total()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	sagaID, err := s.sagaRepo.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	return sagaID, s.sagaRepo.Store(&model.CheckoutSaga{
+		ID:         sagaID,
+		OrderID:    orderID,
+		CustomerID: order.CustomerID,
+		Amount:     amount,
+		State:      model.CheckoutOrderPending,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	})
+}
+
+func (s *checkoutService) PaymentInitiated(sagaID, paymentID uuid.UUID) error {
+	saga, err := s.findSaga(sagaID, model.CheckoutOrderPending)
+	if err != nil {
+		return err
+	}
+
+	saga.PaymentID = paymentID
+	return s.moveTo(saga, model.CheckoutPaymentInitiated, "")
+}
+
+func (s *checkoutService) PaymentCompleted(sagaID uuid.UUID) error {
+	saga, err := s.findSaga(sagaID, model.CheckoutPaymentInitiated)
+	if err != nil {
+		return err
+	}
+
+	err = s.orderService.SetStatus(saga.OrderID, model.Paid, checkoutActor, "payment completed")
+	if errors.Is(err, ErrInvalidStatusTransition) || errors.Is(err, model.ErrOrderNotFound) {
+		return s.moveTo(saga, model.CheckoutRefundingPayment, "order can not be paid: "+err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.moveTo(saga, model.CheckoutOrderPaid, "")
+}
+
+func (s *checkoutService) PaymentDeclined(sagaID uuid.UUID, reason string) error {
+	saga, err := s.findSaga(sagaID, model.CheckoutPaymentInitiated)
+	if err != nil {
+		return err
+	}
+
+	err = s.cancelOrder(saga.OrderID, model.Cancelled, "payment declined: "+reason)
+	if err != nil {
+		return err
+	}
+
+	return s.moveTo(saga, model.CheckoutCancelled, reason)
+}
+
+func (s *checkoutService) AbortCheckout(sagaID uuid.UUID, reason string) error {
+	saga, err := s.findSaga(sagaID, model.CheckoutOrderPending)
+	if err != nil {
+		return err
+	}
+
+	err = s.cancelOrder(saga.OrderID, model.Open, "checkout failed: "+reason)
+	if err != nil {
+		return err
+	}
+
+	return s.moveTo(saga, model.CheckoutFailed, reason)
+}
+
+func (s *checkoutService) PaymentRefunded(sagaID uuid.UUID) error {
+	saga, err := s.findSaga(sagaID, model.CheckoutRefundingPayment)
+	if err != nil {
+		return err
+	}
+
+	// Причина остаётся той, из-за которой пришлось вернуть платёж
+	return s.moveTo(saga, model.CheckoutFailed, saga.FailureReason)
+}
+
+func (s *checkoutService) CustomerNotified(sagaID uuid.UUID) error {
+	saga, err := s.findSaga(sagaID, model.CheckoutOrderPaid)
+	if err != nil {
+		return err
+	}
+
+	return s.moveTo(saga, model.CheckoutCompleted, "")
+}
+
+func (s *checkoutService) StepFailed(sagaID uuid.UUID, reason string) (*model.CheckoutSaga, error) {
+	saga, err := s.sagaRepo.Find(sagaID)
+	if err != nil {
+		return nil, err
+	}
+	if saga.State.IsFinished() {
+		return nil, ErrInvalidCheckoutState
+	}
+
+	saga.Attempts++
+	saga.FailureReason = reason
+	saga.UpdatedAt = time.Now()
+	return saga, s.sagaRepo.Store(saga)
+}
+
+// checkPromoCode не даёт оплатить заказ со скидкой по промокоду, срок действия которого истёк
+// после применения или лимит использования которого исчерпали заказы, оформленные раньше
+func (s *checkoutService) checkPromoCode(order *model.Order) error {
+	if order.PromoCode == nil {
+		return nil
+	}
+
+	promoCode, err := s.promoCodeRepo.Find(order.PromoCode.Code)
+	if errors.Is(err, model.ErrPromoCodeNotFound) {
+		return ErrPromoCodeNotActive
+	}
+	if err != nil {
+		return err
+	}
+	if !promoCode.IsActive(time.Now()) {
+		return ErrPromoCodeNotActive
+	}
+	if err = checkPromoCodeUsageLimits(s.promoCodeRepo, promoCode, order.CustomerID); err != nil {
+		return err
+	}
+
+	// Сохранение повышает версию промокода: из параллельных оформлений с одним кодом
+	// пройдёт только одно, и лимиты не будут превышены
+	return s.promoCodeRepo.Store(promoCode)
+}
+
+// cancelOrder откатывает заказ из Pending. Если заказ уже вышел из Pending
+// (например, его отменили вручную), откатывать нечего
+func (s *checkoutService) cancelOrder(orderID uuid.UUID, status model.OrderStatus, reason string) error {
+	err := s.orderService.SetStatus(orderID, status, checkoutActor, reason)
+	if errors.Is(err, ErrInvalidStatusTransition) || errors.Is(err, model.ErrOrderNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *checkoutService) findSaga(sagaID uuid.UUID, expectedState model.CheckoutSagaState) (*model.CheckoutSaga, error) {
+	saga, err := s.sagaRepo.Find(sagaID)
+	if err != nil {
+		return nil, err
+	}
+	if saga.State != expectedState {
+		return nil, ErrInvalidCheckoutState
+	}
+	return saga, nil
+}
+
+func (s *checkoutService) moveTo(saga *model.CheckoutSaga, state model.CheckoutSagaState, reason string) error {
+	saga.State = state
+	saga.FailureReason = reason
+	saga.Attempts = 0
+	saga.UpdatedAt = time.Now()
+	return s.sagaRepo.Store(saga)
+}
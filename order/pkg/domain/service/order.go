@@ -10,9 +10,17 @@ import (
 )
 
 var (
-	ErrInvalidOrderStatus = errors.New("invalid order status")
+	ErrInvalidOrderStatus      = errors.New("invalid order status")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrInvalidQuantity         = errors.New("item quantity must be positive")
+	ErrCancelReasonRequired    = errors.New("cancellation reason is required")
+	ErrNothingToReorder        = errors.New("none of the ordered products is available anymore")
+	ErrOrderAlreadyShipped     = errors.New("order with shipped items cannot be cancelled")
 )
 
+// expiryActor - автор отмены заказа, просроченного в статусе Pending
+const expiryActor = "expiry"
+
 type Event interface {
 	Type() string
 }
@@ -22,62 +30,159 @@ type EventDispatcher interface {
 }
 
 type Order interface {
-	CreateOrder(customerID uuid.UUID) (uuid.UUID, error)
+	// CreateOrder создаёт открытый заказ. Доставку можно указать сразу или позже через SetDelivery
+	CreateOrder(customerID uuid.UUID, delivery *model.Delivery) (uuid.UUID, error)
 	DeleteOrder(orderID uuid.UUID) error
-	SetStatus(orderID uuid.UUID, status model.OrderStatus) error
-
-	AddItem(orderID uuid.UUID, productID uuid.UUID, price float64) (uuid.UUID, error)
+	RestoreOrder(orderID uuid.UUID) error
+	// SetStatus меняет статус заказа. Оплаченный заказ нельзя отменить, если хотя бы одно
+	// его отправление уже передано перевозчику: отмена вернула бы весь остаток платежа
+	SetStatus(orderID uuid.UUID, status model.OrderStatus, changedBy, reason string) error
+	// CancelOrder отменяет заказ с обязательной причиной. Повторная отмена ничего не меняет
+	CancelOrder(orderID uuid.UUID, cancelledBy, reason string) error
+	// ExpireOrder отменяет заказ, который перешёл в Pending раньше pendingBefore и так и не был оплачен.
+	// Заказ, который уже вышел из Pending или был оформлен заново позже, не трогает и возвращает false
+	ExpireOrder(orderID uuid.UUID, pendingBefore time.Time) (bool, error)
+
+	AddItem(orderID uuid.UUID, product model.Product, quantity int) (uuid.UUID, error)
+	UpdateItemQuantity(orderID uuid.UUID, itemID uuid.UUID, quantity int) error
 	DeleteItem(orderID uuid.UUID, itemID uuid.UUID) error
+
+	// SetDelivery задаёт адрес и способ доставки открытого заказа, заменяя прежние
+	SetDelivery(orderID uuid.UUID, delivery model.Delivery) error
+
+	// ReorderFrom создаёт открытый заказ того же покупателя с позициями заказа sourceOrderID
+	// по текущим ценам из products. Товары, которых нет в products, пропускаются
+	ReorderFrom(sourceOrderID uuid.UUID, products map[uuid.UUID]model.Product) (ReorderResult, error)
+}
+
+// ReorderResult - новый заказ и отличия его позиций от исходного заказа
+type ReorderResult struct {
+	OrderID uuid.UUID
+	// Dropped - позиции исходного заказа, товаров которых больше нет
+	Dropped []model.Item
+	// Repriced - позиции, цена которых изменилась
+	Repriced []RepricedItem
+}
+
+type RepricedItem struct {
+	ProductID uuid.UUID
+	Name      string
+	OldPrice  model.Money
+	NewPrice  model.Money
 }
 
-func NewOrderService(repo model.OrderRepository, dispatcher EventDispatcher) Order {
+func NewOrderService(
+	repo model.OrderRepository,
+	historyRepo model.OrderStatusHistoryRepository,
+	taxRuleRepo model.TaxRuleRepository,
+	shipmentRepo model.ShipmentRepository,
+	dispatcher EventDispatcher,
+) Order {
 	return &orderService{
-		repo:       repo,
-		dispatcher: dispatcher,
+		repo:         repo,
+		historyRepo:  historyRepo,
+		taxRuleRepo:  taxRuleRepo,
+		shipmentRepo: shipmentRepo,
+		dispatcher:   dispatcher,
 	}
 }
 
 type orderService struct {
-	repo       model.OrderRepository
-	dispatcher EventDispatcher
+	repo         model.OrderRepository
+	historyRepo  model.OrderStatusHistoryRepository
+	taxRuleRepo  model.TaxRuleRepository
+	shipmentRepo model.ShipmentRepository
+	dispatcher   EventDispatcher
 }
 
-func (o *orderService) CreateOrder(customerID uuid.UUID) (uuid.UUID, error) {
+func (o *orderService) CreateOrder(customerID uuid.UUID, delivery *model.Delivery) (uuid.UUID, error) {
+	if delivery != nil {
+		normalized, err := normalizeDelivery(*delivery)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		delivery = &normalized
+	}
+
 	orderID, err := o.repo.NextID()
 	if err != nil {
 		return uuid.Nil, err
 	}
 
 	currentTime := time.Now()
-	err = o.repo.Store(&model.Order{
+	order := &model.Order{
 		ID:         orderID,
 		CustomerID: customerID,
 		Status:     model.Open,
+		Delivery:   delivery,
 		CreatedAt:  currentTime,
 		UpdatedAt:  currentTime,
-	})
-	if err != nil {
+	}
+	if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+		return uuid.Nil, err
+	}
+	if err = o.repo.Store(order); err != nil {
 		return uuid.Nil, err
 	}
 
+	total, err := order.Total()
+	if err != nil {
+		return uuid.Nil, err
+	}
 	return orderID, o.dispatcher.Dispatch(model.OrderCreated{
-		OrderID:    orderID,
-		CustomerID: customerID,
+		EventMetadata: model.NewEventMetadata(currentTime),
+		OrderID:       orderID,
+		CustomerID:    customerID,
+		Delivery:      delivery,
+		Total:         total,
 	})
 }
 
 func (o *orderService) DeleteOrder(orderID uuid.UUID) error {
-	err := o.repo.Delete(orderID)
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+	total, err := order.Total()
+	if err != nil {
+		return err
+	}
+
+	err = o.repo.Delete(orderID)
 	if err != nil {
 		return err
 	}
 
 	return o.dispatcher.Dispatch(model.OrderDeleted{
-		OrderID: orderID,
+		EventMetadata: model.NewEventMetadata(time.Now()),
+		OrderID:       orderID,
+		Total:         total,
 	})
 }
 
-func (o *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) error {
+func (o *orderService) RestoreOrder(orderID uuid.UUID) error {
+	err := o.repo.Restore(orderID)
+	if err != nil {
+		return err
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+	total, err := order.Total()
+	if err != nil {
+		return err
+	}
+
+	return o.dispatcher.Dispatch(model.OrderRestored{
+		EventMetadata: model.NewEventMetadata(time.Now()),
+		OrderID:       orderID,
+		Total:         total,
+	})
+}
+
+func (o *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus, changedBy, reason string) error {
 	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return err
@@ -87,23 +192,119 @@ func (o *orderService) SetStatus(orderID uuid.UUID, status model.OrderStatus) er
 	if oldStatus == status {
 		return nil
 	}
+	if !oldStatus.CanTransitionTo(status) {
+		return ErrInvalidStatusTransition
+	}
+	if oldStatus == model.Paid && status == model.Cancelled {
+		if err = o.checkNotShipped(orderID); err != nil {
+			return err
+		}
+	}
 
+	// Налог фиксируется при оформлении по правилам, действующим на этот момент
+	if oldStatus == model.Open && status == model.Pending {
+		if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+			return err
+		}
+	}
+
+	currentTime := time.Now()
 	order.Status = status
-	order.UpdatedAt = time.Now()
+	order.UpdatedAt = currentTime
+	if status == model.Pending {
+		order.PendingAt = &currentTime
+	}
 
 	err = o.repo.Store(order)
 	if err != nil {
 		return err
 	}
 
-	return o.dispatcher.Dispatch(model.OrderStatusChanged{
+	err = o.historyRepo.Append(&model.StatusChange{
 		OrderID:   orderID,
 		OldStatus: oldStatus,
 		NewStatus: status,
+		ChangedBy: changedBy,
+		Reason:    reason,
+		ChangedAt: currentTime,
 	})
+	if err != nil {
+		return err
+	}
+
+	total, err := order.Total()
+	if err != nil {
+		return err
+	}
+	err = o.dispatcher.Dispatch(model.OrderStatusChanged{
+		EventMetadata: model.NewEventMetadata(currentTime),
+		OrderID:       orderID,
+		OldStatus:     oldStatus,
+		NewStatus:     status,
+		Delivery:      order.Delivery,
+		Total:         total,
+	})
+	if err != nil || status != model.Cancelled {
+		return err
+	}
+
+	// Отмена через SetStatus тоже должна вернуть деньги за оплаченный заказ
+	return o.dispatcher.Dispatch(model.OrderCancelled{
+		EventMetadata:  model.NewEventMetadata(currentTime),
+		OrderID:        orderID,
+		CustomerID:     order.CustomerID,
+		Total:          total,
+		Reason:         reason,
+		CancelledBy:    changedBy,
+		RefundRequired: oldStatus == model.Paid,
+	})
+}
+
+// checkNotShipped возвращает ErrOrderAlreadyShipped, если отправление заказа уже вышло из статуса Packed
+func (o *orderService) checkNotShipped(orderID uuid.UUID) error {
+	shipments, err := o.shipmentRepo.FindByOrder(orderID)
+	if err != nil {
+		return err
+	}
+	for _, shipment := range shipments {
+		if shipment.Status != model.ShipmentPacked {
+			return ErrOrderAlreadyShipped
+		}
+	}
+	return nil
+}
+
+func (o *orderService) CancelOrder(orderID uuid.UUID, cancelledBy, reason string) error {
+	if reason == "" {
+		return ErrCancelReasonRequired
+	}
+	return o.SetStatus(orderID, model.Cancelled, cancelledBy, reason)
+}
+
+func (o *orderService) ExpireOrder(orderID uuid.UUID, pendingBefore time.Time) (bool, error) {
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return false, err
+	}
+	if order.Status != model.Pending || order.PendingAt == nil || !order.PendingAt.Before(pendingBefore) {
+		return false, nil
+	}
+
+	return true, o.CancelOrder(orderID, expiryActor, "expired")
 }
 
-func (o *orderService) AddItem(orderID, productID uuid.UUID, price float64) (uuid.UUID, error) {
+// AddItem добавляет позицию в заказ, фиксируя в ней название и цену товара.
+// Если товар уже есть в заказе, количество прибавляется к существующей позиции,
+// а её название и цена обновляются на актуальные
+func (o *orderService) AddItem(orderID uuid.UUID, product model.Product, quantity int) (uuid.UUID, error) {
+	if quantity <= 0 {
+		return uuid.Nil, ErrInvalidQuantity
+	}
+	price := product.Price
+	if price.IsNegative() {
+		return uuid.Nil, model.ErrInvalidAmount
+	}
+
 	order, err := o.repo.Find(orderID)
 	if err != nil {
 		return uuid.Nil, err
@@ -113,26 +314,106 @@ func (o *orderService) AddItem(orderID, productID uuid.UUID, price float64) (uui
 		return uuid.Nil, ErrInvalidOrderStatus
 	}
 
+	// Все позиции и доставка заказа должны быть в одной валюте, иначе сумму заказа не посчитать
+	if len(order.Items) > 0 && order.Items[0].Price.Currency != price.Currency {
+		return uuid.Nil, model.ErrCurrencyMismatch
+	}
+	if order.Delivery != nil && order.Delivery.Cost.Currency != price.Currency {
+		return uuid.Nil, model.ErrCurrencyMismatch
+	}
+
+	if itemIndex, found := findItemIndexByProduct(order.Items, product.ID); found {
+		item := &order.Items[itemIndex]
+		item.Quantity += quantity
+		item.Name = product.Name
+		item.Category = product.Category
+		item.Price = price
+		order.UpdatedAt = time.Now()
+
+		if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+			return uuid.Nil, err
+		}
+		err = o.repo.Store(order)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		event, err := newItemChangedEvent(order, nil, []model.Item{*item}, nil)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return item.ID, o.dispatcher.Dispatch(event)
+	}
+
 	itemID, err := o.repo.NextID()
 	if err != nil {
 		return uuid.Nil, err
 	}
-	order.Items = append(order.Items, model.Item{
+	item := model.Item{
 		ID:        itemID,
-		ProductID: productID,
+		ProductID: product.ID,
+		Name:      product.Name,
+		Category:  product.Category,
 		Price:     price,
-	})
+		Quantity:  quantity,
+	}
+	order.Items = append(order.Items, item)
 	order.UpdatedAt = time.Now()
 
+	if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+		return uuid.Nil, err
+	}
 	err = o.repo.Store(order)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	return itemID, o.dispatcher.Dispatch(model.OrderItemChanged{
-		OrderID:    orderID,
-		AddedItems: []uuid.UUID{itemID},
-	})
+	event, err := newItemChangedEvent(order, []model.Item{item}, nil, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return itemID, o.dispatcher.Dispatch(event)
+}
+
+func (o *orderService) UpdateItemQuantity(orderID, itemID uuid.UUID, quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Open {
+		return ErrInvalidOrderStatus
+	}
+
+	itemIndex, found := findItemIndex(order.Items, itemID)
+	if !found {
+		return model.ErrItemNotFound
+	}
+
+	if order.Items[itemIndex].Quantity == quantity {
+		return nil
+	}
+
+	order.Items[itemIndex].Quantity = quantity
+	order.UpdatedAt = time.Now()
+
+	if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+		return err
+	}
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	event, err := newItemChangedEvent(order, nil, []model.Item{order.Items[itemIndex]}, nil)
+	if err != nil {
+		return err
+	}
+	return o.dispatcher.Dispatch(event)
 }
 
 func (o *orderService) DeleteItem(orderID, itemID uuid.UUID) error {
@@ -150,18 +431,207 @@ func (o *orderService) DeleteItem(orderID, itemID uuid.UUID) error {
 		return model.ErrItemNotFound
 	}
 
+	removedItem := order.Items[itemIndex]
 	order.Items = append(order.Items[:itemIndex], order.Items[itemIndex+1:]...)
 	order.UpdatedAt = time.Now()
 
+	if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+		return err
+	}
+	err = o.repo.Store(order)
+	if err != nil {
+		return err
+	}
+
+	event, err := newItemChangedEvent(order, nil, nil, []model.Item{removedItem})
+	if err != nil {
+		return err
+	}
+	return o.dispatcher.Dispatch(event)
+}
+
+func (o *orderService) SetDelivery(orderID uuid.UUID, delivery model.Delivery) error {
+	delivery, err := normalizeDelivery(delivery)
+	if err != nil {
+		return err
+	}
+
+	order, err := o.repo.Find(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != model.Open {
+		return ErrInvalidOrderStatus
+	}
+
+	order.Delivery = &delivery
+	// Стоимость доставки должна быть в валюте позиций заказа
+	if _, err = order.Total(); err != nil {
+		return err
+	}
+	// Ставки зависят от страны доставки
+	if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+		return err
+	}
+	order.UpdatedAt = time.Now()
+
 	err = o.repo.Store(order)
 	if err != nil {
 		return err
 	}
 
-	return o.dispatcher.Dispatch(model.OrderItemChanged{
-		OrderID:      orderID,
-		RemovedItems: []uuid.UUID{itemID},
+	total, err := order.Total()
+	if err != nil {
+		return err
+	}
+	return o.dispatcher.Dispatch(model.OrderDeliveryChanged{
+		EventMetadata: model.NewEventMetadata(order.UpdatedAt),
+		OrderID:       orderID,
+		Delivery:      delivery,
+		Total:         total,
+	})
+}
+
+func (o *orderService) ReorderFrom(sourceOrderID uuid.UUID, products map[uuid.UUID]model.Product) (ReorderResult, error) {
+	source, err := o.repo.Find(sourceOrderID)
+	if err != nil {
+		return ReorderResult{}, err
+	}
+	if len(source.Items) == 0 {
+		return ReorderResult{}, ErrEmptyOrder
+	}
+
+	var result ReorderResult
+	items := make([]model.Item, 0, len(source.Items))
+	for _, sourceItem := range source.Items {
+		product, found := products[sourceItem.ProductID]
+		if !found {
+			result.Dropped = append(result.Dropped, sourceItem)
+			continue
+		}
+		if product.Price.IsNegative() {
+			return ReorderResult{}, model.ErrInvalidAmount
+		}
+		if len(items) > 0 && items[0].Price.Currency != product.Price.Currency {
+			return ReorderResult{}, model.ErrCurrencyMismatch
+		}
+		if product.Price != sourceItem.Price {
+			result.Repriced = append(result.Repriced, RepricedItem{
+				ProductID: product.ID,
+				Name:      product.Name,
+				OldPrice:  sourceItem.Price,
+				NewPrice:  product.Price,
+			})
+		}
+
+		itemID, err := o.repo.NextID()
+		if err != nil {
+			return ReorderResult{}, err
+		}
+		items = append(items, model.Item{
+			ID:        itemID,
+			ProductID: product.ID,
+			Name:      product.Name,
+			Category:  product.Category,
+			Price:     product.Price,
+			Quantity:  sourceItem.Quantity,
+		})
+	}
+	if len(items) == 0 {
+		return ReorderResult{}, ErrNothingToReorder
+	}
+
+	result.OrderID, err = o.repo.NextID()
+	if err != nil {
+		return ReorderResult{}, err
+	}
+
+	currentTime := time.Now()
+	order := &model.Order{
+		ID:         result.OrderID,
+		CustomerID: source.CustomerID,
+		Status:     model.Open,
+		Items:      items,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+	if err = recalculateTax(order, o.taxRuleRepo); err != nil {
+		return ReorderResult{}, err
+	}
+	if err = o.repo.Store(order); err != nil {
+		return ReorderResult{}, err
+	}
+
+	total, err := order.Total()
+	if err != nil {
+		return ReorderResult{}, err
+	}
+	err = o.dispatcher.Dispatch(model.OrderCreated{
+		EventMetadata: model.NewEventMetadata(currentTime),
+		OrderID:       result.OrderID,
+		CustomerID:    source.CustomerID,
+		Total:         total,
 	})
+	if err != nil {
+		return ReorderResult{}, err
+	}
+
+	event, err := newItemChangedEvent(order, items, nil, nil)
+	if err != nil {
+		return ReorderResult{}, err
+	}
+	return result, o.dispatcher.Dispatch(event)
+}
+
+// newItemChangedEvent собирает событие об изменении позиций заказа: снимки позиций
+// и сумма заказа берутся на момент после изменения, удалённые позиции - до удаления
+func newItemChangedEvent(order *model.Order, added, changed, removed []model.Item) (model.OrderItemChanged, error) {
+	total, err := order.Total()
+	if err != nil {
+		return model.OrderItemChanged{}, err
+	}
+
+	event := model.OrderItemChanged{
+		EventMetadata: model.NewEventMetadata(order.UpdatedAt),
+		OrderID:       order.ID,
+		AddedItems:    itemIDs(added),
+		ChangedItems:  itemIDs(changed),
+		RemovedItems:  itemIDs(removed),
+		Total:         total,
+	}
+	for _, items := range [][]model.Item{added, changed, removed} {
+		for _, item := range items {
+			event.Items = append(event.Items, model.NewEventItem(item))
+		}
+	}
+	return event, nil
+}
+
+func itemIDs(items []model.Item) []uuid.UUID {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+// normalizeDelivery проверяет доставку и рассчитывает её стоимость по способу доставки
+func normalizeDelivery(delivery model.Delivery) (model.Delivery, error) {
+	delivery.Address = delivery.Address.Normalize()
+	if err := delivery.Validate(); err != nil {
+		return model.Delivery{}, err
+	}
+
+	cost, err := delivery.Method.Cost()
+	if err != nil {
+		return model.Delivery{}, err
+	}
+	delivery.Cost = cost
+	return delivery, nil
 }
 
 func findItemIndex(items []model.Item, itemID uuid.UUID) (int, bool) {
@@ -172,3 +642,46 @@ func findItemIndex(items []model.Item, itemID uuid.UUID) (int, bool) {
 	}
 	return -1, false
 }
+
+func findItemIndexByProduct(items []model.Item, productID uuid.UUID) (int, bool) {
+	for i, item := range items {
+		if item.ProductID == productID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// RequestedItem - сколько единиц позиции заказа запрошено в отправление или возврат
+type RequestedItem struct {
+	ItemID   uuid.UUID
+	Quantity int
+}
+
+// validateRequestedItems возвращает errInvalid, если список пуст, позиции повторяются
+// или количество не положительное
+func validateRequestedItems(items []RequestedItem, errInvalid error) error {
+	if len(items) == 0 {
+		return errInvalid
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return errInvalid
+		}
+		if _, duplicate := seen[item.ItemID]; duplicate {
+			return errInvalid
+		}
+		seen[item.ItemID] = struct{}{}
+	}
+	return nil
+}
+
+func newEventItems[T any](items []T, newEventItem func(T) model.EventItem) []model.EventItem {
+	eventItems := make([]model.EventItem, 0, len(items))
+	for _, item := range items {
+		eventItems = append(eventItems, newEventItem(item))
+	}
+	return eventItems
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+var (
+	ErrPromoCodeNotActive         = errors.New("promo code is not active")
+	ErrPromoCodeUsageLimitReached = errors.New("promo code usage limit reached")
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+
+// NormalizePromoCode приводит код к виду, в котором он хранится: без пробелов по краям и в верхнем регистре
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+type PromoCode interface {
+	CreatePromoCode(promoCode model.PromoCode) error
+	// ApplyPromoCode применяет промокод к открытому заказу, заменяя ранее применённый
+	ApplyPromoCode(orderID uuid.UUID, code string) error
+	RemovePromoCode(orderID uuid.UUID) error
+}
+
+func NewPromoCodeService(
+	orderRepo model.OrderRepository,
+	promoCodeRepo model.PromoCodeRepository,
+	taxRuleRepo model.TaxRuleRepository,
+	dispatcher EventDispatcher,
+) PromoCode {
+	return &promoCodeService{
+		orderRepo:     orderRepo,
+		promoCodeRepo: promoCodeRepo,
+		taxRuleRepo:   taxRuleRepo,
+		dispatcher:    dispatcher,
+	}
+}
+
+type promoCodeService struct {
+	orderRepo     model.OrderRepository
+	promoCodeRepo model.PromoCodeRepository
+	taxRuleRepo   model.TaxRuleRepository
+	dispatcher    EventDispatcher
+}
+
+func (s *promoCodeService) CreatePromoCode(promoCode model.PromoCode) error {
+	promoCode.Code = NormalizePromoCode(promoCode.Code)
+	if !promoCodePattern.MatchString(promoCode.Code) {
+		return model.ErrInvalidPromoCode
+	}
+	if err := promoCode.Discount.Validate(); err != nil {
+		return err
+	}
+	if promoCode.ValidFrom != nil && promoCode.ValidTo != nil && !promoCode.ValidFrom.Before(*promoCode.ValidTo) {
+		return model.ErrInvalidPromoCode
+	}
+	if promoCode.MaxUses < 0 || promoCode.MaxUsesPerCustomer < 0 {
+		return model.ErrInvalidPromoCode
+	}
+
+	_, err := s.promoCodeRepo.Find(promoCode.Code)
+	if err == nil {
+		return model.ErrPromoCodeExists
+	}
+	if !errors.Is(err, model.ErrPromoCodeNotFound) {
+		return err
+	}
+
+	promoCode.CreatedAt = time.Now()
+	promoCode.Version = 0
+	return s.promoCodeRepo.Store(&promoCode)
+}
+
+func (s *promoCodeService) ApplyPromoCode(orderID uuid.UUID, code string) error {
+	order, err := s.findOpenOrder(orderID)
+	if err != nil {
+		return err
+	}
+
+	code = NormalizePromoCode(code)
+	if order.PromoCode != nil && order.PromoCode.Code == code {
+		return nil
+	}
+
+	promoCode, err := s.promoCodeRepo.Find(code)
+	if err != nil {
+		return err
+	}
+	if !promoCode.IsActive(time.Now()) {
+		return ErrPromoCodeNotActive
+	}
+	if err = checkPromoCodeUsageLimits(s.promoCodeRepo, promoCode, order.CustomerID); err != nil {
+		return err
+	}
+
+	order.PromoCode = &model.AppliedPromoCode{
+		Code:     promoCode.Code,
+		Discount: promoCode.Discount,
+	}
+	// Фиксированная скидка должна быть в валюте заказа
+	if _, err = order.Total(); err != nil {
+		return err
+	}
+	// Скидка уменьшает налоговую базу
+	if err = recalculateTax(order, s.taxRuleRepo); err != nil {
+		return err
+	}
+	order.UpdatedAt = time.Now()
+	if err = s.orderRepo.Store(order); err != nil {
+		return err
+	}
+
+	// Сохранение повышает версию промокода: из параллельных применений одного кода
+	// пройдёт только одно, и лимиты не будут превышены
+	if err = s.promoCodeRepo.Store(promoCode); err != nil {
+		return err
+	}
+
+	total, err := order.Total()
+	if err != nil {
+		return err
+	}
+	return s.dispatcher.Dispatch(model.OrderPromoCodeApplied{
+		EventMetadata: model.NewEventMetadata(order.UpdatedAt),
+		OrderID:       orderID,
+		Code:          promoCode.Code,
+		Total:         total,
+	})
+}
+
+func (s *promoCodeService) RemovePromoCode(orderID uuid.UUID) error {
+	order, err := s.findOpenOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if order.PromoCode == nil {
+		return nil
+	}
+
+	code := order.PromoCode.Code
+	order.PromoCode = nil
+	if err = recalculateTax(order, s.taxRuleRepo); err != nil {
+		return err
+	}
+	order.UpdatedAt = time.Now()
+	if err = s.orderRepo.Store(order); err != nil {
+		return err
+	}
+
+	total, err := order.Total()
+	if err != nil {
+		return err
+	}
+	return s.dispatcher.Dispatch(model.OrderPromoCodeRemoved{
+		EventMetadata: model.NewEventMetadata(order.UpdatedAt),
+		OrderID:       orderID,
+		Code:          code,
+		Total:         total,
+	})
+}
+
+// checkPromoCodeUsageLimits проверяет лимиты использования кода. Открытые заказы код не расходуют,
+// поэтому лимиты проверяются и при применении, и повторно при оформлении заказа
+func checkPromoCodeUsageLimits(promoCodeRepo model.PromoCodeRepository, promoCode *model.PromoCode, customerID uuid.UUID) error {
+	if promoCode.MaxUses > 0 {
+		uses, err := promoCodeRepo.CountUses(promoCode.Code)
+		if err != nil {
+			return err
+		}
+		if uses >= promoCode.MaxUses {
+			return ErrPromoCodeUsageLimitReached
+		}
+	}
+
+	if promoCode.MaxUsesPerCustomer > 0 {
+		uses, err := promoCodeRepo.CountCustomerUses(promoCode.Code, customerID)
+		if err != nil {
+			return err
+		}
+		if uses >= promoCode.MaxUsesPerCustomer {
+			return ErrPromoCodeUsageLimitReached
+		}
+	}
+
+	return nil
+}
+
+func (s *promoCodeService) findOpenOrder(orderID uuid.UUID) (*model.Order, error) {
+	order, err := s.orderRepo.Find(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.Open {
+		return nil, ErrInvalidOrderStatus
+	}
+	return order, nil
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+var (
+	ErrOrderNotReturnable            = errors.New("only paid order can be returned")
+	ErrReturnQuantityExceeded        = errors.New("return quantity exceeds delivered quantity not yet returned")
+	ErrInvalidReturnStatusTransition = errors.New("invalid return status transition")
+	ErrRejectReasonRequired          = errors.New("reject reason is required")
+)
+
+// RequestedReturnItem - сколько единиц позиции заказа покупатель хочет вернуть
+type RequestedReturnItem = RequestedItem
+
+type Return interface {
+	// RequestReturn открывает возврат позиций оплаченного заказа. Вернуть можно только доставленные
+	// покупателю единицы, которые не входят в другие неотклонённые возвраты этого заказа
+	RequestReturn(orderID uuid.UUID, items []RequestedReturnItem, reason, requestedBy string) (uuid.UUID, error)
+	ApproveReturn(returnID uuid.UUID, changedBy, reason string) error
+	RejectReturn(returnID uuid.UUID, changedBy, reason string) error
+	// ReceiveReturn отмечает, что товары получены, и запрашивает возврат денег покупателю
+	ReceiveReturn(returnID uuid.UUID, changedBy, reason string) error
+}
+
+func NewReturnService(
+	orderRepo model.OrderRepository,
+	returnRepo model.ReturnRepository,
+	historyRepo model.ReturnStatusHistoryRepository,
+	shipmentRepo model.ShipmentRepository,
+	dispatcher EventDispatcher,
+) Return {
+	return &returnService{
+		orderRepo:    orderRepo,
+		returnRepo:   returnRepo,
+		historyRepo:  historyRepo,
+		shipmentRepo: shipmentRepo,
+		dispatcher:   dispatcher,
+	}
+}
+
+type returnService struct {
+	orderRepo    model.OrderRepository
+	returnRepo   model.ReturnRepository
+	historyRepo  model.ReturnStatusHistoryRepository
+	shipmentRepo model.ShipmentRepository
+	dispatcher   EventDispatcher
+}
+
+func (s *returnService) RequestReturn(
+	orderID uuid.UUID,
+	items []RequestedReturnItem,
+	reason, requestedBy string,
+) (uuid.UUID, error) {
+	if err := validateRequestedItems(items, model.ErrInvalidReturnItems); err != nil {
+		return uuid.Nil, err
+	}
+
+	order, err := s.orderRepo.Find(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if order.Status != model.Paid {
+		return uuid.Nil, ErrOrderNotReturnable
+	}
+
+	delivered, err := s.deliveredQuantities(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	returned, err := s.returnedQuantities(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	paid, err := model.PaidItemAmounts(order)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	returnItems := make([]model.ReturnItem, 0, len(items))
+	for _, requested := range items {
+		itemIndex, found := findItemIndex(order.Items, requested.ItemID)
+		if !found {
+			return uuid.Nil, model.ErrItemNotFound
+		}
+		item := order.Items[itemIndex]
+		if returned[item.ID]+requested.Quantity > delivered[item.ID] {
+			return uuid.Nil, ErrReturnQuantityExceeded
+		}
+
+		returnItems = append(returnItems, model.ReturnItem{
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Price:     item.Price,
+			Quantity:  requested.Quantity,
+			Refund:    model.ItemRefund(paid[item.ID], item.Quantity, returned[item.ID], requested.Quantity),
+		})
+	}
+
+	returnID, err := s.returnRepo.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	r := &model.Return{
+		ID:         returnID,
+		OrderID:    orderID,
+		CustomerID: order.CustomerID,
+		Status:     model.ReturnRequested,
+		Items:      returnItems,
+		Reason:     reason,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+	refundAmount, err := r.RefundAmount()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err = s.returnRepo.Store(r); err != nil {
+		return uuid.Nil, err
+	}
+
+	// Первая запись истории - открытие возврата, в ней старый и новый статус совпадают
+	err = s.historyRepo.Append(&model.ReturnStatusChange{
+		ReturnID:  returnID,
+		OldStatus: model.ReturnRequested,
+		NewStatus: model.ReturnRequested,
+		ChangedBy: requestedBy,
+		Reason:    reason,
+		ChangedAt: currentTime,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return returnID, s.dispatcher.Dispatch(model.OrderReturnRequested{
+		EventMetadata: model.NewEventMetadata(currentTime),
+		ReturnID:      returnID,
+		OrderID:       orderID,
+		CustomerID:    order.CustomerID,
+		Items:         newEventItems(r.Items, model.NewReturnEventItem),
+		Reason:        reason,
+		RefundAmount:  refundAmount,
+	})
+}
+
+func (s *returnService) ApproveReturn(returnID uuid.UUID, changedBy, reason string) error {
+	_, err := s.setStatus(returnID, model.ReturnApproved, changedBy, reason)
+	return err
+}
+
+func (s *returnService) RejectReturn(returnID uuid.UUID, changedBy, reason string) error {
+	if reason == "" {
+		return ErrRejectReasonRequired
+	}
+	_, err := s.setStatus(returnID, model.ReturnRejected, changedBy, reason)
+	return err
+}
+
+func (s *returnService) ReceiveReturn(returnID uuid.UUID, changedBy, reason string) error {
+	r, err := s.setStatus(returnID, model.ReturnReceived, changedBy, reason)
+	if err != nil {
+		return err
+	}
+
+	refundAmount, err := r.RefundAmount()
+	if err != nil {
+		return err
+	}
+	return s.dispatcher.Dispatch(model.OrderReturnRefundRequested{
+		EventMetadata: model.NewEventMetadata(r.UpdatedAt),
+		ReturnID:      r.ID,
+		OrderID:       r.OrderID,
+		CustomerID:    r.CustomerID,
+		Items:         newEventItems(r.Items, model.NewReturnEventItem),
+		RefundAmount:  refundAmount,
+	})
+}
+
+func (s *returnService) setStatus(returnID uuid.UUID, status model.ReturnStatus, changedBy, reason string) (*model.Return, error) {
+	r, err := s.returnRepo.Find(returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStatus := r.Status
+	if !oldStatus.CanTransitionTo(status) {
+		return nil, ErrInvalidReturnStatusTransition
+	}
+	refundAmount, err := r.RefundAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now()
+	r.Status = status
+	r.UpdatedAt = currentTime
+	if err = s.returnRepo.Store(r); err != nil {
+		return nil, err
+	}
+
+	err = s.historyRepo.Append(&model.ReturnStatusChange{
+		ReturnID:  returnID,
+		OldStatus: oldStatus,
+		NewStatus: status,
+		ChangedBy: changedBy,
+		Reason:    reason,
+		ChangedAt: currentTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, s.dispatcher.Dispatch(model.OrderReturnStatusChanged{
+		EventMetadata: model.NewEventMetadata(currentTime),
+		ReturnID:      returnID,
+		OrderID:       r.OrderID,
+		OldStatus:     oldStatus,
+		NewStatus:     status,
+		ChangedBy:     changedBy,
+		Reason:        reason,
+		RefundAmount:  refundAmount,
+	})
+}
+
+// deliveredQuantities - сколько единиц каждой позиции заказа входит в доставленные отправления
+func (s *returnService) deliveredQuantities(orderID uuid.UUID) (map[uuid.UUID]int, error) {
+	shipments, err := s.shipmentRepo.FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivered := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		if shipment.Status != model.ShipmentDelivered {
+			continue
+		}
+		for _, item := range shipment.Items {
+			delivered[item.ItemID] += item.Quantity
+		}
+	}
+	return delivered, nil
+}
+
+// returnedQuantities - сколько единиц каждой позиции заказа уже входит в неотклонённые возвраты
+func (s *returnService) returnedQuantities(orderID uuid.UUID) (map[uuid.UUID]int, error) {
+	returns, err := s.returnRepo.FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	returned := make(map[uuid.UUID]int)
+	for _, r := range returns {
+		if !r.IsActive() {
+			continue
+		}
+		for _, item := range r.Items {
+			returned[item.ItemID] += item.Quantity
+		}
+	}
+	return returned, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+var (
+	ErrOrderNotShippable               = errors.New("only paid order can be shipped")
+	ErrShipmentQuantityExceeded        = errors.New("shipment quantity exceeds quantity not yet shipped")
+	ErrInvalidShipmentStatusTransition = errors.New("invalid shipment status transition")
+	ErrTrackingInfoRequired            = errors.New("carrier and tracking number are required")
+)
+
+// RequestedShipmentItem - сколько единиц позиции заказа положить в отправление
+type RequestedShipmentItem = RequestedItem
+
+type Shipment interface {
+	// CreateShipment собирает отправление из позиций оплаченного заказа. Единицы позиции,
+	// которые уже входят в другие отправления, отправить повторно нельзя
+	CreateShipment(orderID uuid.UUID, items []RequestedShipmentItem) (uuid.UUID, error)
+	// ShipShipment отмечает, что отправление передано перевозчику. Отправление отменённого заказа не отправляется
+	ShipShipment(shipmentID uuid.UUID, carrier, trackingNumber string) error
+	DeliverShipment(shipmentID uuid.UUID) error
+}
+
+func NewShipmentService(
+	orderRepo model.OrderRepository,
+	shipmentRepo model.ShipmentRepository,
+	dispatcher EventDispatcher,
+) Shipment {
+	return &shipmentService{
+		orderRepo:    orderRepo,
+		shipmentRepo: shipmentRepo,
+		dispatcher:   dispatcher,
+	}
+}
+
+type shipmentService struct {
+	orderRepo    model.OrderRepository
+	shipmentRepo model.ShipmentRepository
+	dispatcher   EventDispatcher
+}
+
+func (s *shipmentService) CreateShipment(orderID uuid.UUID, items []RequestedShipmentItem) (uuid.UUID, error) {
+	if err := validateRequestedItems(items, model.ErrInvalidShipmentItems); err != nil {
+		return uuid.Nil, err
+	}
+
+	order, err := s.orderRepo.Find(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if order.Status != model.Paid {
+		return uuid.Nil, ErrOrderNotShippable
+	}
+
+	shipped, err := s.shippedQuantities(orderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	shipmentItems := make([]model.ShipmentItem, 0, len(items))
+	for _, requested := range items {
+		itemIndex, found := findItemIndex(order.Items, requested.ItemID)
+		if !found {
+			return uuid.Nil, model.ErrItemNotFound
+		}
+		item := order.Items[itemIndex]
+		if shipped[item.ID]+requested.Quantity > item.Quantity {
+			return uuid.Nil, ErrShipmentQuantityExceeded
+		}
+
+		shipmentItems = append(shipmentItems, model.ShipmentItem{
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Price:     item.Price,
+			Quantity:  requested.Quantity,
+		})
+	}
+
+	shipmentID, err := s.shipmentRepo.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	shipment := &model.Shipment{
+		ID:         shipmentID,
+		OrderID:    orderID,
+		CustomerID: order.CustomerID,
+		Status:     model.ShipmentPacked,
+		Items:      shipmentItems,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+	if err = s.shipmentRepo.Store(shipment); err != nil {
+		return uuid.Nil, err
+	}
+
+	return shipmentID, s.dispatcher.Dispatch(model.OrderShipmentPacked{
+		EventMetadata: model.NewEventMetadata(currentTime),
+		ShipmentID:    shipmentID,
+		OrderID:       orderID,
+		CustomerID:    order.CustomerID,
+		Items:         newEventItems(shipment.Items, model.NewShipmentEventItem),
+	})
+}
+
+func (s *shipmentService) ShipShipment(shipmentID uuid.UUID, carrier, trackingNumber string) error {
+	if carrier == "" || trackingNumber == "" {
+		return ErrTrackingInfoRequired
+	}
+
+	shipment, err := s.findForTransition(shipmentID, model.ShipmentShipped)
+	if err != nil {
+		return err
+	}
+	// Собранное отправление отменённого заказа остаётся на складе: деньги за него уже возвращены
+	order, err := s.orderRepo.Find(shipment.OrderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != model.Paid {
+		return ErrOrderNotShippable
+	}
+
+	currentTime := time.Now()
+	shipment.Status = model.ShipmentShipped
+	shipment.Carrier = carrier
+	shipment.TrackingNumber = trackingNumber
+	shipment.ShippedAt = &currentTime
+	shipment.UpdatedAt = currentTime
+	if err = s.shipmentRepo.Store(shipment); err != nil {
+		return err
+	}
+
+	return s.dispatcher.Dispatch(model.OrderShipmentShipped{
+		EventMetadata:  model.NewEventMetadata(currentTime),
+		ShipmentID:     shipment.ID,
+		OrderID:        shipment.OrderID,
+		CustomerID:     shipment.CustomerID,
+		Items:          newEventItems(shipment.Items, model.NewShipmentEventItem),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+	})
+}
+
+func (s *shipmentService) DeliverShipment(shipmentID uuid.UUID) error {
+	shipment, err := s.findForTransition(shipmentID, model.ShipmentDelivered)
+	if err != nil {
+		return err
+	}
+
+	currentTime := time.Now()
+	shipment.Status = model.ShipmentDelivered
+	shipment.DeliveredAt = &currentTime
+	shipment.UpdatedAt = currentTime
+	if err = s.shipmentRepo.Store(shipment); err != nil {
+		return err
+	}
+
+	return s.dispatcher.Dispatch(model.OrderShipmentDelivered{
+		EventMetadata:  model.NewEventMetadata(currentTime),
+		ShipmentID:     shipment.ID,
+		OrderID:        shipment.OrderID,
+		CustomerID:     shipment.CustomerID,
+		Items:          newEventItems(shipment.Items, model.NewShipmentEventItem),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+	})
+}
+
+func (s *shipmentService) findForTransition(shipmentID uuid.UUID, status model.ShipmentStatus) (*model.Shipment, error) {
+	shipment, err := s.shipmentRepo.Find(shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !shipment.Status.CanTransitionTo(status) {
+		return nil, ErrInvalidShipmentStatusTransition
+	}
+	return shipment, nil
+}
+
+// shippedQuantities - сколько единиц каждой позиции заказа уже входит в отправления
+func (s *shipmentService) shippedQuantities(orderID uuid.UUID) (map[uuid.UUID]int, error) {
+	shipments, err := s.shipmentRepo.FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipped := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			shipped[item.ItemID] += item.Quantity
+		}
+	}
+	return shipped, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"order/pkg/domain/model"
+)
+
+type TaxRule interface {
+	// SetTaxRule создаёт правило или заменяет правило с теми же категорией и страной.
+	// Открытые заказы пересчитываются по новым правилам при следующем изменении или оформлении
+	SetTaxRule(rule model.TaxRule) error
+	DeleteTaxRule(category, country string) error
+}
+
+func NewTaxRuleService(repo model.TaxRuleRepository) TaxRule {
+	return &taxRuleService{
+		repo: repo,
+	}
+}
+
+type taxRuleService struct {
+	repo model.TaxRuleRepository
+}
+
+func (s *taxRuleService) SetTaxRule(rule model.TaxRule) error {
+	rule = rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Store(&rule)
+}
+
+func (s *taxRuleService) DeleteTaxRule(category, country string) error {
+	rule := model.TaxRule{Category: category, Country: country}.Normalize()
+	return s.repo.Delete(rule.Category, rule.Country)
+}
+
+// recalculateTax пересчитывает налог заказа по действующим правилам для страны доставки
+func recalculateTax(order *model.Order, taxRuleRepo model.TaxRuleRepository) error {
+	country := ""
+	if order.Delivery != nil {
+		country = order.Delivery.Address.Country
+	}
+	rules, err := taxRuleRepo.FindByCountry(country)
+	if err != nil {
+		return err
+	}
+
+	order.Tax, err = model.CalculateTax(order, rules)
+	return err
+}
@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+func TestCheckoutService(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	price := model.Money{Amount: 9999, Currency: model.DefaultCurrency}
+	keyboards := model.Item{ID: uuid.Must(uuid.NewV7()), ProductID: uuid.Must(uuid.NewV7()), Name: "Keyboard", Price: price, Quantity: 2}
+	paymentID := uuid.Must(uuid.NewV7())
+
+	t.Run("Start checkout", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+
+		sagaID, err := f.checkoutService.StartCheckout(orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Pending, f.repo.store[orderID].Status)
+		saga := f.sagaRepo.store[sagaID]
+		require.Equal(t, model.CheckoutOrderPending, saga.State)
+		require.Equal(t, customerID, saga.CustomerID)
+		require.Equal(t, price.Multiply(2), saga.Amount)
+	})
+
+	t.Run("Checkout charges discounted total", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		f.promoCodeRepo.store["SALE10"] = &model.PromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}}
+		f.repo.store[orderID].PromoCode = &model.AppliedPromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}}
+
+		sagaID, err := f.checkoutService.StartCheckout(orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 17999, Currency: model.DefaultCurrency}, f.sagaRepo.store[sagaID].Amount)
+	})
+
+	t.Run("Fail to checkout order with expired promo code", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		f.promoCodeRepo.store["SALE10"] = &model.PromoCode{
+			Code:     "SALE10",
+			Discount: model.Discount{Percent: 10},
+			ValidTo:  toPtr(time.Now().Add(-time.Minute)),
+		}
+		f.repo.store[orderID].PromoCode = &model.AppliedPromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}}
+
+		_, err := f.checkoutService.StartCheckout(orderID)
+
+		require.ErrorIs(t, err, service.ErrPromoCodeNotActive)
+		require.Equal(t, model.Open, f.repo.store[orderID].Status)
+		require.Empty(t, f.sagaRepo.store)
+	})
+
+	t.Run("Fail to checkout order over promo code usage limit", func(t *testing.T) {
+		f := setup()
+		firstOrderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		secondOrderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		f.promoCodeRepo.store["ONCE"] = &model.PromoCode{Code: "ONCE", Discount: model.Discount{Percent: 10}, MaxUses: 1}
+		for _, orderID := range []uuid.UUID{firstOrderID, secondOrderID} {
+			f.repo.store[orderID].PromoCode = &model.AppliedPromoCode{Code: "ONCE", Discount: model.Discount{Percent: 10}}
+		}
+		_, err := f.checkoutService.StartCheckout(firstOrderID)
+		require.NoError(t, err)
+
+		_, err = f.checkoutService.StartCheckout(secondOrderID)
+
+		require.ErrorIs(t, err, service.ErrPromoCodeUsageLimitReached)
+		require.Equal(t, model.Open, f.repo.store[secondOrderID].Status)
+		require.Len(t, f.sagaRepo.store, 1)
+	})
+
+	t.Run("Fail to checkout empty order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+
+		_, err := f.checkoutService.StartCheckout(orderID)
+
+		require.ErrorIs(t, err, service.ErrEmptyOrder)
+		require.Equal(t, model.Open, f.repo.store[orderID].Status)
+		require.Empty(t, f.sagaRepo.store)
+	})
+
+	t.Run("Fail to checkout pending order", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		_, _ = f.checkoutService.StartCheckout(orderID)
+
+		_, err := f.checkoutService.StartCheckout(orderID)
+
+		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
+		require.Len(t, f.sagaRepo.store, 1)
+	})
+
+	t.Run("Complete checkout", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		sagaID, _ := f.checkoutService.StartCheckout(orderID)
+
+		require.NoError(t, f.checkoutService.PaymentInitiated(sagaID, paymentID))
+		require.NoError(t, f.checkoutService.PaymentCompleted(sagaID))
+		require.NoError(t, f.checkoutService.CustomerNotified(sagaID))
+
+		require.Equal(t, model.Paid, f.repo.store[orderID].Status)
+		saga := f.sagaRepo.store[sagaID]
+		require.Equal(t, model.CheckoutCompleted, saga.State)
+		require.Equal(t, paymentID, saga.PaymentID)
+	})
+
+	t.Run("Repeated step does not change saga", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		sagaID, _ := f.checkoutService.StartCheckout(orderID)
+		_ = f.checkoutService.PaymentInitiated(sagaID, paymentID)
+
+		err := f.checkoutService.PaymentInitiated(sagaID, uuid.Must(uuid.NewV7()))
+
+		require.ErrorIs(t, err, service.ErrInvalidCheckoutState)
+		require.Equal(t, paymentID, f.sagaRepo.store[sagaID].PaymentID)
+	})
+
+	t.Run("Cancel order when payment declined", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		sagaID, _ := f.checkoutService.StartCheckout(orderID)
+		_ = f.checkoutService.PaymentInitiated(sagaID, paymentID)
+
+		err := f.checkoutService.PaymentDeclined(sagaID, "insufficient funds")
+
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, f.repo.store[orderID].Status)
+		saga := f.sagaRepo.store[sagaID]
+		require.Equal(t, model.CheckoutCancelled, saga.State)
+		require.Equal(t, "insufficient funds", saga.FailureReason)
+	})
+
+	t.Run("Reopen order when checkout aborted", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		sagaID, _ := f.checkoutService.StartCheckout(orderID)
+
+		err := f.checkoutService.AbortCheckout(sagaID, "wallet not found")
+
+		require.NoError(t, err)
+		require.Equal(t, model.Open, f.repo.store[orderID].Status)
+		require.Equal(t, model.CheckoutFailed, f.sagaRepo.store[sagaID].State)
+	})
+
+	t.Run("Refund payment when order cancelled during checkout", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		sagaID, _ := f.checkoutService.StartCheckout(orderID)
+		_ = f.checkoutService.PaymentInitiated(sagaID, paymentID)
+		_ = f.orderService.SetStatus(orderID, model.Cancelled, "test", "")
+
+		err := f.checkoutService.PaymentCompleted(sagaID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, f.repo.store[orderID].Status)
+		require.Equal(t, model.CheckoutRefundingPayment, f.sagaRepo.store[sagaID].State)
+
+		err = f.checkoutService.PaymentRefunded(sagaID)
+
+		require.NoError(t, err)
+		saga := f.sagaRepo.store[sagaID]
+		require.Equal(t, model.CheckoutFailed, saga.State)
+		require.NotEmpty(t, saga.FailureReason)
+	})
+
+	t.Run("Record step failure", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		sagaID, _ := f.checkoutService.StartCheckout(orderID)
+
+		_, _ = f.checkoutService.StepFailed(sagaID, "payment service unavailable")
+		saga, err := f.checkoutService.StepFailed(sagaID, "payment service unavailable")
+
+		require.NoError(t, err)
+		require.Equal(t, 2, saga.Attempts)
+		require.Equal(t, model.CheckoutOrderPending, saga.State)
+
+		_ = f.checkoutService.PaymentInitiated(sagaID, paymentID)
+
+		require.Zero(t, f.sagaRepo.store[sagaID].Attempts)
+	})
+}
+
+var _ model.CheckoutSagaRepository = &mockCheckoutSagaRepository{}
+
+type mockCheckoutSagaRepository struct {
+	store map[uuid.UUID]*model.CheckoutSaga
+}
+
+func (m *mockCheckoutSagaRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockCheckoutSagaRepository) Store(saga *model.CheckoutSaga) error {
+	m.store[saga.ID] = saga
+	return nil
+}
+
+func (m *mockCheckoutSagaRepository) Find(id uuid.UUID) (*model.CheckoutSaga, error) {
+	if saga, ok := m.store[id]; ok {
+		return saga, nil
+	}
+	return nil, model.ErrCheckoutSagaNotFound
+}
+
+func (m *mockCheckoutSagaRepository) FindUnfinished(updatedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, saga := range m.store {
+		if !saga.State.IsFinished() && saga.UpdatedAt.Before(updatedBefore) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
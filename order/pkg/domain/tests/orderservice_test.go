@@ -12,31 +12,101 @@ import (
 )
 
 type testFixture struct {
-	orderService    service.Order
-	repo            *mockOrderRepository
-	eventDispatcher *mockEventDispatcher
+	orderService      service.Order
+	checkoutService   service.Checkout
+	promoCodeService  service.PromoCode
+	returnService     service.Return
+	shipmentService   service.Shipment
+	repo              *mockOrderRepository
+	historyRepo       *mockOrderStatusHistoryRepository
+	taxRuleRepo       *mockTaxRuleRepository
+	sagaRepo          *mockCheckoutSagaRepository
+	promoCodeRepo     *mockPromoCodeRepository
+	returnRepo        *mockReturnRepository
+	returnHistoryRepo *mockReturnStatusHistoryRepository
+	shipmentRepo      *mockShipmentRepository
+	eventDispatcher   *mockEventDispatcher
 }
 
 func setup() testFixture {
 	repo := &mockOrderRepository{store: make(map[uuid.UUID]*model.Order)}
+	historyRepo := &mockOrderStatusHistoryRepository{}
+	taxRuleRepo := &mockTaxRuleRepository{}
+	sagaRepo := &mockCheckoutSagaRepository{store: make(map[uuid.UUID]*model.CheckoutSaga)}
+	promoCodeRepo := newMockPromoCodeRepository(repo)
+	returnRepo := &mockReturnRepository{store: make(map[uuid.UUID]*model.Return)}
+	returnHistoryRepo := &mockReturnStatusHistoryRepository{}
+	shipmentRepo := &mockShipmentRepository{store: make(map[uuid.UUID]*model.Shipment)}
 	eventDispatcher := &mockEventDispatcher{}
-	orderService := service.NewOrderService(repo, eventDispatcher)
+	orderService := service.NewOrderService(repo, historyRepo, taxRuleRepo, shipmentRepo, eventDispatcher)
 
 	return testFixture{
-		orderService:    orderService,
-		repo:            repo,
-		eventDispatcher: eventDispatcher,
+		orderService:      orderService,
+		checkoutService:   service.NewCheckoutService(repo, sagaRepo, promoCodeRepo, orderService),
+		promoCodeService:  service.NewPromoCodeService(repo, promoCodeRepo, taxRuleRepo, eventDispatcher),
+		returnService:     service.NewReturnService(repo, returnRepo, returnHistoryRepo, shipmentRepo, eventDispatcher),
+		shipmentService:   service.NewShipmentService(repo, shipmentRepo, eventDispatcher),
+		repo:              repo,
+		historyRepo:       historyRepo,
+		taxRuleRepo:       taxRuleRepo,
+		sagaRepo:          sagaRepo,
+		promoCodeRepo:     promoCodeRepo,
+		returnRepo:        returnRepo,
+		returnHistoryRepo: returnHistoryRepo,
+		shipmentRepo:      shipmentRepo,
+		eventDispatcher:   eventDispatcher,
 	}
 }
 
+// storeOrder сохраняет заказ покупателя в статусе status с позициями items в обход сервиса заказов:
+// так тесты других сервисов готовят заказ в нужном статусе без переходов и событий заказа
+func (f testFixture) storeOrder(customerID uuid.UUID, status model.OrderStatus, items ...model.Item) *model.Order {
+	currentTime := time.Now()
+	order := &model.Order{
+		ID:         uuid.Must(uuid.NewV7()),
+		CustomerID: customerID,
+		Status:     status,
+		Items:      append([]model.Item(nil), items...),
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+	f.repo.store[order.ID] = order
+	return order
+}
+
+// storeShipment сохраняет отправление заказа в статусе status со всеми единицами его позиций
+func (f testFixture) storeShipment(order *model.Order, status model.ShipmentStatus) *model.Shipment {
+	shipment := &model.Shipment{
+		ID:         uuid.Must(uuid.NewV7()),
+		OrderID:    order.ID,
+		CustomerID: order.CustomerID,
+		Status:     status,
+	}
+	for _, item := range order.Items {
+		shipment.Items = append(shipment.Items, model.ShipmentItem{
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Price:     item.Price,
+			Quantity:  item.Quantity,
+		})
+	}
+	f.shipmentRepo.store[shipment.ID] = shipment
+	return shipment
+}
+
 func TestOrderService(t *testing.T) {
 	customerID := uuid.Must(uuid.NewV7())
 	productID := uuid.Must(uuid.NewV7())
+	actor := "test"
+	price := model.Money{Amount: 9999, Currency: model.DefaultCurrency}
+	product := model.Product{ID: productID, Name: "Keyboard", Price: price}
+	paidItem := model.Item{ID: uuid.Must(uuid.NewV7()), ProductID: productID, Name: product.Name, Price: price, Quantity: 1}
 
 	t.Run("Create order", func(t *testing.T) {
 		f := setup()
 
-		orderID, err := f.orderService.CreateOrder(customerID)
+		orderID, err := f.orderService.CreateOrder(customerID, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, f.repo.store[orderID])
@@ -46,7 +116,7 @@ func TestOrderService(t *testing.T) {
 
 	t.Run("Delete order", func(t *testing.T) {
 		f := setup()
-		orderID, _ := f.orderService.CreateOrder(customerID)
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
 		f.eventDispatcher.events = nil
 
 		err := f.orderService.DeleteOrder(orderID)
@@ -57,42 +127,320 @@ func TestOrderService(t *testing.T) {
 		require.Equal(t, model.OrderDeleted{}.Type(), f.eventDispatcher.events[0].Type())
 	})
 
+	t.Run("Fail to delete deleted order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.DeleteOrder(orderID)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.DeleteOrder(orderID)
+
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Restore order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.DeleteOrder(orderID)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.RestoreOrder(orderID)
+
+		require.NoError(t, err)
+		require.Nil(t, f.repo.store[orderID].DeletedAt)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, model.OrderRestored{}.Type(), f.eventDispatcher.events[0].Type())
+	})
+
+	t.Run("Fail to restore not deleted order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.RestoreOrder(orderID)
+
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
 	t.Run("Set status", func(t *testing.T) {
 		f := setup()
-		orderID, _ := f.orderService.CreateOrder(customerID)
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
 		f.eventDispatcher.events = nil
 
-		err := f.orderService.SetStatus(orderID, model.Paid)
+		err := f.orderService.SetStatus(orderID, model.Pending, actor, "checkout")
 
 		require.NoError(t, err)
-		require.Equal(t, model.Paid, f.repo.store[orderID].Status)
+		require.Equal(t, model.Pending, f.repo.store[orderID].Status)
 		require.Len(t, f.eventDispatcher.events, 1)
 		event := f.eventDispatcher.events[0].(model.OrderStatusChanged)
 		require.Equal(t, model.OrderStatusChanged{}.Type(), event.Type())
 		require.Equal(t, model.Open, event.OldStatus)
-		require.Equal(t, model.Paid, event.NewStatus)
+		require.Equal(t, model.Pending, event.NewStatus)
+		require.Len(t, f.historyRepo.changes, 1)
+		require.Equal(t, f.historyRepo.changes[0].ChangedAt, event.OccurredAt)
+		require.Equal(t, model.Open, f.historyRepo.changes[0].OldStatus)
+		require.Equal(t, model.Pending, f.historyRepo.changes[0].NewStatus)
+		require.Equal(t, actor, f.historyRepo.changes[0].ChangedBy)
+		require.Equal(t, "checkout", f.historyRepo.changes[0].Reason)
+	})
+
+	t.Run("Cancel open order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, product, 2)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.CancelOrder(orderID, actor, "changed my mind")
+
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, f.repo.store[orderID].Status)
+		require.Equal(t, "changed my mind", f.historyRepo.changes[0].Reason)
+		require.Len(t, f.eventDispatcher.events, 2)
+		event := f.eventDispatcher.events[1].(model.OrderCancelled)
+		require.Equal(t, customerID, event.CustomerID)
+		require.Equal(t, price.Multiply(2), event.Total)
+		require.Equal(t, "changed my mind", event.Reason)
+		require.Equal(t, actor, event.CancelledBy)
+		require.False(t, event.RefundRequired)
+	})
+
+	t.Run("Cancel paid order with refund", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, product, 1)
+		_ = f.orderService.SetStatus(orderID, model.Pending, actor, "")
+		_ = f.orderService.SetStatus(orderID, model.Paid, actor, "")
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.CancelOrder(orderID, actor, "out of stock")
+
+		require.NoError(t, err)
+		event := f.eventDispatcher.events[1].(model.OrderCancelled)
+		require.Equal(t, price, event.Total)
+		require.True(t, event.RefundRequired)
+	})
+
+	t.Run("Cancel paid order with packed shipment", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, paidItem)
+		f.storeShipment(order, model.ShipmentPacked)
+
+		err := f.orderService.CancelOrder(order.ID, actor, "out of stock")
+
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, f.repo.store[order.ID].Status)
+	})
+
+	t.Run("Fail to cancel paid order after shipment left warehouse", func(t *testing.T) {
+		for _, status := range []model.ShipmentStatus{model.ShipmentShipped, model.ShipmentDelivered} {
+			f := setup()
+			order := f.storeOrder(customerID, model.Paid, paidItem)
+			f.storeShipment(order, status)
+
+			err := f.orderService.CancelOrder(order.ID, actor, "changed my mind")
+
+			require.ErrorIs(t, err, service.ErrOrderAlreadyShipped)
+			require.Equal(t, model.Paid, f.repo.store[order.ID].Status)
+			require.Empty(t, f.eventDispatcher.events)
+		}
+	})
+
+	t.Run("Cancel cancelled order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.CancelOrder(orderID, actor, "changed my mind")
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.CancelOrder(orderID, actor, "changed my mind")
+
+		require.NoError(t, err)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to cancel order without reason", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+
+		err := f.orderService.CancelOrder(orderID, actor, "")
+
+		require.ErrorIs(t, err, service.ErrCancelReasonRequired)
+		require.Equal(t, model.Open, f.repo.store[orderID].Status)
+	})
+
+	t.Run("Expire pending order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.SetStatus(orderID, model.Pending, actor, "")
+		f.historyRepo.changes = nil
+
+		expired, err := f.orderService.ExpireOrder(orderID, time.Now().Add(time.Second))
+
+		require.NoError(t, err)
+		require.True(t, expired)
+		require.Equal(t, model.Cancelled, f.repo.store[orderID].Status)
+		require.Equal(t, "expiry", f.historyRepo.changes[0].ChangedBy)
+		require.Equal(t, "expired", f.historyRepo.changes[0].Reason)
+	})
+
+	t.Run("Skip expiry of paid order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.SetStatus(orderID, model.Pending, actor, "")
+		_ = f.orderService.SetStatus(orderID, model.Paid, actor, "")
+		f.eventDispatcher.events = nil
+
+		expired, err := f.orderService.ExpireOrder(orderID, time.Now().Add(time.Second))
+
+		require.NoError(t, err)
+		require.False(t, expired)
+		require.Equal(t, model.Paid, f.repo.store[orderID].Status)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Skip expiry of order checked out again after deadline", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.SetStatus(orderID, model.Pending, actor, "")
+		pendingBefore := time.Now().Add(time.Millisecond)
+		time.Sleep(2 * time.Millisecond)
+		_ = f.orderService.SetStatus(orderID, model.Open, actor, "")
+		_ = f.orderService.SetStatus(orderID, model.Pending, actor, "")
+		f.eventDispatcher.events = nil
+
+		expired, err := f.orderService.ExpireOrder(orderID, pendingBefore)
+
+		require.NoError(t, err)
+		require.False(t, expired)
+		require.Equal(t, model.Pending, f.repo.store[orderID].Status)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to skip pending status", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.SetStatus(orderID, model.Paid, actor, "")
+
+		require.ErrorIs(t, err, service.ErrInvalidStatusTransition)
+		require.Equal(t, model.Open, f.repo.store[orderID].Status)
+		require.Empty(t, f.historyRepo.changes)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to reopen cancelled order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.SetStatus(orderID, model.Cancelled, actor, "")
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.SetStatus(orderID, model.Open, actor, "")
+
+		require.ErrorIs(t, err, service.ErrInvalidStatusTransition)
+		require.Equal(t, model.Cancelled, f.repo.store[orderID].Status)
+		require.Empty(t, f.eventDispatcher.events)
 	})
 
 	t.Run("Add item to order", func(t *testing.T) {
 		f := setup()
-		orderID, _ := f.orderService.CreateOrder(customerID)
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
 		f.eventDispatcher.events = nil
 
-		itemID, err := f.orderService.AddItem(orderID, productID, 99.99)
+		itemID, err := f.orderService.AddItem(orderID, product, 1)
 
 		require.NoError(t, err)
 		require.Len(t, f.repo.store[orderID].Items, 1)
 		require.Equal(t, itemID, f.repo.store[orderID].Items[0].ID)
+		require.Equal(t, product.Name, f.repo.store[orderID].Items[0].Name)
+		require.Equal(t, price, f.repo.store[orderID].Items[0].Price)
 		require.Len(t, f.eventDispatcher.events, 1)
 		event := f.eventDispatcher.events[0].(model.OrderItemChanged)
 		require.Equal(t, model.OrderItemChanged{}.Type(), event.Type())
 		require.Equal(t, []uuid.UUID{itemID}, event.AddedItems)
+		require.Equal(t, model.OrderEventSchemaVersion, event.SchemaVersion)
+		require.False(t, event.OccurredAt.IsZero())
+		require.Equal(t, []model.EventItem{{ItemID: itemID, ProductID: productID, Name: product.Name, Price: price, Quantity: 1}}, event.Items)
+		require.Equal(t, price, event.Total)
+	})
+
+	t.Run("Merge item with the same product", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		itemID, _ := f.orderService.AddItem(orderID, product, 1)
+		f.eventDispatcher.events = nil
+
+		newPrice := model.Money{Amount: 8999, Currency: model.DefaultCurrency}
+		repriced := model.Product{ID: productID, Name: "Keyboard Pro", Price: newPrice}
+		mergedItemID, err := f.orderService.AddItem(orderID, repriced, 2)
+
+		require.NoError(t, err)
+		require.Equal(t, itemID, mergedItemID)
+		require.Len(t, f.repo.store[orderID].Items, 1)
+		require.Equal(t, 3, f.repo.store[orderID].Items[0].Quantity)
+		require.Equal(t, newPrice, f.repo.store[orderID].Items[0].Price)
+		require.Equal(t, "Keyboard Pro", f.repo.store[orderID].Items[0].Name)
+		require.Equal(t, model.Money{Amount: 26997, Currency: model.DefaultCurrency}, f.repo.store[orderID].Items[0].Total())
+		require.Len(t, f.eventDispatcher.events, 1)
+		event := f.eventDispatcher.events[0].(model.OrderItemChanged)
+		require.Equal(t, []uuid.UUID{itemID}, event.ChangedItems)
+		require.Empty(t, event.AddedItems)
+	})
+
+	t.Run("Update item quantity", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		itemID, _ := f.orderService.AddItem(orderID, product, 1)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.UpdateItemQuantity(orderID, itemID, 5)
+
+		require.NoError(t, err)
+		require.Equal(t, 5, f.repo.store[orderID].Items[0].Quantity)
+		require.Len(t, f.eventDispatcher.events, 1)
+		event := f.eventDispatcher.events[0].(model.OrderItemChanged)
+		require.Equal(t, []uuid.UUID{itemID}, event.ChangedItems)
+		require.Len(t, event.Items, 1)
+		require.Equal(t, 5, event.Items[0].Quantity)
+		require.Equal(t, price.Multiply(5), event.Total)
+	})
+
+	t.Run("Fail to set non-positive quantity", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		itemID, _ := f.orderService.AddItem(orderID, product, 1)
+		f.eventDispatcher.events = nil
+
+		_, addErr := f.orderService.AddItem(orderID, product, 0)
+		updateErr := f.orderService.UpdateItemQuantity(orderID, itemID, -1)
+
+		require.ErrorIs(t, addErr, service.ErrInvalidQuantity)
+		require.ErrorIs(t, updateErr, service.ErrInvalidQuantity)
+		require.Equal(t, 1, f.repo.store[orderID].Items[0].Quantity)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to add item in another currency", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, product, 1)
+		f.eventDispatcher.events = nil
+
+		_, err := f.orderService.AddItem(orderID, model.Product{
+			ID:    uuid.Must(uuid.NewV7()),
+			Price: model.Money{Amount: 100, Currency: "USD"},
+		}, 1)
+
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+		require.Len(t, f.repo.store[orderID].Items, 1)
+		require.Empty(t, f.eventDispatcher.events)
 	})
 
 	t.Run("Delete item from order", func(t *testing.T) {
 		f := setup()
-		orderID, _ := f.orderService.CreateOrder(customerID)
-		itemID, _ := f.orderService.AddItem(orderID, productID, 99.99)
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		itemID, _ := f.orderService.AddItem(orderID, product, 1)
 		f.eventDispatcher.events = nil
 
 		err := f.orderService.DeleteItem(orderID, itemID)
@@ -103,21 +451,277 @@ func TestOrderService(t *testing.T) {
 		event := f.eventDispatcher.events[0].(model.OrderItemChanged)
 		require.Equal(t, model.OrderItemChanged{}.Type(), event.Type())
 		require.Equal(t, []uuid.UUID{itemID}, event.RemovedItems)
+		require.Equal(t, []model.EventItem{{ItemID: itemID, ProductID: productID, Name: product.Name, Price: price, Quantity: 1}}, event.Items)
+		require.Zero(t, event.Total.Amount)
 	})
 
 	t.Run("Fail to add item to non-open order", func(t *testing.T) {
 		f := setup()
-		orderID, _ := f.orderService.CreateOrder(customerID)
-		_ = f.orderService.SetStatus(orderID, model.Paid)
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.SetStatus(orderID, model.Pending, actor, "")
+		f.eventDispatcher.events = nil
+
+		_, err := f.orderService.AddItem(orderID, product, 1)
+
+		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+}
+
+func TestOrderDelivery(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	price := model.Money{Amount: 9999, Currency: model.DefaultCurrency}
+	product := model.Product{ID: uuid.Must(uuid.NewV7()), Name: "Keyboard", Price: price}
+	newDelivery := func() model.Delivery {
+		return model.Delivery{
+			Address: model.DeliveryAddress{
+				Recipient:  "Иван Иванов",
+				Phone:      "+7 (900) 123-45-67",
+				Country:    "ru",
+				City:       "Москва",
+				Street:     "Тверская",
+				Building:   "1",
+				PostalCode: "125009",
+			},
+			Method: model.ShippingExpress,
+		}
+	}
+
+	t.Run("Create order with delivery", func(t *testing.T) {
+		f := setup()
+		delivery := newDelivery()
+
+		orderID, err := f.orderService.CreateOrder(customerID, &delivery)
+
+		require.NoError(t, err)
+		stored := f.repo.store[orderID].Delivery
+		require.NotNil(t, stored)
+		require.Equal(t, "+79001234567", stored.Address.Phone)
+		require.Equal(t, "RU", stored.Address.Country)
+		created := f.eventDispatcher.events[0].(model.OrderCreated)
+		require.Equal(t, stored, created.Delivery)
+	})
+
+	t.Run("Set delivery", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, product, 2)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.SetDelivery(orderID, newDelivery())
+
+		require.NoError(t, err)
+		order := f.repo.store[orderID]
+		require.Equal(t, model.ShippingExpress, order.Delivery.Method)
+		total, err := order.Total()
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 2*9999 + 50000, Currency: model.DefaultCurrency}, total)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, model.OrderDeliveryChanged{}.Type(), f.eventDispatcher.events[0].Type())
+	})
+
+	t.Run("Status change event carries delivery", func(t *testing.T) {
+		f := setup()
+		delivery := newDelivery()
+		orderID, _ := f.orderService.CreateOrder(customerID, &delivery)
+		f.eventDispatcher.events = nil
+
+		err := f.orderService.SetStatus(orderID, model.Pending, "test", "")
+
+		require.NoError(t, err)
+		changed := f.eventDispatcher.events[0].(model.OrderStatusChanged)
+		require.Equal(t, f.repo.store[orderID].Delivery, changed.Delivery)
+	})
+
+	t.Run("Fail to set invalid delivery", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		f.eventDispatcher.events = nil
+
+		missingCity := newDelivery()
+		missingCity.Address.City = "  "
+		require.ErrorIs(t, f.orderService.SetDelivery(orderID, missingCity), model.ErrInvalidDeliveryAddress)
+
+		invalidCountry := newDelivery()
+		invalidCountry.Address.Country = "RUS"
+		require.ErrorIs(t, f.orderService.SetDelivery(orderID, invalidCountry), model.ErrInvalidDeliveryAddress)
+
+		invalidMethod := newDelivery()
+		invalidMethod.Method = 0
+		require.ErrorIs(t, f.orderService.SetDelivery(orderID, invalidMethod), model.ErrInvalidShippingMethod)
+
+		require.Nil(t, f.repo.store[orderID].Delivery)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Delivery cost is calculated by shipping method", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		delivery := newDelivery()
+		delivery.Method = model.ShippingPickup
+		delivery.Cost = model.Money{Amount: -100, Currency: "USD"}
+
+		err := f.orderService.SetDelivery(orderID, delivery)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 0, Currency: model.DefaultCurrency}, f.repo.store[orderID].Delivery.Cost)
+	})
+
+	t.Run("Fail to set delivery in other currency than items", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		usdProduct := product
+		usdProduct.Price.Currency = "USD"
+		_, _ = f.orderService.AddItem(orderID, usdProduct, 1)
+
+		err := f.orderService.SetDelivery(orderID, newDelivery())
+
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+	})
+
+	t.Run("Fail to add item in other currency than delivery", func(t *testing.T) {
+		f := setup()
+		delivery := newDelivery()
+		orderID, _ := f.orderService.CreateOrder(customerID, &delivery)
+		usdProduct := product
+		usdProduct.Price.Currency = "USD"
+
+		_, err := f.orderService.AddItem(orderID, usdProduct, 1)
+
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+	})
+
+	t.Run("Fail to set delivery of non-open order", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_ = f.orderService.SetStatus(orderID, model.Pending, "test", "")
 		f.eventDispatcher.events = nil
 
-		_, err := f.orderService.AddItem(orderID, productID, 99.99)
+		err := f.orderService.SetDelivery(orderID, newDelivery())
 
 		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
+		require.Nil(t, f.repo.store[orderID].Delivery)
 		require.Empty(t, f.eventDispatcher.events)
 	})
 }
 
+func TestReorderFrom(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	keyboard := model.Product{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  "Keyboard",
+		Price: model.Money{Amount: 9999, Currency: model.DefaultCurrency},
+	}
+	mouse := model.Product{
+		ID:    uuid.Must(uuid.NewV7()),
+		Name:  "Mouse",
+		Price: model.Money{Amount: 2999, Currency: model.DefaultCurrency},
+	}
+	setupSource := func(f testFixture) uuid.UUID {
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, keyboard, 2)
+		_, _ = f.orderService.AddItem(orderID, mouse, 1)
+		_ = f.orderService.SetStatus(orderID, model.Pending, "test", "")
+		_ = f.orderService.SetStatus(orderID, model.Paid, "test", "")
+		f.eventDispatcher.events = nil
+		return orderID
+	}
+
+	t.Run("Reorder at current prices", func(t *testing.T) {
+		f := setup()
+		sourceID := setupSource(f)
+		newKeyboard := keyboard
+		newKeyboard.Price.Amount = 10999
+
+		result, err := f.orderService.ReorderFrom(sourceID, map[uuid.UUID]model.Product{
+			keyboard.ID: newKeyboard,
+			mouse.ID:    mouse,
+		})
+
+		require.NoError(t, err)
+		require.NotEqual(t, sourceID, result.OrderID)
+		require.Empty(t, result.Dropped)
+		require.Equal(t, []service.RepricedItem{{
+			ProductID: keyboard.ID,
+			Name:      keyboard.Name,
+			OldPrice:  keyboard.Price,
+			NewPrice:  newKeyboard.Price,
+		}}, result.Repriced)
+
+		order := f.repo.store[result.OrderID]
+		require.Equal(t, customerID, order.CustomerID)
+		require.Equal(t, model.Open, order.Status)
+		require.Len(t, order.Items, 2)
+		require.Equal(t, newKeyboard.Price, order.Items[0].Price)
+		require.Equal(t, 2, order.Items[0].Quantity)
+		require.Equal(t, mouse.Price, order.Items[1].Price)
+		require.Equal(t, model.Paid, f.repo.store[sourceID].Status)
+
+		require.Len(t, f.eventDispatcher.events, 2)
+		require.Equal(t, model.OrderCreated{}.Type(), f.eventDispatcher.events[0].Type())
+		changed := f.eventDispatcher.events[1].(model.OrderItemChanged)
+		require.Equal(t, []uuid.UUID{order.Items[0].ID, order.Items[1].ID}, changed.AddedItems)
+	})
+
+	t.Run("Skip unavailable products", func(t *testing.T) {
+		f := setup()
+		sourceID := setupSource(f)
+
+		result, err := f.orderService.ReorderFrom(sourceID, map[uuid.UUID]model.Product{mouse.ID: mouse})
+
+		require.NoError(t, err)
+		require.Len(t, result.Dropped, 1)
+		require.Equal(t, keyboard.ID, result.Dropped[0].ProductID)
+		require.Empty(t, result.Repriced)
+		items := f.repo.store[result.OrderID].Items
+		require.Len(t, items, 1)
+		require.Equal(t, mouse.ID, items[0].ProductID)
+	})
+
+	t.Run("Fail when no product is available", func(t *testing.T) {
+		f := setup()
+		sourceID := setupSource(f)
+
+		_, err := f.orderService.ReorderFrom(sourceID, nil)
+
+		require.ErrorIs(t, err, service.ErrNothingToReorder)
+		require.Len(t, f.repo.store, 1)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to reorder empty order", func(t *testing.T) {
+		f := setup()
+		sourceID, _ := f.orderService.CreateOrder(customerID, nil)
+
+		_, err := f.orderService.ReorderFrom(sourceID, map[uuid.UUID]model.Product{keyboard.ID: keyboard})
+
+		require.ErrorIs(t, err, service.ErrEmptyOrder)
+	})
+
+	t.Run("Fail when prices are in different currencies", func(t *testing.T) {
+		f := setup()
+		sourceID := setupSource(f)
+		usdMouse := mouse
+		usdMouse.Price.Currency = "USD"
+
+		_, err := f.orderService.ReorderFrom(sourceID, map[uuid.UUID]model.Product{
+			keyboard.ID: keyboard,
+			mouse.ID:    usdMouse,
+		})
+
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+		require.Len(t, f.repo.store, 1)
+	})
+
+	t.Run("Fail to reorder unknown order", func(t *testing.T) {
+		f := setup()
+
+		_, err := f.orderService.ReorderFrom(uuid.Must(uuid.NewV7()), nil)
+
+		require.ErrorIs(t, err, model.ErrOrderNotFound)
+	})
+}
+
 var _ model.OrderRepository = &mockOrderRepository{}
 
 type mockOrderRepository struct {
@@ -148,6 +752,35 @@ func (m *mockOrderRepository) Delete(id uuid.UUID) error {
 	return model.ErrOrderNotFound
 }
 
+func (m *mockOrderRepository) Restore(id uuid.UUID) error {
+	if order, ok := m.store[id]; ok && order.DeletedAt != nil {
+		order.DeletedAt = nil
+		return nil
+	}
+	return model.ErrOrderNotFound
+}
+
+func (m *mockOrderRepository) FindPendingBefore(pendingBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, order := range m.store {
+		if order.Status == model.Pending && order.DeletedAt == nil && order.PendingAt != nil && order.PendingAt.Before(pendingBefore) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+var _ model.OrderStatusHistoryRepository = &mockOrderStatusHistoryRepository{}
+
+type mockOrderStatusHistoryRepository struct {
+	changes []*model.StatusChange
+}
+
+func (m *mockOrderStatusHistoryRepository) Append(change *model.StatusChange) error {
+	m.changes = append(m.changes, change)
+	return nil
+}
+
 var _ service.EventDispatcher = &mockEventDispatcher{}
 
 type mockEventDispatcher struct {
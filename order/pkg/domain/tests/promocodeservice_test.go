@@ -0,0 +1,277 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+func TestPromoCodeService(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	price := model.Money{Amount: 9999, Currency: model.DefaultCurrency}
+	product := model.Product{ID: uuid.Must(uuid.NewV7()), Name: "Keyboard", Price: price}
+	keyboards := model.Item{ID: uuid.Must(uuid.NewV7()), ProductID: product.ID, Name: product.Name, Price: price, Quantity: 2}
+	fixedAmount := model.Money{Amount: 5000, Currency: model.DefaultCurrency}
+
+	t.Run("Create promo code", func(t *testing.T) {
+		f := setup()
+
+		err := f.promoCodeService.CreatePromoCode(model.PromoCode{
+			Code:     " sale10 ",
+			Discount: model.Discount{Percent: 10},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, f.promoCodeRepo.store["SALE10"])
+	})
+
+	t.Run("Fail to create invalid promo code", func(t *testing.T) {
+		f := setup()
+
+		err := f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "X", Discount: model.Discount{Percent: 10}})
+		require.ErrorIs(t, err, model.ErrInvalidPromoCode)
+
+		err = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "SALE", Discount: model.Discount{Percent: 101}})
+		require.ErrorIs(t, err, model.ErrInvalidDiscount)
+
+		err = f.promoCodeService.CreatePromoCode(model.PromoCode{
+			Code:     "SALE",
+			Discount: model.Discount{Percent: 10, Amount: &fixedAmount},
+		})
+		require.ErrorIs(t, err, model.ErrInvalidDiscount)
+
+		err = f.promoCodeService.CreatePromoCode(model.PromoCode{
+			Code:      "SALE",
+			Discount:  model.Discount{Percent: 10},
+			ValidFrom: toPtr(time.Now()),
+			ValidTo:   toPtr(time.Now().Add(-time.Hour)),
+		})
+		require.ErrorIs(t, err, model.ErrInvalidPromoCode)
+		require.Empty(t, f.promoCodeRepo.store)
+	})
+
+	t.Run("Fail to create existing promo code", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "SALE", Discount: model.Discount{Percent: 10}})
+
+		err := f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "sale", Discount: model.Discount{Percent: 20}})
+
+		require.ErrorIs(t, err, model.ErrPromoCodeExists)
+		require.Equal(t, 10, f.promoCodeRepo.store["SALE"].Discount.Percent)
+	})
+
+	t.Run("Apply percent promo code", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}})
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+
+		err := f.promoCodeService.ApplyPromoCode(orderID, "sale10")
+
+		require.NoError(t, err)
+		order := f.repo.store[orderID]
+		discount, err := order.Discount()
+		require.NoError(t, err)
+		// 10% от 199.98 округляется вниз до 19.99
+		require.Equal(t, model.Money{Amount: 1999, Currency: model.DefaultCurrency}, discount)
+		total, err := order.Total()
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 17999, Currency: model.DefaultCurrency}, total)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, model.OrderPromoCodeApplied{}.Type(), f.eventDispatcher.events[0].Type())
+	})
+
+	t.Run("Apply fixed promo code", func(t *testing.T) {
+		f := setup()
+		bigAmount := model.Money{Amount: 50000, Currency: model.DefaultCurrency}
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "MINUS50", Discount: model.Discount{Amount: &fixedAmount}})
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "MINUS500", Discount: model.Discount{Amount: &bigAmount}})
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(orderID, "MINUS50"))
+		total, err := f.repo.store[orderID].Total()
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 14998, Currency: model.DefaultCurrency}, total)
+
+		// Скидка больше суммы заказа не делает итог отрицательным
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(orderID, "MINUS500"))
+		total, err = f.repo.store[orderID].Total()
+		require.NoError(t, err)
+		require.True(t, total.IsZero())
+	})
+
+	t.Run("Discount is recalculated when items change", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}})
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		_ = f.promoCodeService.ApplyPromoCode(orderID, "SALE10")
+
+		_, err := f.orderService.AddItem(orderID, product, 8)
+
+		require.NoError(t, err)
+		discount, err := f.repo.store[orderID].Discount()
+		require.NoError(t, err)
+		require.Equal(t, model.Money{Amount: 9999, Currency: model.DefaultCurrency}, discount)
+	})
+
+	t.Run("Fail to apply unknown promo code", func(t *testing.T) {
+		f := setup()
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+
+		err := f.promoCodeService.ApplyPromoCode(orderID, "UNKNOWN")
+
+		require.ErrorIs(t, err, model.ErrPromoCodeNotFound)
+		require.Nil(t, f.repo.store[orderID].PromoCode)
+	})
+
+	t.Run("Fail to apply promo code outside validity window", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{
+			Code:      "FUTURE",
+			Discount:  model.Discount{Percent: 10},
+			ValidFrom: toPtr(time.Now().Add(time.Hour)),
+		})
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{
+			Code:     "EXPIRED",
+			Discount: model.Discount{Percent: 10},
+			ValidTo:  toPtr(time.Now().Add(-time.Hour)),
+		})
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+
+		require.ErrorIs(t, f.promoCodeService.ApplyPromoCode(orderID, "FUTURE"), service.ErrPromoCodeNotActive)
+		require.ErrorIs(t, f.promoCodeService.ApplyPromoCode(orderID, "EXPIRED"), service.ErrPromoCodeNotActive)
+		require.Nil(t, f.repo.store[orderID].PromoCode)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to apply promo code over usage limit", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "ONCE", Discount: model.Discount{Percent: 10}, MaxUses: 1})
+		firstOrderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		secondOrderID := f.storeOrder(uuid.Must(uuid.NewV7()), model.Open, keyboards).ID
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(firstOrderID, "ONCE"))
+		require.NoError(t, f.orderService.SetStatus(firstOrderID, model.Pending, "test", ""))
+
+		err := f.promoCodeService.ApplyPromoCode(secondOrderID, "ONCE")
+		require.ErrorIs(t, err, service.ErrPromoCodeUsageLimitReached)
+
+		// Отмена заказа освобождает использование
+		require.NoError(t, f.orderService.CancelOrder(firstOrderID, "test", "changed mind"))
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(secondOrderID, "ONCE"))
+	})
+
+	t.Run("Open orders do not consume promo code uses", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "ONCE", Discount: model.Discount{Percent: 10}, MaxUses: 1})
+		firstOrderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		secondOrderID := f.storeOrder(uuid.Must(uuid.NewV7()), model.Open, keyboards).ID
+
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(firstOrderID, "ONCE"))
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(secondOrderID, "ONCE"))
+	})
+
+	t.Run("Fail to apply promo code over per-customer limit", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{
+			Code:               "WELCOME",
+			Discount:           model.Discount{Percent: 10},
+			MaxUsesPerCustomer: 1,
+		})
+		firstOrderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		secondOrderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		otherOrderID := f.storeOrder(uuid.Must(uuid.NewV7()), model.Open, keyboards).ID
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(firstOrderID, "WELCOME"))
+		require.NoError(t, f.orderService.SetStatus(firstOrderID, model.Pending, "test", ""))
+
+		require.ErrorIs(t, f.promoCodeService.ApplyPromoCode(secondOrderID, "WELCOME"), service.ErrPromoCodeUsageLimitReached)
+		require.NoError(t, f.promoCodeService.ApplyPromoCode(otherOrderID, "WELCOME"))
+	})
+
+	t.Run("Fail to apply promo code to non-open order", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}})
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		_ = f.orderService.SetStatus(orderID, model.Pending, "test", "")
+		f.eventDispatcher.events = nil
+
+		err := f.promoCodeService.ApplyPromoCode(orderID, "SALE10")
+
+		require.ErrorIs(t, err, service.ErrInvalidOrderStatus)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Remove promo code", func(t *testing.T) {
+		f := setup()
+		_ = f.promoCodeService.CreatePromoCode(model.PromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}})
+		orderID := f.storeOrder(customerID, model.Open, keyboards).ID
+		_ = f.promoCodeService.ApplyPromoCode(orderID, "SALE10")
+		f.eventDispatcher.events = nil
+
+		err := f.promoCodeService.RemovePromoCode(orderID)
+
+		require.NoError(t, err)
+		require.Nil(t, f.repo.store[orderID].PromoCode)
+		total, err := f.repo.store[orderID].Total()
+		require.NoError(t, err)
+		require.Equal(t, price.Multiply(2), total)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, model.OrderPromoCodeRemoved{}.Type(), f.eventDispatcher.events[0].Type())
+	})
+}
+
+var _ model.PromoCodeRepository = &mockPromoCodeRepository{}
+
+// mockPromoCodeRepository считает использования по заказам из mockOrderRepository, как и MySQL-реализация
+type mockPromoCodeRepository struct {
+	store     map[string]*model.PromoCode
+	orderRepo *mockOrderRepository
+}
+
+func newMockPromoCodeRepository(orderRepo *mockOrderRepository) *mockPromoCodeRepository {
+	return &mockPromoCodeRepository{
+		store:     make(map[string]*model.PromoCode),
+		orderRepo: orderRepo,
+	}
+}
+
+func (m *mockPromoCodeRepository) Store(promoCode *model.PromoCode) error {
+	promoCode.Version++
+	m.store[promoCode.Code] = promoCode
+	return nil
+}
+
+func (m *mockPromoCodeRepository) Find(code string) (*model.PromoCode, error) {
+	if promoCode, ok := m.store[code]; ok {
+		return promoCode, nil
+	}
+	return nil, model.ErrPromoCodeNotFound
+}
+
+func (m *mockPromoCodeRepository) CountUses(code string) (int, error) {
+	return m.countUses(code, nil), nil
+}
+
+func (m *mockPromoCodeRepository) CountCustomerUses(code string, customerID uuid.UUID) (int, error) {
+	return m.countUses(code, &customerID), nil
+}
+
+func (m *mockPromoCodeRepository) countUses(code string, customerID *uuid.UUID) int {
+	uses := 0
+	for _, order := range m.orderRepo.store {
+		if order.PromoCode == nil || order.PromoCode.Code != code {
+			continue
+		}
+		if (order.Status != model.Pending && order.Status != model.Paid) || order.DeletedAt != nil {
+			continue
+		}
+		if customerID != nil && order.CustomerID != *customerID {
+			continue
+		}
+		uses++
+	}
+	return uses
+}
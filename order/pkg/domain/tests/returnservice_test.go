@@ -0,0 +1,234 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+func TestReturnService(t *testing.T) {
+	rub := func(amount int64) model.Money {
+		return model.Money{Amount: amount, Currency: model.DefaultCurrency}
+	}
+	customerID := uuid.Must(uuid.NewV7())
+	itemID := uuid.Must(uuid.NewV7())
+	keyboards := model.Item{ID: itemID, ProductID: uuid.Must(uuid.NewV7()), Name: "Keyboard", Price: rub(1000), Quantity: 3}
+
+	// storeDeliveredOrder сохраняет доставленный покупателю заказ из трёх единиц по 1000 со скидкой 100:
+	// за позицию заплачено 2900
+	storeDeliveredOrder := func(f testFixture, status model.OrderStatus) *model.Order {
+		order := f.storeOrder(customerID, status, keyboards)
+		order.PromoCode = &model.AppliedPromoCode{Code: "SALE", Discount: model.Discount{Amount: toPtr(rub(100))}}
+		f.storeShipment(order, model.ShipmentDelivered)
+		return order
+	}
+	requestOne := []service.RequestedReturnItem{{ItemID: itemID, Quantity: 1}}
+
+	t.Run("Request return of paid order", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+
+		returnID, err := f.returnService.RequestReturn(order.ID, requestOne, "broken", "customer")
+
+		require.NoError(t, err)
+		r := f.returnRepo.store[returnID]
+		require.NotNil(t, r)
+		require.Equal(t, model.ReturnRequested, r.Status)
+		require.Equal(t, order.CustomerID, r.CustomerID)
+		require.Len(t, r.Items, 1)
+		require.Equal(t, 1, r.Items[0].Quantity)
+		require.Equal(t, "Keyboard", r.Items[0].Name)
+
+		require.Len(t, f.returnHistoryRepo.changes, 1)
+		require.Equal(t, model.ReturnRequested, f.returnHistoryRepo.changes[0].NewStatus)
+		require.Equal(t, "customer", f.returnHistoryRepo.changes[0].ChangedBy)
+
+		require.Len(t, f.eventDispatcher.events, 1)
+		event, ok := f.eventDispatcher.events[0].(model.OrderReturnRequested)
+		require.True(t, ok)
+		require.Equal(t, returnID, event.ReturnID)
+		require.Equal(t, r.Items[0].Refund, event.RefundAmount)
+	})
+
+	t.Run("Fail to return not paid order", func(t *testing.T) {
+		f := setup()
+		for _, status := range []model.OrderStatus{model.Open, model.Pending, model.Cancelled} {
+			order := storeDeliveredOrder(f, status)
+
+			_, err := f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+
+			require.ErrorIs(t, err, service.ErrOrderNotReturnable)
+		}
+		require.Empty(t, f.returnRepo.store)
+	})
+
+	t.Run("Fail to request invalid items", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+
+		for _, items := range [][]service.RequestedReturnItem{
+			nil,
+			{{ItemID: itemID, Quantity: 0}},
+			{{ItemID: itemID, Quantity: 1}, {ItemID: itemID, Quantity: 1}},
+		} {
+			_, err := f.returnService.RequestReturn(order.ID, items, "", "customer")
+			require.ErrorIs(t, err, model.ErrInvalidReturnItems)
+		}
+
+		_, err := f.returnService.RequestReturn(order.ID, []service.RequestedReturnItem{
+			{ItemID: uuid.Must(uuid.NewV7()), Quantity: 1},
+		}, "", "customer")
+		require.ErrorIs(t, err, model.ErrItemNotFound)
+	})
+
+	t.Run("Fail to return items not delivered yet", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, keyboards)
+
+		_, err := f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+		require.ErrorIs(t, err, service.ErrReturnQuantityExceeded)
+
+		shipment := f.storeShipment(order, model.ShipmentShipped)
+		_, err = f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+		require.ErrorIs(t, err, service.ErrReturnQuantityExceeded)
+		require.Empty(t, f.returnRepo.store)
+
+		shipment.Status = model.ShipmentDelivered
+		_, err = f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail to return more than was bought", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+
+		_, err := f.returnService.RequestReturn(order.ID, []service.RequestedReturnItem{
+			{ItemID: itemID, Quantity: 2},
+		}, "", "customer")
+		require.NoError(t, err)
+
+		_, err = f.returnService.RequestReturn(order.ID, []service.RequestedReturnItem{
+			{ItemID: itemID, Quantity: 2},
+		}, "", "customer")
+		require.ErrorIs(t, err, service.ErrReturnQuantityExceeded)
+	})
+
+	t.Run("Partial refunds sum up to paid amount", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+
+		var refunded int64
+		for i := 0; i < 3; i++ {
+			returnID, err := f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+			require.NoError(t, err)
+			refund := f.returnRepo.store[returnID].Items[0].Refund
+			require.Positive(t, refund.Amount)
+			refunded += refund.Amount
+		}
+
+		require.Equal(t, int64(2900), refunded)
+	})
+
+	t.Run("Approve and receive return", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+		returnID, err := f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+		require.NoError(t, err)
+		f.eventDispatcher.events = nil
+
+		require.NoError(t, f.returnService.ApproveReturn(returnID, "manager", ""))
+		require.NoError(t, f.returnService.ReceiveReturn(returnID, "warehouse", ""))
+
+		r := f.returnRepo.store[returnID]
+		require.Equal(t, model.ReturnReceived, r.Status)
+		require.Len(t, f.returnHistoryRepo.changes, 3)
+		require.Equal(t, model.ReturnApproved, f.returnHistoryRepo.changes[2].OldStatus)
+		require.Equal(t, "warehouse", f.returnHistoryRepo.changes[2].ChangedBy)
+
+		require.Len(t, f.eventDispatcher.events, 3)
+		refundEvent, ok := f.eventDispatcher.events[2].(model.OrderReturnRefundRequested)
+		require.True(t, ok)
+		require.Equal(t, order.CustomerID, refundEvent.CustomerID)
+		require.Equal(t, r.Items[0].Refund, refundEvent.RefundAmount)
+	})
+
+	t.Run("Rejected return frees items", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+		requestAll := []service.RequestedReturnItem{{ItemID: itemID, Quantity: 3}}
+		returnID, err := f.returnService.RequestReturn(order.ID, requestAll, "", "customer")
+		require.NoError(t, err)
+
+		err = f.returnService.RejectReturn(returnID, "manager", "")
+		require.ErrorIs(t, err, service.ErrRejectReasonRequired)
+		require.NoError(t, f.returnService.RejectReturn(returnID, "manager", "used item"))
+
+		_, err = f.returnService.RequestReturn(order.ID, requestAll, "", "customer")
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail invalid status transitions", func(t *testing.T) {
+		f := setup()
+		order := storeDeliveredOrder(f, model.Paid)
+		returnID, err := f.returnService.RequestReturn(order.ID, requestOne, "", "customer")
+		require.NoError(t, err)
+
+		err = f.returnService.ReceiveReturn(returnID, "warehouse", "")
+		require.ErrorIs(t, err, service.ErrInvalidReturnStatusTransition)
+
+		require.NoError(t, f.returnService.RejectReturn(returnID, "manager", "late"))
+		err = f.returnService.ApproveReturn(returnID, "manager", "")
+		require.ErrorIs(t, err, service.ErrInvalidReturnStatusTransition)
+
+		err = f.returnService.ApproveReturn(uuid.Must(uuid.NewV7()), "manager", "")
+		require.ErrorIs(t, err, model.ErrReturnNotFound)
+	})
+}
+
+var _ model.ReturnRepository = &mockReturnRepository{}
+
+type mockReturnRepository struct {
+	store map[uuid.UUID]*model.Return
+}
+
+func (m *mockReturnRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockReturnRepository) Store(r *model.Return) error {
+	r.Version++
+	m.store[r.ID] = r
+	return nil
+}
+
+func (m *mockReturnRepository) Find(id uuid.UUID) (*model.Return, error) {
+	if r, ok := m.store[id]; ok {
+		return r, nil
+	}
+	return nil, model.ErrReturnNotFound
+}
+
+func (m *mockReturnRepository) FindByOrder(orderID uuid.UUID) ([]model.Return, error) {
+	var returns []model.Return
+	for _, r := range m.store {
+		if r.OrderID == orderID {
+			returns = append(returns, *r)
+		}
+	}
+	return returns, nil
+}
+
+var _ model.ReturnStatusHistoryRepository = &mockReturnStatusHistoryRepository{}
+
+type mockReturnStatusHistoryRepository struct {
+	changes []*model.ReturnStatusChange
+}
+
+func (m *mockReturnStatusHistoryRepository) Append(change *model.ReturnStatusChange) error {
+	m.changes = append(m.changes, change)
+	return nil
+}
@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+func TestShipmentService(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	keyboardID := uuid.Must(uuid.NewV7())
+	mouseID := uuid.Must(uuid.NewV7())
+	items := []model.Item{
+		{
+			ID:        keyboardID,
+			ProductID: uuid.Must(uuid.NewV7()),
+			Name:      "Keyboard",
+			Price:     model.Money{Amount: 1000, Currency: model.DefaultCurrency},
+			Quantity:  2,
+		},
+		{
+			ID:        mouseID,
+			ProductID: uuid.Must(uuid.NewV7()),
+			Name:      "Mouse",
+			Price:     model.Money{Amount: 500, Currency: model.DefaultCurrency},
+			Quantity:  1,
+		},
+	}
+	oneKeyboard := []service.RequestedShipmentItem{{ItemID: keyboardID, Quantity: 1}}
+
+	t.Run("Create shipment for paid order", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, items...)
+
+		shipmentID, err := f.shipmentService.CreateShipment(order.ID, oneKeyboard)
+
+		require.NoError(t, err)
+		shipment := f.shipmentRepo.store[shipmentID]
+		require.NotNil(t, shipment)
+		require.Equal(t, model.ShipmentPacked, shipment.Status)
+		require.Equal(t, order.CustomerID, shipment.CustomerID)
+		require.Len(t, shipment.Items, 1)
+		require.Equal(t, "Keyboard", shipment.Items[0].Name)
+		require.Equal(t, 1, shipment.Items[0].Quantity)
+
+		require.Len(t, f.eventDispatcher.events, 1)
+		event, ok := f.eventDispatcher.events[0].(model.OrderShipmentPacked)
+		require.True(t, ok)
+		require.Equal(t, shipmentID, event.ShipmentID)
+		require.Equal(t, order.CustomerID, event.CustomerID)
+		require.Len(t, event.Items, 1)
+	})
+
+	t.Run("Fail to ship not paid order", func(t *testing.T) {
+		f := setup()
+		for _, status := range []model.OrderStatus{model.Open, model.Pending, model.Cancelled} {
+			order := f.storeOrder(customerID, status, items...)
+
+			_, err := f.shipmentService.CreateShipment(order.ID, oneKeyboard)
+
+			require.ErrorIs(t, err, service.ErrOrderNotShippable)
+		}
+		require.Empty(t, f.shipmentRepo.store)
+	})
+
+	t.Run("Fail to create shipment with invalid items", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, items...)
+
+		for _, items := range [][]service.RequestedShipmentItem{
+			nil,
+			{{ItemID: keyboardID, Quantity: -1}},
+			{{ItemID: keyboardID, Quantity: 1}, {ItemID: keyboardID, Quantity: 1}},
+		} {
+			_, err := f.shipmentService.CreateShipment(order.ID, items)
+			require.ErrorIs(t, err, model.ErrInvalidShipmentItems)
+		}
+
+		_, err := f.shipmentService.CreateShipment(order.ID, []service.RequestedShipmentItem{
+			{ItemID: uuid.Must(uuid.NewV7()), Quantity: 1},
+		})
+		require.ErrorIs(t, err, model.ErrItemNotFound)
+	})
+
+	t.Run("Split order into several shipments", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, items...)
+
+		_, err := f.shipmentService.CreateShipment(order.ID, oneKeyboard)
+		require.NoError(t, err)
+		_, err = f.shipmentService.CreateShipment(order.ID, []service.RequestedShipmentItem{
+			{ItemID: keyboardID, Quantity: 1},
+			{ItemID: mouseID, Quantity: 1},
+		})
+		require.NoError(t, err)
+
+		_, err = f.shipmentService.CreateShipment(order.ID, oneKeyboard)
+		require.ErrorIs(t, err, service.ErrShipmentQuantityExceeded)
+	})
+
+	t.Run("Ship and deliver shipment", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, items...)
+		shipmentID, err := f.shipmentService.CreateShipment(order.ID, oneKeyboard)
+		require.NoError(t, err)
+
+		err = f.shipmentService.ShipShipment(shipmentID, "CDEK", "")
+		require.ErrorIs(t, err, service.ErrTrackingInfoRequired)
+
+		require.NoError(t, f.shipmentService.ShipShipment(shipmentID, "CDEK", "1234567890"))
+		shipment := f.shipmentRepo.store[shipmentID]
+		require.Equal(t, model.ShipmentShipped, shipment.Status)
+		require.Equal(t, "CDEK", shipment.Carrier)
+		require.NotNil(t, shipment.ShippedAt)
+		require.Nil(t, shipment.DeliveredAt)
+
+		require.NoError(t, f.shipmentService.DeliverShipment(shipmentID))
+		require.Equal(t, model.ShipmentDelivered, shipment.Status)
+		require.NotNil(t, shipment.DeliveredAt)
+
+		require.Len(t, f.eventDispatcher.events, 3)
+		shipped, ok := f.eventDispatcher.events[1].(model.OrderShipmentShipped)
+		require.True(t, ok)
+		require.Equal(t, "1234567890", shipped.TrackingNumber)
+		delivered, ok := f.eventDispatcher.events[2].(model.OrderShipmentDelivered)
+		require.True(t, ok)
+		require.Equal(t, order.CustomerID, delivered.CustomerID)
+		require.Equal(t, "CDEK", delivered.Carrier)
+	})
+
+	t.Run("Fail to ship shipment of cancelled order", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, items...)
+		shipment := f.storeShipment(order, model.ShipmentPacked)
+		require.NoError(t, f.orderService.CancelOrder(order.ID, "test", "out of stock"))
+
+		err := f.shipmentService.ShipShipment(shipment.ID, "CDEK", "1234567890")
+
+		require.ErrorIs(t, err, service.ErrOrderNotShippable)
+		require.Equal(t, model.ShipmentPacked, f.shipmentRepo.store[shipment.ID].Status)
+	})
+
+	t.Run("Fail invalid status transitions", func(t *testing.T) {
+		f := setup()
+		order := f.storeOrder(customerID, model.Paid, items...)
+		shipmentID, err := f.shipmentService.CreateShipment(order.ID, oneKeyboard)
+		require.NoError(t, err)
+
+		err = f.shipmentService.DeliverShipment(shipmentID)
+		require.ErrorIs(t, err, service.ErrInvalidShipmentStatusTransition)
+
+		require.NoError(t, f.shipmentService.ShipShipment(shipmentID, "CDEK", "1234567890"))
+		err = f.shipmentService.ShipShipment(shipmentID, "CDEK", "1234567890")
+		require.ErrorIs(t, err, service.ErrInvalidShipmentStatusTransition)
+
+		err = f.shipmentService.DeliverShipment(uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, model.ErrShipmentNotFound)
+	})
+}
+
+var _ model.ShipmentRepository = &mockShipmentRepository{}
+
+type mockShipmentRepository struct {
+	store map[uuid.UUID]*model.Shipment
+}
+
+func (m *mockShipmentRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (m *mockShipmentRepository) Store(shipment *model.Shipment) error {
+	shipment.Version++
+	m.store[shipment.ID] = shipment
+	return nil
+}
+
+func (m *mockShipmentRepository) Find(id uuid.UUID) (*model.Shipment, error) {
+	if shipment, ok := m.store[id]; ok {
+		return shipment, nil
+	}
+	return nil, model.ErrShipmentNotFound
+}
+
+func (m *mockShipmentRepository) FindByOrder(orderID uuid.UUID) ([]model.Shipment, error) {
+	var shipments []model.Shipment
+	for _, shipment := range m.store {
+		if shipment.OrderID == orderID {
+			shipments = append(shipments, *shipment)
+		}
+	}
+	return shipments, nil
+}
@@ -0,0 +1,385 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+func TestCalculateTax(t *testing.T) {
+	rub := func(amount int64) model.Money {
+		return model.Money{Amount: amount, Currency: model.DefaultCurrency}
+	}
+	item := func(price int64, quantity int, category string) model.Item {
+		return model.Item{ID: uuid.Must(uuid.NewV7()), Price: rub(price), Quantity: quantity, Category: category}
+	}
+	rule := func(rate model.TaxRate, pricing model.TaxPricing, rounding model.TaxRounding) []model.TaxRule {
+		return []model.TaxRule{{Rate: rate, Pricing: pricing, Rounding: rounding}}
+	}
+	percentOff := func(percent int) *model.AppliedPromoCode {
+		return &model.AppliedPromoCode{Code: "SALE", Discount: model.Discount{Percent: percent}}
+	}
+	amountOff := func(amount int64) *model.AppliedPromoCode {
+		return &model.AppliedPromoCode{Code: "SALE", Discount: model.Discount{Amount: toPtr(rub(amount))}}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		items     []model.Item
+		rules     []model.TaxRule
+		promoCode *model.AppliedPromoCode
+		delivery  *model.Delivery
+		// lineTaxes - налог каждой позиции, net и gross - итоги заказа
+		lineTaxes []int64
+		net       int64
+		gross     int64
+	}{
+		{
+			name:      "No rules means no tax",
+			items:     []model.Item{item(1000, 1, "")},
+			lineTaxes: []int64{0},
+			net:       1000,
+			gross:     1000,
+		},
+		{
+			name:      "Exclusive tax is added to price",
+			items:     []model.Item{item(1000, 2, "")},
+			rules:     rule(2000, model.TaxExclusive, model.TaxRoundPerLine),
+			lineTaxes: []int64{400},
+			net:       2000,
+			gross:     2400,
+		},
+		{
+			name:      "Half a cent rounds up",
+			items:     []model.Item{item(25, 1, "")},
+			rules:     rule(1000, model.TaxExclusive, model.TaxRoundPerLine),
+			lineTaxes: []int64{3},
+			net:       25,
+			gross:     28,
+		},
+		{
+			name:      "Less than half a cent rounds down",
+			items:     []model.Item{item(24, 1, "")},
+			rules:     rule(1000, model.TaxExclusive, model.TaxRoundPerLine),
+			lineTaxes: []int64{2},
+			net:       24,
+			gross:     26,
+		},
+		{
+			name:      "Rounding per line accumulates rounding up",
+			items:     []model.Item{item(13, 1, ""), item(13, 1, ""), item(13, 1, "")},
+			rules:     rule(2000, model.TaxExclusive, model.TaxRoundPerLine),
+			lineTaxes: []int64{3, 3, 3},
+			net:       39,
+			gross:     48,
+		},
+		{
+			name:      "Rounding per order rounds the sum once",
+			items:     []model.Item{item(13, 1, ""), item(13, 1, ""), item(13, 1, "")},
+			rules:     rule(2000, model.TaxExclusive, model.TaxRoundPerOrder),
+			lineTaxes: []int64{3, 3, 2},
+			net:       39,
+			gross:     47,
+		},
+		{
+			name:      "Inclusive tax is extracted from price",
+			items:     []model.Item{item(12000, 1, "")},
+			rules:     rule(2000, model.TaxInclusive, model.TaxRoundPerLine),
+			lineTaxes: []int64{2000},
+			net:       10000,
+			gross:     12000,
+		},
+		{
+			name:      "Inclusive tax rounding per line",
+			items:     []model.Item{item(100, 1, ""), item(100, 1, ""), item(100, 1, "")},
+			rules:     rule(2000, model.TaxInclusive, model.TaxRoundPerLine),
+			lineTaxes: []int64{17, 17, 17},
+			net:       249,
+			gross:     300,
+		},
+		{
+			name:      "Inclusive tax rounding per order",
+			items:     []model.Item{item(100, 1, ""), item(100, 1, ""), item(100, 1, "")},
+			rules:     rule(2000, model.TaxInclusive, model.TaxRoundPerOrder),
+			lineTaxes: []int64{17, 17, 16},
+			net:       250,
+			gross:     300,
+		},
+		{
+			name:      "Percent discount reduces tax base proportionally",
+			items:     []model.Item{item(1000, 1, ""), item(2000, 1, "")},
+			rules:     rule(2000, model.TaxExclusive, model.TaxRoundPerLine),
+			promoCode: percentOff(10),
+			lineTaxes: []int64{180, 360},
+			net:       2700,
+			gross:     3240,
+		},
+		{
+			name:      "Discount cents left after split go to lines with largest remainder",
+			items:     []model.Item{item(100, 1, ""), item(200, 1, ""), item(100, 1, "")},
+			rules:     rule(10000, model.TaxExclusive, model.TaxRoundPerLine),
+			promoCode: amountOff(101),
+			// Доли скидки 25.25, 50.5 и 25.25 округляются до 25, 51 и 25
+			lineTaxes: []int64{75, 149, 75},
+			net:       299,
+			gross:     598,
+		},
+		{
+			name:      "Full discount leaves nothing to tax",
+			items:     []model.Item{item(333, 1, ""), item(667, 1, "")},
+			rules:     rule(2000, model.TaxExclusive, model.TaxRoundPerOrder),
+			promoCode: percentOff(100),
+			lineTaxes: []int64{0, 0},
+			net:       0,
+			gross:     0,
+		},
+		{
+			name:  "Rate depends on category",
+			items: []model.Item{item(1000, 1, "books"), item(1000, 1, "")},
+			rules: []model.TaxRule{
+				{Rate: 2000},
+				{Category: "books", Rate: 1000},
+			},
+			lineTaxes: []int64{100, 200},
+			net:       2000,
+			gross:     2300,
+		},
+		{
+			name:  "Rounding per order groups lines by rule",
+			items: []model.Item{item(13, 1, "books"), item(13, 1, ""), item(13, 1, "books")},
+			rules: []model.TaxRule{
+				{Rate: 2000, Rounding: model.TaxRoundPerOrder},
+				{Category: "books", Rate: 2000, Rounding: model.TaxRoundPerOrder},
+			},
+			// 0.026 + 0.026 = 0.052 по книгам и 0.026 по остальному
+			lineTaxes: []int64{3, 3, 2},
+			net:       39,
+			gross:     47,
+		},
+		{
+			name:  "Lines with different pricing in one order",
+			items: []model.Item{item(1200, 1, "food"), item(1000, 1, "")},
+			rules: []model.TaxRule{
+				{Rate: 2000, Pricing: model.TaxExclusive},
+				{Category: "food", Rate: 2000, Pricing: model.TaxInclusive},
+			},
+			lineTaxes: []int64{200, 200},
+			net:       2000,
+			gross:     2400,
+		},
+		{
+			name:  "Shipping is not taxed and rate depends on delivery country",
+			items: []model.Item{item(1000, 1, "")},
+			rules: []model.TaxRule{
+				{Rate: 2000},
+				{Country: "KZ", Rate: 1200},
+			},
+			delivery: &model.Delivery{
+				Address: model.DeliveryAddress{Country: "KZ"},
+				Method:  model.ShippingStandard,
+				Cost:    rub(500),
+			},
+			lineTaxes: []int64{120},
+			net:       1500,
+			gross:     1620,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			order := &model.Order{Items: tc.items, PromoCode: tc.promoCode, Delivery: tc.delivery}
+
+			tax, err := model.CalculateTax(order, tc.rules)
+
+			require.NoError(t, err)
+			require.Len(t, tax.Lines, len(tc.items))
+			taxTotal := int64(0)
+			for i, line := range tax.Lines {
+				require.Equal(t, tc.items[i].ID, line.ItemID)
+				require.Equal(t, tc.lineTaxes[i], line.Tax.Amount, "line %d", i)
+				require.Equal(t, line.Gross.Amount, line.Net.Amount+line.Tax.Amount, "line %d", i)
+				taxTotal += line.Tax.Amount
+			}
+			require.Equal(t, rub(taxTotal), tax.Tax)
+			require.Equal(t, rub(tc.net), tax.Net)
+			require.Equal(t, rub(tc.gross), tax.Gross)
+
+			order.Tax = tax
+			total, err := order.Total()
+			require.NoError(t, err)
+			require.Equal(t, tax.Gross, total)
+		})
+	}
+}
+
+func TestFindTaxRule(t *testing.T) {
+	rules := []model.TaxRule{
+		{Rate: 1},
+		{Country: "RU", Rate: 2},
+		{Category: "books", Rate: 3},
+		{Category: "books", Country: "RU", Rate: 4},
+	}
+
+	for _, tc := range []struct {
+		category string
+		country  string
+		expected model.TaxRate
+	}{
+		{category: "", country: "", expected: 1},
+		{category: "food", country: "KZ", expected: 1},
+		{category: "food", country: "RU", expected: 2},
+		{category: "books", country: "KZ", expected: 3},
+		{category: "books", country: "", expected: 3},
+		{category: "books", country: "RU", expected: 4},
+	} {
+		rule := model.FindTaxRule(rules, tc.category, tc.country)
+
+		require.NotNil(t, rule, "%s/%s", tc.category, tc.country)
+		require.Equal(t, tc.expected, rule.Rate, "%s/%s", tc.category, tc.country)
+	}
+
+	require.Nil(t, model.FindTaxRule(rules[1:2], "", "KZ"))
+}
+
+func TestOrderTax(t *testing.T) {
+	customerID := uuid.Must(uuid.NewV7())
+	price := model.Money{Amount: 10000, Currency: model.DefaultCurrency}
+	book := model.Product{ID: uuid.Must(uuid.NewV7()), Name: "Book", Category: "books", Price: price}
+	keyboard := model.Product{ID: uuid.Must(uuid.NewV7()), Name: "Keyboard", Price: price}
+	defaultRule := model.TaxRule{Rate: 2000}
+	bookRule := model.TaxRule{Category: "books", Rate: 1000}
+
+	t.Run("Recalculate tax when items change", func(t *testing.T) {
+		f := setup()
+		f.taxRuleRepo.rules = []model.TaxRule{defaultRule, bookRule}
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		require.Equal(t, int64(0), f.repo.store[orderID].Tax.Tax.Amount)
+
+		_, err := f.orderService.AddItem(orderID, book, 1)
+		require.NoError(t, err)
+		itemID, err := f.orderService.AddItem(orderID, keyboard, 1)
+		require.NoError(t, err)
+
+		order := f.repo.store[orderID]
+		require.Equal(t, "books", order.Items[0].Category)
+		require.Equal(t, int64(1000+2000), order.Tax.Tax.Amount)
+		total, _ := order.Total()
+		require.Equal(t, int64(23000), total.Amount)
+
+		require.NoError(t, f.orderService.DeleteItem(orderID, itemID))
+		require.Equal(t, int64(1000), f.repo.store[orderID].Tax.Tax.Amount)
+	})
+
+	t.Run("Recalculate tax for delivery country", func(t *testing.T) {
+		f := setup()
+		f.taxRuleRepo.rules = []model.TaxRule{defaultRule, {Country: "KZ", Rate: 1200}}
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, keyboard, 1)
+
+		err := f.orderService.SetDelivery(orderID, model.Delivery{
+			Address: model.DeliveryAddress{
+				Recipient:  "Асель",
+				Phone:      "+77011234567",
+				Country:    "kz",
+				City:       "Алматы",
+				Street:     "Абая",
+				Building:   "1",
+				PostalCode: "050000",
+			},
+			Method: model.ShippingStandard,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, int64(1200), f.repo.store[orderID].Tax.Tax.Amount)
+	})
+
+	t.Run("Fix tax by rules in force at checkout", func(t *testing.T) {
+		f := setup()
+		orderID, _ := f.orderService.CreateOrder(customerID, nil)
+		_, _ = f.orderService.AddItem(orderID, keyboard, 1)
+		require.Equal(t, int64(0), f.repo.store[orderID].Tax.Tax.Amount)
+
+		f.taxRuleRepo.rules = []model.TaxRule{defaultRule}
+		require.NoError(t, f.orderService.SetStatus(orderID, model.Pending, "test", ""))
+		require.Equal(t, int64(2000), f.repo.store[orderID].Tax.Tax.Amount)
+
+		f.taxRuleRepo.rules = nil
+		require.NoError(t, f.orderService.SetStatus(orderID, model.Paid, "test", ""))
+		require.Equal(t, int64(2000), f.repo.store[orderID].Tax.Tax.Amount)
+	})
+
+	t.Run("Set tax rule", func(t *testing.T) {
+		taxRuleRepo := &mockTaxRuleRepository{}
+		taxRuleService := service.NewTaxRuleService(taxRuleRepo)
+
+		err := taxRuleService.SetTaxRule(model.TaxRule{Category: " Books ", Country: "ru", Rate: 1000})
+
+		require.NoError(t, err)
+		require.Equal(t, []model.TaxRule{{Category: "books", Country: "RU", Rate: 1000}}, taxRuleRepo.rules)
+
+		err = taxRuleService.SetTaxRule(model.TaxRule{Category: "books", Country: "RU", Rate: 2000})
+
+		require.NoError(t, err)
+		require.Len(t, taxRuleRepo.rules, 1)
+		require.Equal(t, model.TaxRate(2000), taxRuleRepo.rules[0].Rate)
+
+		require.NoError(t, taxRuleService.DeleteTaxRule("BOOKS", "ru"))
+		require.Empty(t, taxRuleRepo.rules)
+		require.ErrorIs(t, taxRuleService.DeleteTaxRule("books", "RU"), model.ErrTaxRuleNotFound)
+	})
+
+	t.Run("Fail to set invalid tax rule", func(t *testing.T) {
+		taxRuleRepo := &mockTaxRuleRepository{}
+		taxRuleService := service.NewTaxRuleService(taxRuleRepo)
+
+		for _, rule := range []model.TaxRule{
+			{Rate: -1},
+			{Rate: model.MaxTaxRate + 1},
+			{Country: "RUS", Rate: 1000},
+			{Rate: 1000, Pricing: 2},
+			{Rate: 1000, Rounding: 2},
+		} {
+			require.ErrorIs(t, taxRuleService.SetTaxRule(rule), model.ErrInvalidTaxRule)
+		}
+		require.Empty(t, taxRuleRepo.rules)
+	})
+}
+
+var _ model.TaxRuleRepository = &mockTaxRuleRepository{}
+
+type mockTaxRuleRepository struct {
+	rules []model.TaxRule
+}
+
+func (m *mockTaxRuleRepository) Store(rule *model.TaxRule) error {
+	for i, stored := range m.rules {
+		if stored.Category == rule.Category && stored.Country == rule.Country {
+			m.rules[i] = *rule
+			return nil
+		}
+	}
+	m.rules = append(m.rules, *rule)
+	return nil
+}
+
+func (m *mockTaxRuleRepository) Delete(category, country string) error {
+	for i, stored := range m.rules {
+		if stored.Category == category && stored.Country == country {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return model.ErrTaxRuleNotFound
+}
+
+func (m *mockTaxRuleRepository) FindByCountry(country string) ([]model.TaxRule, error) {
+	var rules []model.TaxRule
+	for _, rule := range m.rules {
+		if rule.Country == "" || rule.Country == country {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,62 @@
+package checkout
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"order/pkg/application/service"
+)
+
+func NewResumer(
+	checkoutService service.CheckoutService,
+	logger *log.Logger,
+	interval time.Duration,
+	retryDelay time.Duration,
+	batchSize int,
+) *Resumer {
+	return &Resumer{
+		checkoutService: checkoutService,
+		logger:          logger,
+		interval:        interval,
+		retryDelay:      retryDelay,
+		batchSize:       batchSize,
+	}
+}
+
+// Resumer периодически продолжает незавершённые оформления заказов, пока не будет отменён контекст.
+// Саги, которые менялись позже чем retryDelay назад, не трогаются: их ещё выполняет запрос Checkout
+// или их шаг недавно упал
+type Resumer struct {
+	checkoutService service.CheckoutService
+	logger          *log.Logger
+	interval        time.Duration
+	retryDelay      time.Duration
+	batchSize       int
+}
+
+func (r *Resumer) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.resume(ctx)
+		}
+	}
+}
+
+func (r *Resumer) resume(ctx context.Context) {
+	resumed, err := r.checkoutService.ResumeCheckouts(ctx, time.Now().Add(-r.retryDelay), r.batchSize)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to resume checkouts")
+		return
+	}
+	if resumed > 0 {
+		r.logger.WithField("count", resumed).Debug("checkouts resumed")
+	}
+}
@@ -0,0 +1,29 @@
+package event
+
+import (
+	commonevent "common/event"
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+)
+
+type Serializer = commonevent.Serializer[service.Event]
+
+func NewJSONSerializer() Serializer {
+	return commonevent.NewJSONSerializer[service.Event](
+		model.OrderCreated{},
+		model.OrderDeleted{},
+		model.OrderRestored{},
+		model.OrderItemChanged{},
+		model.OrderStatusChanged{},
+		model.OrderCancelled{},
+		model.OrderPromoCodeApplied{},
+		model.OrderPromoCodeRemoved{},
+		model.OrderDeliveryChanged{},
+		model.OrderReturnRequested{},
+		model.OrderReturnStatusChanged{},
+		model.OrderReturnRefundRequested{},
+		model.OrderShipmentPacked{},
+		model.OrderShipmentShipped{},
+		model.OrderShipmentDelivered{},
+	)
+}
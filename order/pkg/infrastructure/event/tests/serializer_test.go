@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
+	"order/pkg/infrastructure/event"
+)
+
+// Структуры событий первой версии схемы в том виде, в каком их читают потребители,
+// написанные до появления версий
+type orderCreatedV1 struct {
+	OrderID    uuid.UUID
+	CustomerID uuid.UUID
+	Delivery   *model.Delivery
+}
+
+type orderItemChangedV1 struct {
+	OrderID      uuid.UUID
+	AddedItems   []uuid.UUID
+	ChangedItems []uuid.UUID
+	RemovedItems []uuid.UUID
+}
+
+type orderStatusChangedV1 struct {
+	OrderID   uuid.UUID
+	OldStatus model.OrderStatus
+	NewStatus model.OrderStatus
+	Delivery  *model.Delivery
+}
+
+type orderCancelledV1 struct {
+	OrderID        uuid.UUID
+	CustomerID     uuid.UUID
+	Total          model.Money
+	Reason         string
+	CancelledBy    string
+	RefundRequired bool
+}
+
+type orderPromoCodeAppliedV1 struct {
+	OrderID uuid.UUID
+	Code    string
+}
+
+type orderDeletedV1 struct {
+	OrderID uuid.UUID
+}
+
+func TestJSONSerializer(t *testing.T) {
+	serializer := event.NewJSONSerializer()
+	orderID := uuid.MustParse("0192f0c8-5d6e-7a1b-9c2d-3e4f5a6b7c8d")
+	customerID := uuid.MustParse("0192f0c8-5d6e-7a1b-9c2d-3e4f5a6b7c8e")
+	itemID := uuid.MustParse("0192f0c8-5d6e-7a1b-9c2d-3e4f5a6b7c8f")
+	total := model.Money{Amount: 19998, Currency: model.DefaultCurrency}
+	metadata := model.NewEventMetadata(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+
+	t.Run("Old consumers decode new payloads", func(t *testing.T) {
+		decodeV1 := func(e service.Event, v1 any) {
+			payload, err := serializer.Serialize(e)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal([]byte(payload), v1))
+		}
+
+		var created orderCreatedV1
+		decodeV1(model.OrderCreated{EventMetadata: metadata, OrderID: orderID, CustomerID: customerID, Total: total}, &created)
+		require.Equal(t, orderCreatedV1{OrderID: orderID, CustomerID: customerID}, created)
+
+		var itemChanged orderItemChangedV1
+		decodeV1(model.OrderItemChanged{
+			EventMetadata: metadata,
+			OrderID:       orderID,
+			AddedItems:    []uuid.UUID{itemID},
+			Items:         []model.EventItem{{ItemID: itemID, ProductID: uuid.New(), Price: total, Quantity: 1}},
+			Total:         total,
+		}, &itemChanged)
+		require.Equal(t, orderItemChangedV1{OrderID: orderID, AddedItems: []uuid.UUID{itemID}}, itemChanged)
+
+		var statusChanged orderStatusChangedV1
+		decodeV1(model.OrderStatusChanged{
+			EventMetadata: metadata,
+			OrderID:       orderID,
+			OldStatus:     model.Open,
+			NewStatus:     model.Pending,
+			Total:         total,
+		}, &statusChanged)
+		require.Equal(t, orderStatusChangedV1{OrderID: orderID, OldStatus: model.Open, NewStatus: model.Pending}, statusChanged)
+
+		var cancelled orderCancelledV1
+		decodeV1(model.OrderCancelled{
+			EventMetadata:  metadata,
+			OrderID:        orderID,
+			CustomerID:     customerID,
+			Total:          total,
+			Reason:         "changed mind",
+			CancelledBy:    "customer",
+			RefundRequired: true,
+		}, &cancelled)
+		require.Equal(t, orderCancelledV1{
+			OrderID:        orderID,
+			CustomerID:     customerID,
+			Total:          total,
+			Reason:         "changed mind",
+			CancelledBy:    "customer",
+			RefundRequired: true,
+		}, cancelled)
+
+		var promoCodeApplied orderPromoCodeAppliedV1
+		decodeV1(model.OrderPromoCodeApplied{EventMetadata: metadata, OrderID: orderID, Code: "SALE", Total: total}, &promoCodeApplied)
+		require.Equal(t, orderPromoCodeAppliedV1{OrderID: orderID, Code: "SALE"}, promoCodeApplied)
+
+		var deleted orderDeletedV1
+		decodeV1(model.OrderDeleted{EventMetadata: metadata, OrderID: orderID, Total: total}, &deleted)
+		require.Equal(t, orderDeletedV1{OrderID: orderID}, deleted)
+	})
+
+	t.Run("Metadata is written at top level of payload", func(t *testing.T) {
+		payload, err := serializer.Serialize(model.OrderDeleted{EventMetadata: metadata, OrderID: orderID, Total: total})
+		require.NoError(t, err)
+
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(payload), &fields))
+		require.JSONEq(t, "2", string(fields["SchemaVersion"]))
+		require.JSONEq(t, `"2026-10-01T12:00:00Z"`, string(fields["OccurredAt"]))
+	})
+
+	t.Run("Decode v1 payloads", func(t *testing.T) {
+		decoded, err := serializer.Deserialize(
+			model.OrderItemChanged{}.Type(),
+			`{"OrderID":"`+orderID.String()+`","AddedItems":null,"ChangedItems":["`+itemID.String()+`"],"RemovedItems":null}`,
+		)
+		require.NoError(t, err)
+		require.Equal(t, model.OrderItemChanged{OrderID: orderID, ChangedItems: []uuid.UUID{itemID}}, decoded)
+
+		decoded, err = serializer.Deserialize(
+			model.OrderCancelled{}.Type(),
+			`{"OrderID":"`+orderID.String()+`","CustomerID":"`+customerID.String()+`",`+
+				`"Total":{"Amount":19998,"Currency":"RUB"},"Reason":"expired","CancelledBy":"system","RefundRequired":false}`,
+		)
+		require.NoError(t, err)
+		cancelled := decoded.(model.OrderCancelled)
+		require.Zero(t, cancelled.SchemaVersion)
+		require.Equal(t, total, cancelled.Total)
+		require.Equal(t, "expired", cancelled.Reason)
+	})
+
+	t.Run("Round trip new payloads", func(t *testing.T) {
+		itemChanged := model.OrderItemChanged{
+			EventMetadata: metadata,
+			OrderID:       orderID,
+			RemovedItems:  []uuid.UUID{itemID},
+			Items:         []model.EventItem{{ItemID: itemID, ProductID: customerID, Name: "Keyboard", Price: total, Quantity: 2}},
+			Total:         model.Money{Currency: model.DefaultCurrency},
+		}
+
+		payload, err := serializer.Serialize(itemChanged)
+		require.NoError(t, err)
+		decoded, err := serializer.Deserialize(itemChanged.Type(), payload)
+
+		require.NoError(t, err)
+		require.Equal(t, itemChanged, decoded)
+	})
+}
@@ -0,0 +1,68 @@
+package expiry
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"order/pkg/application/service"
+)
+
+func NewWorker(
+	expiryService service.OrderExpiryService,
+	logger *log.Logger,
+	interval time.Duration,
+	ttl time.Duration,
+	batchSize int,
+) *Worker {
+	return &Worker{
+		expiryService: expiryService,
+		logger:        logger,
+		interval:      interval,
+		ttl:           ttl,
+		batchSize:     batchSize,
+	}
+}
+
+// Worker периодически отменяет заказы, которые находятся в Pending дольше ttl с момента оформления,
+// пока не будет отменён контекст
+type Worker struct {
+	expiryService service.OrderExpiryService
+	logger        *log.Logger
+	interval      time.Duration
+	ttl           time.Duration
+	batchSize     int
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.expire(ctx)
+		}
+	}
+}
+
+func (w *Worker) expire(ctx context.Context) {
+	for ctx.Err() == nil {
+		expired, err := w.expiryService.ExpirePendingOrders(ctx, time.Now().Add(-w.ttl), w.batchSize)
+		if err != nil {
+			w.logger.WithError(err).Error("failed to expire pending orders")
+			return
+		}
+		if expired > 0 {
+			w.logger.WithField("count", expired).Info("pending orders expired")
+		}
+		// Неполная пачка значит, что просроченных заказов больше нет
+		// или остальные сейчас обрабатывают другие реплики
+		if expired < w.batchSize {
+			return
+		}
+	}
+}
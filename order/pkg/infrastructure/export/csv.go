@@ -0,0 +1,120 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+)
+
+var csvHeader = []string{
+	"order_id", "customer_id", "status", "created_at", "updated_at",
+	"promo_code", "shipping_method", "currency", "subtotal", "discount", "shipping_cost", "net", "tax", "total",
+	"item_id", "product_id", "product_name", "category", "price", "quantity", "item_total",
+}
+
+// csvWriter пишет строку на каждую позицию заказа, повторяя в ней поля заказа.
+// Заказ без позиций занимает одну строку с пустыми полями позиции
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := &csvWriter{w: csv.NewWriter(w)}
+	if err := writer.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) Write(order *query.Order) error {
+	totals, err := newOrderTotals(order)
+	if err != nil {
+		return err
+	}
+
+	var promoCode, shippingMethod string
+	if order.PromoCode != nil {
+		promoCode = order.PromoCode.Code
+	}
+	if order.Delivery != nil {
+		shippingMethod = shippingMethodNames[order.Delivery.Method]
+	}
+	orderFields := []string{
+		order.ID.String(),
+		order.CustomerID.String(),
+		statusNames[order.Status],
+		formatTime(order.CreatedAt),
+		formatTime(order.UpdatedAt),
+		promoCode,
+		shippingMethod,
+		totals.Total.Currency,
+		totals.Subtotal.Decimal(),
+		totals.Discount.Decimal(),
+		totals.ShippingCost.Decimal(),
+		totals.Net.Decimal(),
+		totals.Tax.Decimal(),
+		totals.Total.Decimal(),
+	}
+
+	if len(order.Items) == 0 {
+		return w.w.Write(append(orderFields, "", "", "", "", "", "", ""))
+	}
+	for _, item := range order.Items {
+		record := append(orderFields[:len(orderFields):len(orderFields)],
+			item.ID.String(),
+			item.ProductID.String(),
+			item.Name,
+			item.Category,
+			item.Price.Decimal(),
+			strconv.Itoa(item.Quantity),
+			item.Total().Decimal(),
+		)
+		if err = w.w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type orderTotals struct {
+	Subtotal     model.Money
+	Discount     model.Money
+	ShippingCost model.Money
+	// Net и Tax - суммы без налога и налога, у заказов без рассчитанного налога Net равен Total
+	Net   model.Money
+	Tax   model.Money
+	Total model.Money
+}
+
+func newOrderTotals(order *query.Order) (totals orderTotals, err error) {
+	if totals.Subtotal, err = order.Subtotal(); err != nil {
+		return orderTotals{}, err
+	}
+	if totals.Discount, err = order.Discount(); err != nil {
+		return orderTotals{}, err
+	}
+	if totals.ShippingCost, err = order.ShippingCost(); err != nil {
+		return orderTotals{}, err
+	}
+	if totals.Total, err = order.Total(); err != nil {
+		return orderTotals{}, err
+	}
+	totals.Net, totals.Tax = totals.Total, model.Money{Currency: totals.Total.Currency}
+	if order.Tax != nil {
+		totals.Net, totals.Tax = order.Tax.Net, order.Tax.Tax
+	}
+	return totals, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
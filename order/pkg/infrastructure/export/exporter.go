@@ -0,0 +1,105 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+)
+
+type Format string
+
+const (
+	FormatCSV       Format = "csv"
+	FormatJSONLines Format = "jsonl"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(value)); format {
+	case FormatCSV, FormatJSONLines:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q, expected %s or %s", value, FormatCSV, FormatJSONLines)
+	}
+}
+
+var statusNames = map[model.OrderStatus]string{
+	model.Open:      "open",
+	model.Pending:   "pending",
+	model.Paid:      "paid",
+	model.Cancelled: "cancelled",
+}
+
+var shippingMethodNames = map[model.ShippingMethod]string{
+	model.ShippingStandard: "standard",
+	model.ShippingExpress:  "express",
+	model.ShippingPickup:   "pickup",
+}
+
+func ParseStatus(value string) (model.OrderStatus, error) {
+	for status, name := range statusNames {
+		if strings.EqualFold(name, value) {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown order status %q", value)
+}
+
+// Writer записывает заказы в выходной поток по одному
+type Writer interface {
+	Write(order *query.Order) error
+	// Flush дописывает буферизованные данные, вызывается после последнего заказа
+	Flush() error
+}
+
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatJSONLines:
+		return newJSONLinesWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+func NewExporter(orderQueryService query.OrderQueryService) *Exporter {
+	return &Exporter{
+		orderQueryService: orderQueryService,
+		pageSize:          query.MaxOrdersPageSize,
+	}
+}
+
+// Exporter выгружает заказы с позициями постранично, в памяти держится не больше одной страницы
+type Exporter struct {
+	orderQueryService query.OrderQueryService
+	pageSize          int
+}
+
+// Export пишет заказы, подходящие под фильтры spec, от новых к старым и возвращает их количество.
+// Параметры страницы из spec не используются
+func (e *Exporter) Export(ctx context.Context, spec query.ListOrdersSpec, writer Writer) (int, error) {
+	spec.PageSize = e.pageSize
+	spec.After = nil
+
+	exported := 0
+	for {
+		page, err := e.orderQueryService.ListOrders(ctx, spec)
+		if err != nil {
+			return exported, err
+		}
+		for i := range page.Orders {
+			if err = writer.Write(&page.Orders[i]); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+		if page.NextCursor == nil {
+			return exported, writer.Flush()
+		}
+		spec.After = page.NextCursor
+	}
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"order/pkg/application/query"
+)
+
+// jsonLinesWriter пишет каждый заказ отдельной JSON-строкой. Суммы - десятичные строки, как в CSV
+type jsonLinesWriter struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLinesWriter(w io.Writer) *jsonLinesWriter {
+	buffered := bufio.NewWriter(w)
+	return &jsonLinesWriter{
+		w:       buffered,
+		encoder: json.NewEncoder(buffered),
+	}
+}
+
+type jsonOrder struct {
+	OrderID        string     `json:"orderId"`
+	CustomerID     string     `json:"customerId"`
+	Status         string     `json:"status"`
+	CreatedAt      string     `json:"createdAt"`
+	UpdatedAt      string     `json:"updatedAt"`
+	PromoCode      string     `json:"promoCode,omitempty"`
+	ShippingMethod string     `json:"shippingMethod,omitempty"`
+	Currency       string     `json:"currency"`
+	Subtotal       string     `json:"subtotal"`
+	Discount       string     `json:"discount"`
+	ShippingCost   string     `json:"shippingCost"`
+	Net            string     `json:"net"`
+	Tax            string     `json:"tax"`
+	Total          string     `json:"total"`
+	Items          []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ItemID      string `json:"itemId"`
+	ProductID   string `json:"productId"`
+	ProductName string `json:"productName"`
+	Category    string `json:"category,omitempty"`
+	Price       string `json:"price"`
+	Quantity    int    `json:"quantity"`
+	Total       string `json:"total"`
+}
+
+func (w *jsonLinesWriter) Write(order *query.Order) error {
+	totals, err := newOrderTotals(order)
+	if err != nil {
+		return err
+	}
+
+	result := jsonOrder{
+		OrderID:      order.ID.String(),
+		CustomerID:   order.CustomerID.String(),
+		Status:       statusNames[order.Status],
+		CreatedAt:    formatTime(order.CreatedAt),
+		UpdatedAt:    formatTime(order.UpdatedAt),
+		Currency:     totals.Total.Currency,
+		Subtotal:     totals.Subtotal.Decimal(),
+		Discount:     totals.Discount.Decimal(),
+		ShippingCost: totals.ShippingCost.Decimal(),
+		Net:          totals.Net.Decimal(),
+		Tax:          totals.Tax.Decimal(),
+		Total:        totals.Total.Decimal(),
+		Items:        make([]jsonItem, 0, len(order.Items)),
+	}
+	if order.PromoCode != nil {
+		result.PromoCode = order.PromoCode.Code
+	}
+	if order.Delivery != nil {
+		result.ShippingMethod = shippingMethodNames[order.Delivery.Method]
+	}
+	for _, item := range order.Items {
+		result.Items = append(result.Items, toJSONItem(item))
+	}
+
+	// Encode дописывает перевод строки после каждого объекта
+	return w.encoder.Encode(result)
+}
+
+func (w *jsonLinesWriter) Flush() error {
+	return w.w.Flush()
+}
+
+func toJSONItem(item query.Item) jsonItem {
+	return jsonItem{
+		ItemID:      item.ID.String(),
+		ProductID:   item.ProductID.String(),
+		ProductName: item.Name,
+		Category:    item.Category,
+		Price:       item.Price.Decimal(),
+		Quantity:    item.Quantity,
+		Total:       item.Total().Decimal(),
+	}
+}
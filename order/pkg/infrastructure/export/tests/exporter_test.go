@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/export"
+)
+
+func TestExporter(t *testing.T) {
+	price := model.Money{Amount: 12550, Currency: model.DefaultCurrency}
+	newOrder := func(itemsCount int) query.Order {
+		order := query.Order{
+			ID:         uuid.Must(uuid.NewV7()),
+			CustomerID: uuid.Must(uuid.NewV7()),
+			Status:     model.Paid,
+			CreatedAt:  time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+			UpdatedAt:  time.Date(2025, 3, 1, 12, 5, 0, 0, time.UTC),
+		}
+		for range itemsCount {
+			order.Items = append(order.Items, query.Item{
+				ID:        uuid.Must(uuid.NewV7()),
+				ProductID: uuid.Must(uuid.NewV7()),
+				Name:      "Keyboard, \"mechanical\"",
+				Price:     price,
+				Quantity:  2,
+			})
+		}
+		return order
+	}
+
+	t.Run("Export all pages", func(t *testing.T) {
+		orders := make([]query.Order, 0, query.MaxOrdersPageSize*2+1)
+		for range cap(orders) {
+			orders = append(orders, newOrder(1))
+		}
+		queryService := &mockOrderQueryService{orders: orders}
+		var output bytes.Buffer
+		writer, err := export.NewWriter(export.FormatJSONLines, &output)
+		require.NoError(t, err)
+
+		exported, err := export.NewExporter(queryService).Export(context.Background(), query.ListOrdersSpec{}, writer)
+
+		require.NoError(t, err)
+		require.Equal(t, len(orders), exported)
+		require.Equal(t, 3, queryService.pages)
+		require.Len(t, strings.Split(strings.TrimSpace(output.String()), "\n"), len(orders))
+	})
+
+	t.Run("Pass filters to query service", func(t *testing.T) {
+		queryService := &mockOrderQueryService{}
+		from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		spec := query.ListOrdersSpec{CreatedFrom: &from, Statuses: []model.OrderStatus{model.Paid}}
+		writer, _ := export.NewWriter(export.FormatCSV, &bytes.Buffer{})
+
+		_, err := export.NewExporter(queryService).Export(context.Background(), spec, writer)
+
+		require.NoError(t, err)
+		require.Equal(t, &from, queryService.lastSpec.CreatedFrom)
+		require.Equal(t, []model.OrderStatus{model.Paid}, queryService.lastSpec.Statuses)
+	})
+
+	t.Run("Write CSV row per item", func(t *testing.T) {
+		withItems := newOrder(2)
+		withItems.PromoCode = &model.AppliedPromoCode{Code: "SALE10", Discount: model.Discount{Percent: 10}}
+		empty := newOrder(0)
+		var output bytes.Buffer
+		writer, _ := export.NewWriter(export.FormatCSV, &output)
+
+		_, err := export.NewExporter(&mockOrderQueryService{orders: []query.Order{withItems, empty}}).
+			Export(context.Background(), query.ListOrdersSpec{}, writer)
+
+		require.NoError(t, err)
+		records, err := csv.NewReader(&output).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		header := records[0]
+		row := toMap(header, records[1])
+		require.Equal(t, withItems.ID.String(), row["order_id"])
+		require.Equal(t, "paid", row["status"])
+		require.Equal(t, "2025-03-01T12:00:00Z", row["created_at"])
+		require.Equal(t, "SALE10", row["promo_code"])
+		require.Equal(t, "502.00", row["subtotal"])
+		require.Equal(t, "50.20", row["discount"])
+		require.Equal(t, "451.80", row["total"])
+		require.Equal(t, "Keyboard, \"mechanical\"", row["product_name"])
+		require.Equal(t, "251.00", row["item_total"])
+		require.Equal(t, withItems.Items[1].ID.String(), toMap(header, records[2])["item_id"])
+		emptyRow := toMap(header, records[3])
+		require.Equal(t, empty.ID.String(), emptyRow["order_id"])
+		require.Empty(t, emptyRow["item_id"])
+	})
+
+	t.Run("Write tax totals", func(t *testing.T) {
+		order := newOrder(2)
+		order.Items[0].Category = "books"
+		var err error
+		order.Tax, err = model.CalculateTax(toModelOrder(order), []model.TaxRule{{Rate: 2000}})
+		require.NoError(t, err)
+		var output bytes.Buffer
+		writer, _ := export.NewWriter(export.FormatCSV, &output)
+
+		_, err = export.NewExporter(&mockOrderQueryService{orders: []query.Order{order}}).
+			Export(context.Background(), query.ListOrdersSpec{}, writer)
+
+		require.NoError(t, err)
+		records, err := csv.NewReader(&output).ReadAll()
+		require.NoError(t, err)
+		row := toMap(records[0], records[1])
+		require.Equal(t, "502.00", row["net"])
+		require.Equal(t, "100.40", row["tax"])
+		require.Equal(t, "602.40", row["total"])
+		require.Equal(t, "books", row["category"])
+	})
+
+	t.Run("Write JSON line per order", func(t *testing.T) {
+		order := newOrder(2)
+		var output bytes.Buffer
+		writer, _ := export.NewWriter(export.FormatJSONLines, &output)
+
+		_, err := export.NewExporter(&mockOrderQueryService{orders: []query.Order{order}}).
+			Export(context.Background(), query.ListOrdersSpec{}, writer)
+
+		require.NoError(t, err)
+		var line struct {
+			OrderID string `json:"orderId"`
+			Total   string `json:"total"`
+			Items   []struct {
+				Quantity int `json:"quantity"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &line))
+		require.Equal(t, order.ID.String(), line.OrderID)
+		require.Equal(t, "502.00", line.Total)
+		require.Len(t, line.Items, 2)
+	})
+
+	t.Run("Parse format and status", func(t *testing.T) {
+		format, err := export.ParseFormat("JSONL")
+		require.NoError(t, err)
+		require.Equal(t, export.FormatJSONLines, format)
+		_, err = export.ParseFormat("xml")
+		require.Error(t, err)
+
+		status, err := export.ParseStatus("Cancelled")
+		require.NoError(t, err)
+		require.Equal(t, model.Cancelled, status)
+		_, err = export.ParseStatus("shipped")
+		require.Error(t, err)
+	})
+}
+
+func toModelOrder(order query.Order) *model.Order {
+	items := make([]model.Item, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, model.Item{ID: item.ID, Category: item.Category, Price: item.Price, Quantity: item.Quantity})
+	}
+	return &model.Order{Items: items}
+}
+
+func toMap(header, record []string) map[string]string {
+	result := make(map[string]string, len(header))
+	for i, column := range header {
+		result[column] = record[i]
+	}
+	return result
+}
+
+var _ query.OrderQueryService = &mockOrderQueryService{}
+
+// mockOrderQueryService отдаёт orders страницами по spec.PageSize, курсор - индекс следующего заказа
+type mockOrderQueryService struct {
+	orders   []query.Order
+	pages    int
+	lastSpec query.ListOrdersSpec
+}
+
+func (m *mockOrderQueryService) ListOrders(_ context.Context, spec query.ListOrdersSpec) (*query.OrderPage, error) {
+	m.pages++
+	m.lastSpec = spec
+
+	start := 0
+	if spec.After != nil {
+		start = int(spec.After.CreatedAt.Unix())
+	}
+	end := min(start+spec.PageSize, len(m.orders))
+	page := &query.OrderPage{Orders: m.orders[start:end]}
+	if end < len(m.orders) {
+		page.NextCursor = &query.OrderCursor{CreatedAt: time.Unix(int64(end), 0)}
+	}
+	return page, nil
+}
+
+func (m *mockOrderQueryService) FindOrder(context.Context, uuid.UUID) (*query.Order, error) {
+	return nil, model.ErrOrderNotFound
+}
+
+func (m *mockOrderQueryService) FindStatusHistory(context.Context, uuid.UUID) ([]query.StatusChange, error) {
+	return nil, nil
+}
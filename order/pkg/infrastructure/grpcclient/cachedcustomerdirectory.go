@@ -0,0 +1,79 @@
+package grpcclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"order/pkg/application/service"
+)
+
+// maxCachedCustomers ограничивает память кэша: при переполнении из него удаляются
+// устаревшие записи, а если их нет - кэш очищается целиком
+const maxCachedCustomers = 10000
+
+// NewCachedCustomerDirectory запоминает на ttl только успешные проверки, поэтому удалённый
+// покупатель может создавать заказы ещё не дольше ttl. Ошибки не кэшируются
+func NewCachedCustomerDirectory(directory service.CustomerDirectory, ttl time.Duration) service.CustomerDirectory {
+	return &cachedCustomerDirectory{
+		directory: directory,
+		ttl:       ttl,
+		expiresAt: make(map[uuid.UUID]time.Time),
+	}
+}
+
+type cachedCustomerDirectory struct {
+	directory service.CustomerDirectory
+	ttl       time.Duration
+
+	mu        sync.Mutex
+	expiresAt map[uuid.UUID]time.Time
+}
+
+func (d *cachedCustomerDirectory) CheckCustomer(ctx context.Context, customerID uuid.UUID) error {
+	if d.isCached(customerID, time.Now()) {
+		return nil
+	}
+
+	if err := d.directory.CheckCustomer(ctx, customerID); err != nil {
+		return err
+	}
+
+	d.store(customerID, time.Now())
+	return nil
+}
+
+func (d *cachedCustomerDirectory) isCached(customerID uuid.UUID, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiresAt, ok := d.expiresAt[customerID]
+	if ok && !now.Before(expiresAt) {
+		delete(d.expiresAt, customerID)
+		return false
+	}
+	return ok
+}
+
+func (d *cachedCustomerDirectory) store(customerID uuid.UUID, now time.Time) {
+	if d.ttl <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.expiresAt) >= maxCachedCustomers {
+		for id, expiresAt := range d.expiresAt {
+			if !now.Before(expiresAt) {
+				delete(d.expiresAt, id)
+			}
+		}
+		if len(d.expiresAt) >= maxCachedCustomers {
+			clear(d.expiresAt)
+		}
+	}
+	d.expiresAt[customerID] = now.Add(d.ttl)
+}
@@ -0,0 +1,45 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "order/api/client/userinternal"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func NewCustomerDirectory(conn grpc.ClientConnInterface) service.CustomerDirectory {
+	return &customerDirectory{
+		client: api.NewUserInternalServiceClient(conn),
+	}
+}
+
+type customerDirectory struct {
+	client api.UserInternalServiceClient
+}
+
+func (d *customerDirectory) CheckCustomer(ctx context.Context, customerID uuid.UUID) error {
+	response, err := d.client.GetUser(ctx, &api.GetUserRequest{
+		UserID: customerID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return model.ErrCustomerNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user %s: %w", customerID, err)
+	}
+
+	if response.User == nil {
+		return fmt.Errorf("user service returned empty user %s", customerID)
+	}
+	if response.User.DeletedAt != nil {
+		return model.ErrCustomerDeleted
+	}
+	return nil
+}
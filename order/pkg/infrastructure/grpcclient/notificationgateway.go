@@ -0,0 +1,41 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "order/api/client/notificationinternal"
+	"order/pkg/application/service"
+)
+
+func NewNotificationGateway(conn grpc.ClientConnInterface) service.NotificationGateway {
+	return &notificationGateway{
+		client: api.NewNotificationInternalServiceClient(conn),
+	}
+}
+
+type notificationGateway struct {
+	client api.NotificationInternalServiceClient
+}
+
+// NotifyCustomer отправляет уведомление на email покупателя
+func (g *notificationGateway) NotifyCustomer(ctx context.Context, customerID uuid.UUID, message string) error {
+	_, err := g.client.ScheduleNotification(ctx, &api.ScheduleNotificationRequest{
+		UserID:  customerID.String(),
+		Channel: api.NotificationChannel_EMAIL,
+		Message: message,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+		return fmt.Errorf("%w: failed to notify customer %s: %s", service.ErrNotificationRejected, customerID, status.Convert(err).Message())
+	default:
+		return fmt.Errorf("failed to notify customer %s: %w", customerID, err)
+	}
+}
@@ -0,0 +1,101 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "order/api/client/paymentinternal"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func NewPaymentGateway(conn grpc.ClientConnInterface) service.PaymentGateway {
+	return &paymentGateway{
+		client: api.NewPaymentInternalServiceClient(conn),
+	}
+}
+
+type paymentGateway struct {
+	client api.PaymentInternalServiceClient
+}
+
+func (g *paymentGateway) InitiatePayment(
+	ctx context.Context,
+	orderID, customerID uuid.UUID,
+	amount model.Money,
+) (uuid.UUID, error) {
+	response, err := g.client.InitiatePayment(ctx, &api.InitiatePaymentRequest{
+		OrderID: orderID.String(),
+		UserID:  customerID.String(),
+		Amount: &api.Money{
+			Amount:   amount.Amount,
+			Currency: amount.Currency,
+		},
+	})
+	if err != nil {
+		return uuid.Nil, paymentError(fmt.Sprintf("initiate payment for order %s", orderID), err)
+	}
+
+	paymentID, err := uuid.Parse(response.PaymentID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("payment service returned invalid payment ID: %w", err)
+	}
+	return paymentID, nil
+}
+
+// ProcessPayment проводит платёж и возвращает его итоговый статус. Если платёж уже
+// проведён (повторный вызов после сбоя), статус берётся из GetPayment
+func (g *paymentGateway) ProcessPayment(ctx context.Context, paymentID uuid.UUID) (service.PaymentResult, error) {
+	_, err := g.client.ProcessPayment(ctx, &api.ProcessPaymentRequest{
+		PaymentID: paymentID.String(),
+	})
+	if err != nil && status.Code(err) != codes.FailedPrecondition {
+		return service.PaymentResult{}, paymentError(fmt.Sprintf("process payment %s", paymentID), err)
+	}
+
+	response, err := g.client.GetPayment(ctx, &api.GetPaymentRequest{
+		PaymentID: paymentID.String(),
+	})
+	if err != nil {
+		return service.PaymentResult{}, paymentError(fmt.Sprintf("get payment %s", paymentID), err)
+	}
+	if response.Payment == nil {
+		return service.PaymentResult{}, fmt.Errorf("payment service returned empty payment %s", paymentID)
+	}
+
+	switch response.Payment.Status {
+	case api.PaymentStatus_COMPLETED:
+		return service.PaymentResult{Completed: true}, nil
+	case api.PaymentStatus_FAILED:
+		return service.PaymentResult{FailureReason: response.Payment.FailureReason}, nil
+	case api.PaymentStatus_REFUNDED:
+		return service.PaymentResult{FailureReason: "payment has been refunded"}, nil
+	default:
+		return service.PaymentResult{}, fmt.Errorf("payment %s is still %s", paymentID, response.Payment.Status)
+	}
+}
+
+func (g *paymentGateway) RefundPayment(ctx context.Context, paymentID uuid.UUID) error {
+	_, err := g.client.RefundPayment(ctx, &api.RefundPaymentRequest{
+		PaymentID: paymentID.String(),
+	})
+	if err != nil {
+		return paymentError(fmt.Sprintf("refund payment %s", paymentID), err)
+	}
+	return nil
+}
+
+// paymentError отделяет отказы сервиса payment от сбоев, после которых запрос можно повторить
+func paymentError(action string, err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+		return fmt.Errorf("%w: failed to %s: %s", service.ErrPaymentRejected, action, status.Convert(err).Message())
+	default:
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+}
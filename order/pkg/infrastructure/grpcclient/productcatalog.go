@@ -0,0 +1,54 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "order/api/client/productinternal"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+)
+
+func NewProductCatalog(conn grpc.ClientConnInterface) service.ProductCatalog {
+	return &productCatalog{
+		client: api.NewProductInternalServiceClient(conn),
+	}
+}
+
+type productCatalog struct {
+	client api.ProductInternalServiceClient
+}
+
+func (c *productCatalog) FindProduct(ctx context.Context, productID uuid.UUID) (*model.Product, error) {
+	response, err := c.client.GetProduct(ctx, &api.GetProductRequest{
+		ProductID: productID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, model.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product %s: %w", productID, err)
+	}
+
+	product := response.Product
+	if product == nil || product.Price == nil {
+		return nil, fmt.Errorf("product service returned empty product %s", productID)
+	}
+
+	price, err := model.NewMoney(product.Price.Amount, product.Price.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price of product %s: %w", productID, err)
+	}
+
+	return &model.Product{
+		ID:       productID,
+		Name:     product.Name,
+		Category: model.NormalizeTaxCategory(product.Category),
+		Price:    price,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/grpcclient"
+)
+
+type mockCustomerDirectory struct {
+	errs  map[uuid.UUID]error
+	calls int
+}
+
+func (m *mockCustomerDirectory) CheckCustomer(_ context.Context, customerID uuid.UUID) error {
+	m.calls++
+	return m.errs[customerID]
+}
+
+func TestCachedCustomerDirectory(t *testing.T) {
+	ctx := context.Background()
+	customerID := uuid.New()
+
+	t.Run("Cache existing customer", func(t *testing.T) {
+		directory := &mockCustomerDirectory{}
+		cached := grpcclient.NewCachedCustomerDirectory(directory, time.Minute)
+
+		require.NoError(t, cached.CheckCustomer(ctx, customerID))
+		require.NoError(t, cached.CheckCustomer(ctx, customerID))
+		require.Equal(t, 1, directory.calls)
+	})
+
+	t.Run("Check again after ttl", func(t *testing.T) {
+		directory := &mockCustomerDirectory{}
+		cached := grpcclient.NewCachedCustomerDirectory(directory, 10*time.Millisecond)
+
+		require.NoError(t, cached.CheckCustomer(ctx, customerID))
+		time.Sleep(20 * time.Millisecond)
+		directory.errs = map[uuid.UUID]error{customerID: model.ErrCustomerDeleted}
+
+		require.ErrorIs(t, cached.CheckCustomer(ctx, customerID), model.ErrCustomerDeleted)
+		require.Equal(t, 2, directory.calls)
+	})
+
+	t.Run("Do not cache errors", func(t *testing.T) {
+		directory := &mockCustomerDirectory{errs: map[uuid.UUID]error{customerID: model.ErrCustomerNotFound}}
+		cached := grpcclient.NewCachedCustomerDirectory(directory, time.Minute)
+
+		require.ErrorIs(t, cached.CheckCustomer(ctx, customerID), model.ErrCustomerNotFound)
+		delete(directory.errs, customerID)
+
+		require.NoError(t, cached.CheckCustomer(ctx, customerID))
+		require.Equal(t, 2, directory.calls)
+	})
+}
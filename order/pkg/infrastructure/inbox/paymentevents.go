@@ -0,0 +1,69 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	commoninbox "common/inbox"
+
+	"order/pkg/application/service"
+)
+
+// PaymentEventTypes - события сервиса payment, на которые подписан сервис order
+var PaymentEventTypes = []string{
+	"PaymentCompleted",
+	"PaymentFailed",
+}
+
+// Поля событий повторяют payment/pkg/domain/model/event.go
+type paymentCompleted struct {
+	PaymentID uuid.UUID
+	OrderID   uuid.UUID
+}
+
+type paymentFailed struct {
+	PaymentID     uuid.UUID
+	OrderID       uuid.UUID
+	FailureReason string
+}
+
+func NewPaymentEventHandler(paymentEventService service.PaymentEventService) commoninbox.Handler {
+	return &paymentEventHandler{paymentEventService: paymentEventService}
+}
+
+type paymentEventHandler struct {
+	paymentEventService service.PaymentEventService
+}
+
+func (h *paymentEventHandler) Handle(ctx context.Context, message commoninbox.Message) error {
+	switch message.Type {
+	case "PaymentCompleted":
+		event, err := deserialize[paymentCompleted](message)
+		if err != nil {
+			return err
+		}
+		return h.paymentEventService.PaymentCompleted(ctx, message.ID, event.OrderID)
+	case "PaymentFailed":
+		event, err := deserialize[paymentFailed](message)
+		if err != nil {
+			return err
+		}
+		return h.paymentEventService.PaymentFailed(ctx, message.ID, event.OrderID, event.FailureReason)
+	default:
+		return fmt.Errorf("%w: unexpected event type %q", commoninbox.ErrInvalidMessage, message.Type)
+	}
+}
+
+func deserialize[T any](message commoninbox.Message) (T, error) {
+	var event T
+	if message.ID == "" {
+		return event, fmt.Errorf("%w: event %s has no ID", commoninbox.ErrInvalidMessage, message.Type)
+	}
+	if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+		return event, fmt.Errorf("%w: failed to deserialize event %s: %s", commoninbox.ErrInvalidMessage, message.ID, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,37 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"order/pkg/application/query"
+)
+
+func NewCheckoutQueryService(db *sqlx.DB) query.CheckoutQueryService {
+	return &checkoutQueryService{db: db}
+}
+
+type checkoutQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *checkoutQueryService) FindCheckout(ctx context.Context, checkoutID uuid.UUID) (*query.Checkout, error) {
+	saga, err := NewCheckoutSagaRepository(ctx, s.db).Find(checkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.Checkout{
+		ID:            saga.ID,
+		OrderID:       saga.OrderID,
+		CustomerID:    saga.CustomerID,
+		Amount:        saga.Amount,
+		State:         saga.State,
+		PaymentID:     saga.PaymentID,
+		FailureReason: saga.FailureReason,
+		CreatedAt:     saga.CreatedAt,
+		UpdatedAt:     saga.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,200 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+	"order/pkg/domain/model"
+)
+
+type CheckoutSagaRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewCheckoutSagaRepository(ctx context.Context, client ClientContext) *CheckoutSagaRepository {
+	return &CheckoutSagaRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func (r *CheckoutSagaRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewUUID()
+}
+
+// Store сохраняет сагу, проверяя, что с момента чтения её версия не изменилась.
+// Так два экземпляра сервиса не выполнят один и тот же шаг дважды
+func (r *CheckoutSagaRepository) Store(saga *model.CheckoutSaga) error {
+	paymentID := sql.NullString{}
+	if saga.PaymentID != uuid.Nil {
+		paymentID = sql.NullString{String: saga.PaymentID.String(), Valid: true}
+	}
+	failureReason := sql.NullString{String: saga.FailureReason, Valid: saga.FailureReason != ""}
+
+	if saga.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO checkout_sagas (id, order_id, customer_id, amount, currency, state, payment_id, failure_reason, attempts, created_at, updated_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			saga.ID.String(),
+			saga.OrderID.String(),
+			saga.CustomerID.String(),
+			saga.Amount.Decimal(),
+			saga.Amount.Currency,
+			int(saga.State),
+			paymentID,
+			failureReason,
+			saga.Attempts,
+			saga.CreatedAt,
+			saga.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store checkout saga: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE checkout_sagas
+			SET state = ?, payment_id = ?, failure_reason = ?, attempts = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			int(saga.State),
+			paymentID,
+			failureReason,
+			saga.Attempts,
+			saga.UpdatedAt,
+			saga.ID.String(),
+			saga.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store checkout saga: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
+	}
+	saga.Version++
+
+	return nil
+}
+
+func (r *CheckoutSagaRepository) Find(id uuid.UUID) (*model.CheckoutSaga, error) {
+	var row checkoutSagaRow
+	err := r.client.GetContext(r.ctx, &row, `
+		SELECT id, order_id, customer_id, amount, currency, state, payment_id, failure_reason, attempts, created_at, updated_at, version
+		FROM checkout_sagas
+		WHERE id = ?`,
+		id.String(),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrCheckoutSagaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find checkout saga: %w", err)
+	}
+
+	return row.toCheckoutSaga()
+}
+
+func (r *CheckoutSagaRepository) FindUnfinished(updatedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	query, args, err := sqlx.In(`
+		SELECT id
+		FROM checkout_sagas
+		WHERE state IN (?) AND updated_at < ?
+		ORDER BY updated_at
+		LIMIT ?`,
+		checkoutSagaStates(model.UnfinishedCheckoutSagaStates),
+		updatedBefore,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build unfinished checkout sagas query: %w", err)
+	}
+
+	var rows []string
+	err = r.client.SelectContext(r.ctx, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unfinished checkout sagas: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i], err = uuid.Parse(row)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkout saga ID: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+func checkoutSagaStates(states []model.CheckoutSagaState) []int {
+	result := make([]int, len(states))
+	for i, state := range states {
+		result[i] = int(state)
+	}
+	return result
+}
+
+type checkoutSagaRow struct {
+	ID            string         `db:"id"`
+	OrderID       string         `db:"order_id"`
+	CustomerID    string         `db:"customer_id"`
+	Amount        string         `db:"amount"`
+	Currency      string         `db:"currency"`
+	State         int            `db:"state"`
+	PaymentID     sql.NullString `db:"payment_id"`
+	FailureReason sql.NullString `db:"failure_reason"`
+	Attempts      int            `db:"attempts"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+	Version       int            `db:"version"`
+}
+
+func (row *checkoutSagaRow) toCheckoutSaga() (*model.CheckoutSaga, error) {
+	sagaID, err := uuid.Parse(row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkout saga ID: %w", err)
+	}
+
+	orderID, err := uuid.Parse(row.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	customerID, err := uuid.Parse(row.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	amount, err := model.ParseMoney(row.Amount, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkout amount: %w", err)
+	}
+
+	saga := &model.CheckoutSaga{
+		ID:            sagaID,
+		OrderID:       orderID,
+		CustomerID:    customerID,
+		Amount:        amount,
+		State:         model.CheckoutSagaState(row.State),
+		FailureReason: row.FailureReason.String,
+		Attempts:      row.Attempts,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+		Version:       row.Version,
+	}
+
+	if row.PaymentID.Valid {
+		saga.PaymentID, err = uuid.Parse(row.PaymentID.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment ID: %w", err)
+		}
+	}
+
+	return saga, nil
+}
@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"order/pkg/domain/model"
+)
+
+// deliveryColumns - представление model.Delivery в колонках shipping_* и delivery_address таблицы orders.
+// Встраивается в OrderRow, sqlx разворачивает его поля
+type deliveryColumns struct {
+	ShippingMethod   sql.NullInt64  `db:"shipping_method"`
+	ShippingCost     sql.NullString `db:"shipping_cost"`
+	ShippingCurrency sql.NullString `db:"shipping_currency"`
+	DeliveryAddress  sql.NullString `db:"delivery_address"`
+}
+
+// deliveryAddressJSON - формат адреса в колонке delivery_address
+type deliveryAddressJSON struct {
+	Recipient  string `json:"recipient"`
+	Phone      string `json:"phone"`
+	Country    string `json:"country"`
+	City       string `json:"city"`
+	Street     string `json:"street"`
+	Building   string `json:"building"`
+	Apartment  string `json:"apartment,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+func toDeliveryColumns(delivery *model.Delivery) (deliveryColumns, error) {
+	if delivery == nil {
+		return deliveryColumns{}, nil
+	}
+
+	address, err := json.Marshal(deliveryAddressJSON(delivery.Address))
+	if err != nil {
+		return deliveryColumns{}, fmt.Errorf("failed to serialize delivery address: %w", err)
+	}
+	return deliveryColumns{
+		ShippingMethod:   sql.NullInt64{Int64: int64(delivery.Method), Valid: true},
+		ShippingCost:     sql.NullString{String: delivery.Cost.Decimal(), Valid: true},
+		ShippingCurrency: sql.NullString{String: delivery.Cost.Currency, Valid: true},
+		DeliveryAddress:  sql.NullString{String: string(address), Valid: true},
+	}, nil
+}
+
+func (c deliveryColumns) toDelivery() (*model.Delivery, error) {
+	if !c.ShippingMethod.Valid {
+		return nil, nil
+	}
+
+	cost, err := model.ParseMoney(c.ShippingCost.String, c.ShippingCurrency.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipping cost: %w", err)
+	}
+	var address deliveryAddressJSON
+	if err = json.Unmarshal([]byte(c.DeliveryAddress.String), &address); err != nil {
+		return nil, fmt.Errorf("invalid delivery address: %w", err)
+	}
+	return &model.Delivery{
+		Address: model.DeliveryAddress(address),
+		Method:  model.ShippingMethod(c.ShippingMethod.Int64),
+		Cost:    cost,
+	}, nil
+}
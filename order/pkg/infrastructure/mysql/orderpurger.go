@@ -0,0 +1,49 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// OrderPurger окончательно удаляет помеченные удалёнными заказы.
+// Позиции и история статусов удаляются каскадно по внешним ключам
+type OrderPurger struct {
+	db        *sqlx.DB
+	batchSize int
+}
+
+func NewOrderPurger(db *sqlx.DB, batchSize int) *OrderPurger {
+	return &OrderPurger{
+		db:        db,
+		batchSize: batchSize,
+	}
+}
+
+// Purge удаляет заказы, помеченные удалёнными раньше deletedBefore, и возвращает их количество.
+// Удаление идёт пачками, чтобы не держать долгие блокировки на таблице
+func (p *OrderPurger) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var total int64
+	for {
+		result, err := p.db.ExecContext(ctx,
+			"DELETE FROM orders WHERE deleted_at IS NOT NULL AND deleted_at < ? LIMIT ?",
+			deletedBefore,
+			p.batchSize,
+		)
+		if err != nil {
+			return total, fmt.Errorf("failed to purge orders: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		total += affected
+
+		if affected < int64(p.batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+)
+
+func NewOrderQueryService(db *sqlx.DB) query.OrderQueryService {
+	return &orderQueryService{db: db}
+}
+
+type orderQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *orderQueryService) FindOrder(ctx context.Context, orderID uuid.UUID) (*query.Order, error) {
+	orderQuery := `
+		SELECT id, customer_id, status, promo_code, discount_percent, discount_amount, discount_currency,
+			shipping_method, shipping_cost, shipping_currency, delivery_address,
+			net_total, tax_total, gross_total, tax_currency, tax_lines, created_at, updated_at, deleted_at
+		FROM orders
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	var orderRow OrderRow
+	err := s.db.GetContext(ctx, &orderRow, orderQuery, orderID.String())
+	if err == sql.ErrNoRows {
+		return nil, model.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	items, err := s.findItems(ctx, []string{orderRow.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := toQueryOrder(&orderRow)
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items[orderRow.ID]
+	return &order, nil
+}
+
+func (s *orderQueryService) ListOrders(ctx context.Context, spec query.ListOrdersSpec) (*query.OrderPage, error) {
+	pageSize := spec.PageSize
+	if pageSize <= 0 {
+		pageSize = query.DefaultOrdersPageSize
+	}
+	pageSize = min(pageSize, query.MaxOrdersPageSize)
+
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+	if spec.CustomerID != nil {
+		conditions = append(conditions, "customer_id = ?")
+		args = append(args, spec.CustomerID.String())
+	}
+	if len(spec.Statuses) > 0 {
+		statuses := make([]int, 0, len(spec.Statuses))
+		for _, status := range spec.Statuses {
+			statuses = append(statuses, int(status))
+		}
+		conditions = append(conditions, "status IN (?)")
+		args = append(args, statuses)
+	}
+	if spec.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *spec.CreatedFrom)
+	}
+	if spec.CreatedTo != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *spec.CreatedTo)
+	}
+	if spec.After != nil {
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, spec.After.CreatedAt, spec.After.CreatedAt, spec.After.ID.String())
+	}
+
+	// Берём на одну запись больше, чтобы понять, есть ли следующая страница
+	ordersQuery := `
+		SELECT id, customer_id, status, promo_code, discount_percent, discount_amount, discount_currency,
+			shipping_method, shipping_cost, shipping_currency, delivery_address,
+			net_total, tax_total, gross_total, tax_currency, tax_lines, created_at, updated_at, deleted_at
+		FROM orders
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+	args = append(args, pageSize+1)
+
+	ordersQuery, args, err := sqlx.In(ordersQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build orders query: %w", err)
+	}
+
+	var orderRows []OrderRow
+	err = s.db.SelectContext(ctx, &orderRows, s.db.Rebind(ordersQuery), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	page := &query.OrderPage{}
+	if len(orderRows) > pageSize {
+		orderRows = orderRows[:pageSize]
+		last := orderRows[len(orderRows)-1]
+		lastID, err := uuid.Parse(last.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid order ID: %w", err)
+		}
+		page.NextCursor = &query.OrderCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        lastID,
+		}
+	}
+	if len(orderRows) == 0 {
+		return page, nil
+	}
+
+	orderIDs := make([]string, 0, len(orderRows))
+	for _, orderRow := range orderRows {
+		orderIDs = append(orderIDs, orderRow.ID)
+	}
+	items, err := s.findItems(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Orders = make([]query.Order, 0, len(orderRows))
+	for i := range orderRows {
+		order, err := toQueryOrder(&orderRows[i])
+		if err != nil {
+			return nil, err
+		}
+		order.Items = items[orderRows[i].ID]
+		page.Orders = append(page.Orders, order)
+	}
+
+	return page, nil
+}
+
+type orderItemRow struct {
+	OrderID string `db:"order_id"`
+	ItemRow
+}
+
+// findItems загружает позиции сразу всех заказов одним запросом и группирует их по ID заказа
+func (s *orderQueryService) findItems(ctx context.Context, orderIDs []string) (map[string][]query.Item, error) {
+	itemsQuery, args, err := sqlx.In(`
+		SELECT order_id, id, product_id, product_name, category, price, currency, quantity
+		FROM order_items
+		WHERE order_id IN (?)
+	`, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build order items query: %w", err)
+	}
+
+	var itemRows []orderItemRow
+	err = s.db.SelectContext(ctx, &itemRows, s.db.Rebind(itemsQuery), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order items: %w", err)
+	}
+
+	items := make(map[string][]query.Item, len(orderIDs))
+	for _, orderID := range orderIDs {
+		items[orderID] = []query.Item{}
+	}
+	for _, itemRow := range itemRows {
+		itemID, err := uuid.Parse(itemRow.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid item ID: %w", err)
+		}
+		productID, err := uuid.Parse(itemRow.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid product ID: %w", err)
+		}
+		price, err := model.ParseMoney(itemRow.Price, itemRow.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid item price: %w", err)
+		}
+
+		items[itemRow.OrderID] = append(items[itemRow.OrderID], query.Item{
+			ID:        itemID,
+			ProductID: productID,
+			Name:      itemRow.ProductName,
+			Category:  itemRow.Category,
+			Price:     price,
+			Quantity:  itemRow.Quantity,
+		})
+	}
+
+	return items, nil
+}
+
+func toQueryOrder(row *OrderRow) (query.Order, error) {
+	orderID, err := uuid.Parse(row.ID)
+	if err != nil {
+		return query.Order{}, fmt.Errorf("invalid order ID: %w", err)
+	}
+	customerID, err := uuid.Parse(row.CustomerID)
+	if err != nil {
+		return query.Order{}, fmt.Errorf("invalid customer ID: %w", err)
+	}
+	promoCode, err := row.appliedPromoCode()
+	if err != nil {
+		return query.Order{}, err
+	}
+	delivery, err := row.toDelivery()
+	if err != nil {
+		return query.Order{}, err
+	}
+	tax, err := row.toOrderTax()
+	if err != nil {
+		return query.Order{}, err
+	}
+
+	return query.Order{
+		ID:         orderID,
+		CustomerID: customerID,
+		Status:     model.OrderStatus(row.Status),
+		PromoCode:  promoCode,
+		Delivery:   delivery,
+		Tax:        tax,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}, nil
+}
+
+type StatusChangeRow struct {
+	OldStatus int       `db:"old_status"`
+	NewStatus int       `db:"new_status"`
+	ChangedBy string    `db:"changed_by"`
+	Reason    string    `db:"reason"`
+	ChangedAt time.Time `db:"changed_at"`
+}
+
+func (s *orderQueryService) FindStatusHistory(ctx context.Context, orderID uuid.UUID) ([]query.StatusChange, error) {
+	var exists bool
+	err := s.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM orders WHERE id = ? AND deleted_at IS NULL)", orderID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check order exists: %w", err)
+	}
+	if !exists {
+		return nil, model.ErrOrderNotFound
+	}
+
+	historyQuery := `
+		SELECT old_status, new_status, changed_by, reason, changed_at
+		FROM order_status_history
+		WHERE order_id = ?
+		ORDER BY changed_at, id
+	`
+
+	var rows []StatusChangeRow
+	err = s.db.SelectContext(ctx, &rows, historyQuery, orderID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order status history: %w", err)
+	}
+
+	history := make([]query.StatusChange, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, query.StatusChange{
+			OldStatus: model.OrderStatus(row.OldStatus),
+			NewStatus: model.OrderStatus(row.NewStatus),
+			ChangedBy: row.ChangedBy,
+			Reason:    row.Reason,
+			ChangedAt: row.ChangedAt,
+		})
+	}
+
+	return history, nil
+}
@@ -1,212 +1,373 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
-	"order/pkg/domain/model"
-
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+	"order/pkg/domain/model"
 )
 
 type OrderRepository struct {
-	db *sqlx.DB
+	ctx    context.Context
+	client ClientContext
 }
 
-func NewOrderRepository(db *sqlx.DB) *OrderRepository {
-	return &OrderRepository{db: db}
+func NewOrderRepository(ctx context.Context, client ClientContext) *OrderRepository {
+	return &OrderRepository{
+		ctx:    ctx,
+		client: client,
+	}
 }
 
 func (r *OrderRepository) NextID() (uuid.UUID, error) {
 	return uuid.NewUUID()
 }
 
+// Store сохраняет заказ, проверяя, что с момента чтения его версия не изменилась.
+// При расхождении версий возвращает model.ErrVersionConflict
 func (r *OrderRepository) Store(order *model.Order) error {
-	// Сохраняем заказ
-	query := `
-		INSERT INTO orders (id, customer_id, status, created_at, updated_at, deleted_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			customer_id = VALUES(customer_id),
-			status = VALUES(status),
-			updated_at = VALUES(updated_at),
-			deleted_at = VALUES(deleted_at)
-	`
-
 	deletedAt := (*time.Time)(nil)
 	if order.DeletedAt != nil {
 		deletedAt = order.DeletedAt
 	}
 
-	_, err := r.db.Exec(query,
-		order.ID.String(),
-		order.CustomerID.String(),
-		int(order.Status),
-		order.CreatedAt,
-		order.UpdatedAt,
-		deletedAt,
-	)
+	promoCode, discount := toPromoCodeColumns(order.PromoCode)
+	delivery, err := toDeliveryColumns(order.Delivery)
 	if err != nil {
-		return fmt.Errorf("failed to store order: %w", err)
+		return err
 	}
-
-	// Удаляем старые элементы заказа
-	_, err = r.db.Exec("DELETE FROM order_items WHERE order_id = ?", order.ID.String())
+	tax, err := toTaxColumns(order.Tax)
 	if err != nil {
-		return fmt.Errorf("failed to delete old order items: %w", err)
+		return err
 	}
 
-	// Сохраняем элементы заказа
-	for _, item := range order.Items {
-		_, err = r.db.Exec(`
-			INSERT INTO order_items (id, order_id, product_id, price)
-			VALUES (?, ?, ?, ?)
-		`, item.ID.String(), order.ID.String(), item.ProductID.String(), item.Price)
+	isNew := order.Version == 0
+	if isNew {
+		_, err = r.client.ExecContext(r.ctx, `
+			INSERT INTO orders (id, customer_id, status, promo_code, discount_percent, discount_amount, discount_currency,
+				shipping_method, shipping_cost, shipping_currency, delivery_address,
+				net_total, tax_total, gross_total, tax_currency, tax_lines, created_at, updated_at, deleted_at, pending_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			order.ID.String(),
+			order.CustomerID.String(),
+			int(order.Status),
+			promoCode,
+			discount.Percent,
+			discount.Amount,
+			discount.Currency,
+			delivery.ShippingMethod,
+			delivery.ShippingCost,
+			delivery.ShippingCurrency,
+			delivery.DeliveryAddress,
+			tax.NetTotal,
+			tax.TaxTotal,
+			tax.GrossTotal,
+			tax.TaxCurrency,
+			tax.TaxLines,
+			order.CreatedAt,
+			order.UpdatedAt,
+			deletedAt,
+			order.PendingAt,
+		)
 		if err != nil {
-			return fmt.Errorf("failed to store order item: %w", err)
+			return fmt.Errorf("failed to store order: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE orders
+			SET customer_id = ?, status = ?, promo_code = ?, discount_percent = ?, discount_amount = ?, discount_currency = ?,
+				shipping_method = ?, shipping_cost = ?, shipping_currency = ?, delivery_address = ?,
+				net_total = ?, tax_total = ?, gross_total = ?, tax_currency = ?, tax_lines = ?,
+				updated_at = ?, deleted_at = ?, pending_at = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			order.CustomerID.String(),
+			int(order.Status),
+			promoCode,
+			discount.Percent,
+			discount.Amount,
+			discount.Currency,
+			delivery.ShippingMethod,
+			delivery.ShippingCost,
+			delivery.ShippingCurrency,
+			delivery.DeliveryAddress,
+			tax.NetTotal,
+			tax.TaxTotal,
+			tax.GrossTotal,
+			tax.TaxCurrency,
+			tax.TaxLines,
+			order.UpdatedAt,
+			deletedAt,
+			order.PendingAt,
+			order.ID.String(),
+			order.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store order: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
 		}
 	}
+	order.Version++
 
-	return nil
+	return r.storeItems(order, isNew)
 }
 
-func (r *OrderRepository) Find(id uuid.UUID) (*model.Order, error) {
-	// Получаем заказ
-	orderQuery := `
-		SELECT id, customer_id, status, created_at, updated_at, deleted_at
-		FROM orders
-		WHERE id = ?
-	`
-
-	var order model.Order
-	var deletedAt sql.NullTime
-
-	err := r.db.Get(&order, orderQuery, id.String())
-	if err == sql.ErrNoRows {
-		return nil, model.ErrOrderNotFound
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to find order: %w", err)
+// orderItemsInsertBatchSize ограничивает число строк в одном INSERT,
+// чтобы не упереться в лимит плейсхолдеров MySQL (65535)
+const orderItemsInsertBatchSize = 1000
+
+// storeItems сравнивает позиции заказа с сохранёнными и пишет только разницу:
+// новые позиции вставляются пачкой, удалённые удаляются одним запросом, изменённые обновляются построчно
+func (r *OrderRepository) storeItems(order *model.Order, isNew bool) error {
+	persisted := map[uuid.UUID]model.Item{}
+	if !isNew {
+		var err error
+		persisted, err = r.findPersistedItems(order.ID)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Получаем элементы заказа
-	itemsQuery := `
-		SELECT id, product_id, price
-		FROM order_items
-		WHERE order_id = ?
-	`
+	var added []model.Item
+	for _, item := range order.Items {
+		persistedItem, found := persisted[item.ID]
+		if !found {
+			added = append(added, item)
+			continue
+		}
+		delete(persisted, item.ID)
 
-	var items []ItemRow
-	err = r.db.Select(&items, itemsQuery, id.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to find order items: %w", err)
+		if persistedItem == item {
+			continue
+		}
+		_, err := r.client.ExecContext(r.ctx, `
+			UPDATE order_items
+			SET product_id = ?, product_name = ?, category = ?, price = ?, currency = ?, quantity = ?
+			WHERE id = ? AND order_id = ?`,
+			item.ProductID.String(),
+			item.Name,
+			item.Category,
+			item.Price.Decimal(),
+			item.Price.Currency,
+			item.Quantity,
+			item.ID.String(),
+			order.ID.String(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update order item: %w", err)
+		}
 	}
 
-	// Конвертируем элементы
-	order.Items = make([]model.Item, len(items))
-	for i, item := range items {
-		order.Items[i] = model.Item{
-			ID:        uuid.Must(uuid.Parse(item.ProductID)),
-			ProductID: uuid.Must(uuid.Parse(item.ProductID)),
-			Price:     item.Price,
+	// В persisted остались позиции, которых больше нет в заказе
+	if len(persisted) > 0 {
+		removedIDs := make([]string, 0, len(persisted))
+		for itemID := range persisted {
+			removedIDs = append(removedIDs, itemID.String())
+		}
+		deleteQuery, args, err := sqlx.In("DELETE FROM order_items WHERE order_id = ? AND id IN (?)", order.ID.String(), removedIDs)
+		if err != nil {
+			return fmt.Errorf("failed to build order items delete query: %w", err)
+		}
+		_, err = r.client.ExecContext(r.ctx, deleteQuery, args...)
+		if err != nil {
+			return fmt.Errorf("failed to delete order items: %w", err)
 		}
-		order.Items[i].ID = uuid.Must(uuid.Parse(item.ID))
 	}
 
-	if deletedAt.Valid {
-		deletedTime := deletedAt.Time
-		order.DeletedAt = &deletedTime
+	for start := 0; start < len(added); start += orderItemsInsertBatchSize {
+		batch := added[start:min(start+orderItemsInsertBatchSize, len(added))]
+		err := r.insertItems(order.ID, batch)
+		if err != nil {
+			return err
+		}
 	}
 
-	return &order, nil
+	return nil
 }
 
-func (r *OrderRepository) Delete(id uuid.UUID) error {
-	// Удаляем элементы заказа
-	_, err := r.db.Exec("DELETE FROM order_items WHERE order_id = ?", id.String())
-	if err != nil {
-		return fmt.Errorf("failed to delete order items: %w", err)
+func (r *OrderRepository) insertItems(orderID uuid.UUID, items []model.Item) error {
+	placeholders := make([]string, 0, len(items))
+	args := make([]interface{}, 0, len(items)*8)
+	for _, item := range items {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			item.ID.String(),
+			orderID.String(),
+			item.ProductID.String(),
+			item.Name,
+			item.Category,
+			item.Price.Decimal(),
+			item.Price.Currency,
+			item.Quantity,
+		)
 	}
 
-	// Удаляем заказ
-	_, err = r.db.Exec("DELETE FROM orders WHERE id = ?", id.String())
+	query := `
+		INSERT INTO order_items (id, order_id, product_id, product_name, category, price, currency, quantity)
+		VALUES ` + strings.Join(placeholders, ", ")
+	_, err := r.client.ExecContext(r.ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to delete order: %w", err)
+		return fmt.Errorf("failed to store order items: %w", err)
 	}
 
 	return nil
 }
 
-// Helper structs для работы с БД
-type ItemRow struct {
-	ID        string  `db:"id"`
-	ProductID string  `db:"product_id"`
-	Price     float64 `db:"price"`
+func (r *OrderRepository) findPersistedItems(orderID uuid.UUID) (map[uuid.UUID]model.Item, error) {
+	items, err := r.findItems(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	persisted := make(map[uuid.UUID]model.Item, len(items))
+	for _, item := range items {
+		persisted[item.ID] = item
+	}
+	return persisted, nil
 }
 
-// FindByCustomerID получает заказы по ID клиента
-func (r *OrderRepository) FindByCustomerID(customerID uuid.UUID) ([]*model.Order, error) {
+func (r *OrderRepository) Find(id uuid.UUID) (*model.Order, error) {
 	query := `
-		SELECT id, customer_id, status, created_at, updated_at, deleted_at
+		SELECT id, customer_id, status, promo_code, discount_percent, discount_amount, discount_currency,
+			shipping_method, shipping_cost, shipping_currency, delivery_address,
+			net_total, tax_total, gross_total, tax_currency, tax_lines, created_at, updated_at, deleted_at, pending_at, version
 		FROM orders
-		WHERE customer_id = ?
-		ORDER BY created_at DESC
+		WHERE id = ? AND deleted_at IS NULL
 	`
 
-	var orders []OrderRow
-	err := r.db.Select(&orders, query, customerID.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to find orders by customer: %w", err)
+	var order OrderRow
+	err := r.client.GetContext(r.ctx, &order, query, id.String())
+	if err == sql.ErrNoRows {
+		return nil, model.ErrOrderNotFound
 	}
-
-	result := make([]*model.Order, len(orders))
-	for i, orderRow := range orders {
-		order, err := r.rowToOrder(&orderRow)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert order row: %w", err)
-		}
-		result[i] = order
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order: %w", err)
 	}
 
-	return result, nil
+	return r.rowToOrder(&order)
 }
 
-// FindByStatus получает заказы по статусу
-func (r *OrderRepository) FindByStatus(status model.OrderStatus) ([]*model.Order, error) {
+func (r *OrderRepository) FindPendingBefore(pendingBefore time.Time, limit int) ([]uuid.UUID, error) {
 	query := `
-		SELECT id, customer_id, status, created_at, updated_at, deleted_at
+		SELECT id
 		FROM orders
-		WHERE status = ?
-		ORDER BY created_at DESC
+		WHERE status = ? AND pending_at < ? AND deleted_at IS NULL
+		ORDER BY pending_at
+		LIMIT ?
 	`
 
-	var orders []OrderRow
-	err := r.db.Select(&orders, query, int(status))
+	var rows []string
+	err := r.client.SelectContext(r.ctx, &rows, query, int(model.Pending), pendingBefore, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find orders by status: %w", err)
+		return nil, fmt.Errorf("failed to find pending orders: %w", err)
 	}
 
-	result := make([]*model.Order, len(orders))
-	for i, orderRow := range orders {
-		order, err := r.rowToOrder(&orderRow)
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i], err = uuid.Parse(row)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert order row: %w", err)
+			return nil, fmt.Errorf("invalid order ID: %w", err)
 		}
-		result[i] = order
 	}
+	return ids, nil
+}
+
+func (r *OrderRepository) Delete(id uuid.UUID) error {
+	result, err := r.client.ExecContext(r.ctx,
+		"UPDATE orders SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL",
+		time.Now(),
+		id.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete order: %w", err)
+	}
+
+	return checkOrderAffected(result)
+}
 
-	return result, nil
+func (r *OrderRepository) Restore(id uuid.UUID) error {
+	result, err := r.client.ExecContext(r.ctx,
+		"UPDATE orders SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL",
+		id.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore order: %w", err)
+	}
+
+	return checkOrderAffected(result)
+}
+
+func checkOrderAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return model.ErrOrderNotFound
+	}
+	return nil
+}
+
+// Helper structs для работы с БД
+type ItemRow struct {
+	ID          string `db:"id"`
+	ProductID   string `db:"product_id"`
+	ProductName string `db:"product_name"`
+	Category    string `db:"category"`
+	Price       string `db:"price"`
+	Currency    string `db:"currency"`
+	Quantity    int    `db:"quantity"`
 }
 
 type OrderRow struct {
-	ID         string       `db:"id"`
-	CustomerID string       `db:"customer_id"`
-	Status     int          `db:"status"`
-	CreatedAt  time.Time    `db:"created_at"`
-	UpdatedAt  time.Time    `db:"updated_at"`
-	DeletedAt  sql.NullTime `db:"deleted_at"`
+	ID               string         `db:"id"`
+	CustomerID       string         `db:"customer_id"`
+	Status           int            `db:"status"`
+	PromoCode        sql.NullString `db:"promo_code"`
+	DiscountPercent  sql.NullInt64  `db:"discount_percent"`
+	DiscountAmount   sql.NullString `db:"discount_amount"`
+	DiscountCurrency sql.NullString `db:"discount_currency"`
+	deliveryColumns
+	taxColumns
+	CreatedAt time.Time    `db:"created_at"`
+	UpdatedAt time.Time    `db:"updated_at"`
+	DeletedAt sql.NullTime `db:"deleted_at"`
+	PendingAt sql.NullTime `db:"pending_at"`
+	Version   int          `db:"version"`
+}
+
+func (row *OrderRow) appliedPromoCode() (*model.AppliedPromoCode, error) {
+	if !row.PromoCode.Valid {
+		return nil, nil
+	}
+
+	discount, err := fromDiscountColumns(discountColumns{
+		Percent:  row.DiscountPercent,
+		Amount:   row.DiscountAmount,
+		Currency: row.DiscountCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.AppliedPromoCode{
+		Code:     row.PromoCode.String,
+		Discount: discount,
+	}, nil
+}
+
+func toPromoCodeColumns(promoCode *model.AppliedPromoCode) (sql.NullString, discountColumns) {
+	if promoCode == nil {
+		return sql.NullString{}, discountColumns{}
+	}
+	return sql.NullString{String: promoCode.Code, Valid: true}, toDiscountColumns(&promoCode.Discount)
 }
 
 func (r *OrderRepository) rowToOrder(row *OrderRow) (*model.Order, error) {
@@ -224,47 +385,82 @@ func (r *OrderRepository) rowToOrder(row *OrderRow) (*model.Order, error) {
 		ID:         orderID,
 		CustomerID: customerID,
 		Status:     model.OrderStatus(row.Status),
-		Items:      []model.Item{},
 		CreatedAt:  row.CreatedAt,
 		UpdatedAt:  row.UpdatedAt,
+		Version:    row.Version,
 	}
 
 	if row.DeletedAt.Valid {
 		deletedAt := row.DeletedAt.Time
 		order.DeletedAt = &deletedAt
 	}
+	if row.PendingAt.Valid {
+		pendingAt := row.PendingAt.Time
+		order.PendingAt = &pendingAt
+	}
 
-	// Получаем элементы заказа
+	order.PromoCode, err = row.appliedPromoCode()
+	if err != nil {
+		return nil, err
+	}
+
+	order.Delivery, err = row.toDelivery()
+	if err != nil {
+		return nil, err
+	}
+
+	order.Tax, err = row.toOrderTax()
+	if err != nil {
+		return nil, err
+	}
+
+	order.Items, err = r.findItems(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *OrderRepository) findItems(orderID uuid.UUID) ([]model.Item, error) {
 	itemsQuery := `
-		SELECT id, product_id, price
+		SELECT id, product_id, product_name, category, price, currency, quantity
 		FROM order_items
 		WHERE order_id = ?
 	`
 
-	var items []ItemRow
-	err = r.db.Select(&items, itemsQuery, row.ID)
+	var rows []ItemRow
+	err := r.client.SelectContext(r.ctx, &rows, itemsQuery, orderID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find order items: %w", err)
 	}
 
-	order.Items = make([]model.Item, len(items))
-	for i, item := range items {
-		itemID, err := uuid.Parse(item.ID)
+	items := make([]model.Item, len(rows))
+	for i, row := range rows {
+		itemID, err := uuid.Parse(row.ID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid item ID: %w", err)
 		}
 
-		productID, err := uuid.Parse(item.ProductID)
+		productID, err := uuid.Parse(row.ProductID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid product ID: %w", err)
 		}
 
-		order.Items[i] = model.Item{
+		price, err := model.ParseMoney(row.Price, row.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid item price: %w", err)
+		}
+
+		items[i] = model.Item{
 			ID:        itemID,
 			ProductID: productID,
-			Price:     item.Price,
+			Name:      row.ProductName,
+			Category:  row.Category,
+			Price:     price,
+			Quantity:  row.Quantity,
 		}
 	}
 
-	return order, nil
+	return items, nil
 }
@@ -0,0 +1,39 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"order/pkg/domain/model"
+)
+
+type OrderStatusHistoryRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewOrderStatusHistoryRepository(ctx context.Context, client ClientContext) *OrderStatusHistoryRepository {
+	return &OrderStatusHistoryRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func (r *OrderStatusHistoryRepository) Append(change *model.StatusChange) error {
+	_, err := r.client.ExecContext(r.ctx, `
+		INSERT INTO order_status_history (order_id, old_status, new_status, changed_by, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`,
+		change.OrderID.String(),
+		int(change.OldStatus),
+		int(change.NewStatus),
+		change.ChangedBy,
+		change.Reason,
+		change.ChangedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store order status change: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/service"
+)
+
+func NewPromoCodeQueryService(db *sqlx.DB) query.PromoCodeQueryService {
+	return &promoCodeQueryService{db: db}
+}
+
+type promoCodeQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *promoCodeQueryService) FindPromoCode(ctx context.Context, code string) (*query.PromoCode, error) {
+	repo := NewPromoCodeRepository(ctx, s.db)
+
+	promoCode, err := repo.Find(service.NormalizePromoCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	uses, err := repo.CountUses(promoCode.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.PromoCode{
+		Code:               promoCode.Code,
+		Discount:           promoCode.Discount,
+		ValidFrom:          promoCode.ValidFrom,
+		ValidTo:            promoCode.ValidTo,
+		MaxUses:            promoCode.MaxUses,
+		MaxUsesPerCustomer: promoCode.MaxUsesPerCustomer,
+		Uses:               uses,
+		CreatedAt:          promoCode.CreatedAt,
+	}, nil
+}
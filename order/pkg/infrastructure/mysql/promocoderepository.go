@@ -0,0 +1,198 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	commonmysql "common/mysql"
+	"order/pkg/domain/model"
+)
+
+type PromoCodeRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewPromoCodeRepository(ctx context.Context, client ClientContext) *PromoCodeRepository {
+	return &PromoCodeRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+// Store сохраняет промокод, проверяя, что с момента чтения его версия не изменилась.
+// При расхождении версий возвращает model.ErrVersionConflict
+func (r *PromoCodeRepository) Store(promoCode *model.PromoCode) error {
+	discount := toDiscountColumns(&promoCode.Discount)
+
+	if promoCode.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO promo_codes (code, discount_percent, discount_amount, discount_currency, valid_from, valid_to, max_uses, max_uses_per_customer, created_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			promoCode.Code,
+			discount.Percent,
+			discount.Amount,
+			discount.Currency,
+			promoCode.ValidFrom,
+			promoCode.ValidTo,
+			promoCode.MaxUses,
+			promoCode.MaxUsesPerCustomer,
+			promoCode.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store promo code: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE promo_codes
+			SET discount_percent = ?, discount_amount = ?, discount_currency = ?, valid_from = ?, valid_to = ?,
+				max_uses = ?, max_uses_per_customer = ?, version = version + 1
+			WHERE code = ? AND version = ?`,
+			discount.Percent,
+			discount.Amount,
+			discount.Currency,
+			promoCode.ValidFrom,
+			promoCode.ValidTo,
+			promoCode.MaxUses,
+			promoCode.MaxUsesPerCustomer,
+			promoCode.Code,
+			promoCode.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store promo code: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
+	}
+	promoCode.Version++
+
+	return nil
+}
+
+func (r *PromoCodeRepository) Find(code string) (*model.PromoCode, error) {
+	var row PromoCodeRow
+	err := r.client.GetContext(r.ctx, &row, `
+		SELECT code, discount_percent, discount_amount, discount_currency, valid_from, valid_to, max_uses, max_uses_per_customer, created_at, version
+		FROM promo_codes
+		WHERE code = ?`,
+		code,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrPromoCodeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promo code: %w", err)
+	}
+
+	return row.toPromoCode()
+}
+
+func (r *PromoCodeRepository) CountUses(code string) (int, error) {
+	var uses int
+	err := r.client.GetContext(r.ctx, &uses, `
+		SELECT COUNT(*)
+		FROM orders
+		WHERE promo_code = ? AND status IN (?, ?) AND deleted_at IS NULL`,
+		code,
+		int(model.Pending),
+		int(model.Paid),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count promo code uses: %w", err)
+	}
+	return uses, nil
+}
+
+func (r *PromoCodeRepository) CountCustomerUses(code string, customerID uuid.UUID) (int, error) {
+	var uses int
+	err := r.client.GetContext(r.ctx, &uses, `
+		SELECT COUNT(*)
+		FROM orders
+		WHERE promo_code = ? AND customer_id = ? AND status IN (?, ?) AND deleted_at IS NULL`,
+		code,
+		customerID.String(),
+		int(model.Pending),
+		int(model.Paid),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count promo code uses: %w", err)
+	}
+	return uses, nil
+}
+
+type PromoCodeRow struct {
+	Code               string         `db:"code"`
+	DiscountPercent    sql.NullInt64  `db:"discount_percent"`
+	DiscountAmount     sql.NullString `db:"discount_amount"`
+	DiscountCurrency   sql.NullString `db:"discount_currency"`
+	ValidFrom          sql.NullTime   `db:"valid_from"`
+	ValidTo            sql.NullTime   `db:"valid_to"`
+	MaxUses            int            `db:"max_uses"`
+	MaxUsesPerCustomer int            `db:"max_uses_per_customer"`
+	CreatedAt          time.Time      `db:"created_at"`
+	Version            int            `db:"version"`
+}
+
+func (row *PromoCodeRow) toPromoCode() (*model.PromoCode, error) {
+	discount, err := fromDiscountColumns(discountColumns{
+		Percent:  row.DiscountPercent,
+		Amount:   row.DiscountAmount,
+		Currency: row.DiscountCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	promoCode := &model.PromoCode{
+		Code:               row.Code,
+		Discount:           discount,
+		MaxUses:            row.MaxUses,
+		MaxUsesPerCustomer: row.MaxUsesPerCustomer,
+		CreatedAt:          row.CreatedAt,
+		Version:            row.Version,
+	}
+	if row.ValidFrom.Valid {
+		promoCode.ValidFrom = &row.ValidFrom.Time
+	}
+	if row.ValidTo.Valid {
+		promoCode.ValidTo = &row.ValidTo.Time
+	}
+	return promoCode, nil
+}
+
+// discountColumns - представление model.Discount в колонках discount_* таблиц promo_codes и orders
+type discountColumns struct {
+	Percent  sql.NullInt64
+	Amount   sql.NullString
+	Currency sql.NullString
+}
+
+func toDiscountColumns(discount *model.Discount) discountColumns {
+	if discount.Amount != nil {
+		return discountColumns{
+			Amount:   sql.NullString{String: discount.Amount.Decimal(), Valid: true},
+			Currency: sql.NullString{String: discount.Amount.Currency, Valid: true},
+		}
+	}
+	return discountColumns{
+		Percent: sql.NullInt64{Int64: int64(discount.Percent), Valid: true},
+	}
+}
+
+func fromDiscountColumns(columns discountColumns) (model.Discount, error) {
+	if !columns.Amount.Valid {
+		return model.Discount{Percent: int(columns.Percent.Int64)}, nil
+	}
+
+	amount, err := model.ParseMoney(columns.Amount.String, columns.Currency.String)
+	if err != nil {
+		return model.Discount{}, fmt.Errorf("invalid discount amount: %w", err)
+	}
+	return model.Discount{Amount: &amount}, nil
+}
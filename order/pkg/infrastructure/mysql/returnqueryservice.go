@@ -0,0 +1,132 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+)
+
+func NewReturnQueryService(db *sqlx.DB) query.ReturnQueryService {
+	return &returnQueryService{db: db}
+}
+
+type returnQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *returnQueryService) FindReturn(ctx context.Context, returnID uuid.UUID) (*query.Return, error) {
+	ret, err := NewReturnRepository(ctx, s.db).Find(returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toQueryReturn(ret)
+}
+
+func (s *returnQueryService) ListOrderReturns(ctx context.Context, orderID uuid.UUID) ([]query.Return, error) {
+	var exists bool
+	err := s.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM orders WHERE id = ? AND deleted_at IS NULL)", orderID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check order exists: %w", err)
+	}
+	if !exists {
+		return nil, model.ErrOrderNotFound
+	}
+
+	returns, err := NewReturnRepository(ctx, s.db).FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]query.Return, 0, len(returns))
+	for i := range returns {
+		ret, err := toQueryReturn(&returns[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *ret)
+	}
+	return result, nil
+}
+
+type returnStatusChangeRow struct {
+	OldStatus int       `db:"old_status"`
+	NewStatus int       `db:"new_status"`
+	ChangedBy string    `db:"changed_by"`
+	Reason    string    `db:"reason"`
+	ChangedAt time.Time `db:"changed_at"`
+}
+
+func (s *returnQueryService) FindReturnStatusHistory(ctx context.Context, returnID uuid.UUID) ([]query.ReturnStatusChange, error) {
+	var exists bool
+	err := s.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM returns WHERE id = ?)", returnID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check return exists: %w", err)
+	}
+	if !exists {
+		return nil, model.ErrReturnNotFound
+	}
+
+	historyQuery := `
+		SELECT old_status, new_status, changed_by, reason, changed_at
+		FROM return_status_history
+		WHERE return_id = ?
+		ORDER BY changed_at, id
+	`
+
+	var rows []returnStatusChangeRow
+	err = s.db.SelectContext(ctx, &rows, historyQuery, returnID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find return status history: %w", err)
+	}
+
+	history := make([]query.ReturnStatusChange, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, query.ReturnStatusChange{
+			OldStatus: model.ReturnStatus(row.OldStatus),
+			NewStatus: model.ReturnStatus(row.NewStatus),
+			ChangedBy: row.ChangedBy,
+			Reason:    row.Reason,
+			ChangedAt: row.ChangedAt,
+		})
+	}
+
+	return history, nil
+}
+
+func toQueryReturn(ret *model.Return) (*query.Return, error) {
+	refundAmount, err := ret.RefundAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]query.ReturnItem, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		items = append(items, query.ReturnItem{
+			ItemID:    item.ItemID,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Price:     item.Price,
+			Quantity:  item.Quantity,
+			Refund:    item.Refund,
+		})
+	}
+
+	return &query.Return{
+		ID:           ret.ID,
+		OrderID:      ret.OrderID,
+		CustomerID:   ret.CustomerID,
+		Status:       ret.Status,
+		Items:        items,
+		Reason:       ret.Reason,
+		RefundAmount: refundAmount,
+		CreatedAt:    ret.CreatedAt,
+		UpdatedAt:    ret.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+	"order/pkg/domain/model"
+)
+
+type ReturnRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewReturnRepository(ctx context.Context, client ClientContext) *ReturnRepository {
+	return &ReturnRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func (r *ReturnRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewUUID()
+}
+
+// Store сохраняет возврат, проверяя, что с момента чтения его версия не изменилась.
+// Позиции возврата не меняются и пишутся только при создании
+func (r *ReturnRepository) Store(ret *model.Return) error {
+	if ret.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO returns (id, order_id, customer_id, status, reason, created_at, updated_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, 1)`,
+			ret.ID.String(),
+			ret.OrderID.String(),
+			ret.CustomerID.String(),
+			int(ret.Status),
+			ret.Reason,
+			ret.CreatedAt,
+			ret.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store return: %w", err)
+		}
+		if err = r.insertItems(ret.ID, ret.Items); err != nil {
+			return err
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE returns
+			SET status = ?, reason = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			int(ret.Status),
+			ret.Reason,
+			ret.UpdatedAt,
+			ret.ID.String(),
+			ret.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store return: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
+	}
+	ret.Version++
+
+	return nil
+}
+
+func (r *ReturnRepository) insertItems(returnID uuid.UUID, items []model.ReturnItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(items))
+	args := make([]interface{}, 0, len(items)*8)
+	for _, item := range items {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			returnID.String(),
+			item.ItemID.String(),
+			item.ProductID.String(),
+			item.Name,
+			item.Price.Decimal(),
+			item.Quantity,
+			item.Refund.Decimal(),
+			item.Price.Currency,
+		)
+	}
+
+	query := `
+		INSERT INTO return_items (return_id, item_id, product_id, product_name, price, quantity, refund, currency)
+		VALUES ` + strings.Join(placeholders, ", ")
+	_, err := r.client.ExecContext(r.ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to store return items: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReturnRepository) Find(id uuid.UUID) (*model.Return, error) {
+	var row returnRow
+	err := r.client.GetContext(r.ctx, &row, `
+		SELECT id, order_id, customer_id, status, reason, created_at, updated_at, version
+		FROM returns
+		WHERE id = ?`,
+		id.String(),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrReturnNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find return: %w", err)
+	}
+
+	returns, err := r.withItems([]returnRow{row})
+	if err != nil {
+		return nil, err
+	}
+	return &returns[0], nil
+}
+
+func (r *ReturnRepository) FindByOrder(orderID uuid.UUID) ([]model.Return, error) {
+	var rows []returnRow
+	err := r.client.SelectContext(r.ctx, &rows, `
+		SELECT id, order_id, customer_id, status, reason, created_at, updated_at, version
+		FROM returns
+		WHERE order_id = ?
+		ORDER BY created_at, id`,
+		orderID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order returns: %w", err)
+	}
+
+	return r.withItems(rows)
+}
+
+// withItems загружает позиции всех возвратов одним запросом
+func (r *ReturnRepository) withItems(rows []returnRow) ([]model.Return, error) {
+	if len(rows) == 0 {
+		return []model.Return{}, nil
+	}
+
+	returnIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		returnIDs = append(returnIDs, row.ID)
+	}
+	itemsQuery, args, err := sqlx.In(`
+		SELECT return_id, item_id, product_id, product_name, price, quantity, refund, currency
+		FROM return_items
+		WHERE return_id IN (?)
+		ORDER BY return_id, item_id`,
+		returnIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build return items query: %w", err)
+	}
+
+	var itemRows []returnItemRow
+	err = r.client.SelectContext(r.ctx, &itemRows, itemsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find return items: %w", err)
+	}
+
+	items := make(map[string][]model.ReturnItem, len(rows))
+	for _, itemRow := range itemRows {
+		item, err := itemRow.toReturnItem()
+		if err != nil {
+			return nil, err
+		}
+		items[itemRow.ReturnID] = append(items[itemRow.ReturnID], item)
+	}
+
+	returns := make([]model.Return, 0, len(rows))
+	for _, row := range rows {
+		ret, err := row.toReturn(items[row.ID])
+		if err != nil {
+			return nil, err
+		}
+		returns = append(returns, *ret)
+	}
+	return returns, nil
+}
+
+type returnRow struct {
+	ID         string    `db:"id"`
+	OrderID    string    `db:"order_id"`
+	CustomerID string    `db:"customer_id"`
+	Status     int       `db:"status"`
+	Reason     string    `db:"reason"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	Version    int       `db:"version"`
+}
+
+func (row *returnRow) toReturn(items []model.ReturnItem) (*model.Return, error) {
+	returnID, err := uuid.Parse(row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid return ID: %w", err)
+	}
+
+	orderID, err := uuid.Parse(row.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	customerID, err := uuid.Parse(row.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	return &model.Return{
+		ID:         returnID,
+		OrderID:    orderID,
+		CustomerID: customerID,
+		Status:     model.ReturnStatus(row.Status),
+		Items:      items,
+		Reason:     row.Reason,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		Version:    row.Version,
+	}, nil
+}
+
+type returnItemRow struct {
+	ReturnID    string `db:"return_id"`
+	ItemID      string `db:"item_id"`
+	ProductID   string `db:"product_id"`
+	ProductName string `db:"product_name"`
+	Price       string `db:"price"`
+	Quantity    int    `db:"quantity"`
+	Refund      string `db:"refund"`
+	Currency    string `db:"currency"`
+}
+
+func (row *returnItemRow) toReturnItem() (model.ReturnItem, error) {
+	itemID, err := uuid.Parse(row.ItemID)
+	if err != nil {
+		return model.ReturnItem{}, fmt.Errorf("invalid item ID: %w", err)
+	}
+
+	productID, err := uuid.Parse(row.ProductID)
+	if err != nil {
+		return model.ReturnItem{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	price, err := model.ParseMoney(row.Price, row.Currency)
+	if err != nil {
+		return model.ReturnItem{}, fmt.Errorf("invalid return item price: %w", err)
+	}
+
+	refund, err := model.ParseMoney(row.Refund, row.Currency)
+	if err != nil {
+		return model.ReturnItem{}, fmt.Errorf("invalid return item refund: %w", err)
+	}
+
+	return model.ReturnItem{
+		ItemID:    itemID,
+		ProductID: productID,
+		Name:      row.ProductName,
+		Price:     price,
+		Quantity:  row.Quantity,
+		Refund:    refund,
+	}, nil
+}
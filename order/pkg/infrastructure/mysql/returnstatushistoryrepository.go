@@ -0,0 +1,39 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"order/pkg/domain/model"
+)
+
+type ReturnStatusHistoryRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewReturnStatusHistoryRepository(ctx context.Context, client ClientContext) *ReturnStatusHistoryRepository {
+	return &ReturnStatusHistoryRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func (r *ReturnStatusHistoryRepository) Append(change *model.ReturnStatusChange) error {
+	_, err := r.client.ExecContext(r.ctx, `
+		INSERT INTO return_status_history (return_id, old_status, new_status, changed_by, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`,
+		change.ReturnID.String(),
+		int(change.OldStatus),
+		int(change.NewStatus),
+		change.ChangedBy,
+		change.Reason,
+		change.ChangedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store return status change: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+)
+
+func NewShipmentQueryService(db *sqlx.DB) query.ShipmentQueryService {
+	return &shipmentQueryService{db: db}
+}
+
+type shipmentQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *shipmentQueryService) FindShipment(ctx context.Context, shipmentID uuid.UUID) (*query.Shipment, error) {
+	shipment, err := NewShipmentRepository(ctx, s.db).Find(shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toQueryShipment(shipment), nil
+}
+
+func (s *shipmentQueryService) ListOrderShipments(ctx context.Context, orderID uuid.UUID) ([]query.Shipment, error) {
+	var exists bool
+	err := s.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM orders WHERE id = ? AND deleted_at IS NULL)", orderID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check order exists: %w", err)
+	}
+	if !exists {
+		return nil, model.ErrOrderNotFound
+	}
+
+	shipments, err := NewShipmentRepository(ctx, s.db).FindByOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]query.Shipment, 0, len(shipments))
+	for i := range shipments {
+		result = append(result, *toQueryShipment(&shipments[i]))
+	}
+	return result, nil
+}
+
+func toQueryShipment(shipment *model.Shipment) *query.Shipment {
+	items := make([]query.ShipmentItem, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		items = append(items, query.ShipmentItem{
+			ItemID:    item.ItemID,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Price:     item.Price,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return &query.Shipment{
+		ID:             shipment.ID,
+		OrderID:        shipment.OrderID,
+		CustomerID:     shipment.CustomerID,
+		Status:         shipment.Status,
+		Items:          items,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		CreatedAt:      shipment.CreatedAt,
+		UpdatedAt:      shipment.UpdatedAt,
+		ShippedAt:      shipment.ShippedAt,
+		DeliveredAt:    shipment.DeliveredAt,
+	}
+}
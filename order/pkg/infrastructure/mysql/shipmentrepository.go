@@ -0,0 +1,288 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+	"order/pkg/domain/model"
+)
+
+type ShipmentRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewShipmentRepository(ctx context.Context, client ClientContext) *ShipmentRepository {
+	return &ShipmentRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func (r *ShipmentRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewUUID()
+}
+
+// Store сохраняет отправление, проверяя, что с момента чтения его версия не изменилась.
+// Позиции отправления не меняются и пишутся только при создании
+func (r *ShipmentRepository) Store(shipment *model.Shipment) error {
+	if shipment.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO shipments (id, order_id, customer_id, status, carrier, tracking_number,
+				created_at, updated_at, shipped_at, delivered_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			shipment.ID.String(),
+			shipment.OrderID.String(),
+			shipment.CustomerID.String(),
+			int(shipment.Status),
+			shipment.Carrier,
+			shipment.TrackingNumber,
+			shipment.CreatedAt,
+			shipment.UpdatedAt,
+			shipment.ShippedAt,
+			shipment.DeliveredAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store shipment: %w", err)
+		}
+		if err = r.insertItems(shipment.ID, shipment.Items); err != nil {
+			return err
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE shipments
+			SET status = ?, carrier = ?, tracking_number = ?, updated_at = ?, shipped_at = ?, delivered_at = ?,
+				version = version + 1
+			WHERE id = ? AND version = ?`,
+			int(shipment.Status),
+			shipment.Carrier,
+			shipment.TrackingNumber,
+			shipment.UpdatedAt,
+			shipment.ShippedAt,
+			shipment.DeliveredAt,
+			shipment.ID.String(),
+			shipment.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store shipment: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
+	}
+	shipment.Version++
+
+	return nil
+}
+
+func (r *ShipmentRepository) insertItems(shipmentID uuid.UUID, items []model.ShipmentItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(items))
+	args := make([]interface{}, 0, len(items)*7)
+	for _, item := range items {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			shipmentID.String(),
+			item.ItemID.String(),
+			item.ProductID.String(),
+			item.Name,
+			item.Price.Decimal(),
+			item.Quantity,
+			item.Price.Currency,
+		)
+	}
+
+	query := `
+		INSERT INTO shipment_items (shipment_id, item_id, product_id, product_name, price, quantity, currency)
+		VALUES ` + strings.Join(placeholders, ", ")
+	_, err := r.client.ExecContext(r.ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to store shipment items: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ShipmentRepository) Find(id uuid.UUID) (*model.Shipment, error) {
+	var row shipmentRow
+	err := r.client.GetContext(r.ctx, &row, `
+		SELECT id, order_id, customer_id, status, carrier, tracking_number,
+			created_at, updated_at, shipped_at, delivered_at, version
+		FROM shipments
+		WHERE id = ?`,
+		id.String(),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrShipmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find shipment: %w", err)
+	}
+
+	shipments, err := r.withItems([]shipmentRow{row})
+	if err != nil {
+		return nil, err
+	}
+	return &shipments[0], nil
+}
+
+func (r *ShipmentRepository) FindByOrder(orderID uuid.UUID) ([]model.Shipment, error) {
+	var rows []shipmentRow
+	err := r.client.SelectContext(r.ctx, &rows, `
+		SELECT id, order_id, customer_id, status, carrier, tracking_number,
+			created_at, updated_at, shipped_at, delivered_at, version
+		FROM shipments
+		WHERE order_id = ?
+		ORDER BY created_at, id`,
+		orderID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order shipments: %w", err)
+	}
+
+	return r.withItems(rows)
+}
+
+// withItems загружает позиции всех отправлений одним запросом
+func (r *ShipmentRepository) withItems(rows []shipmentRow) ([]model.Shipment, error) {
+	if len(rows) == 0 {
+		return []model.Shipment{}, nil
+	}
+
+	shipmentIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		shipmentIDs = append(shipmentIDs, row.ID)
+	}
+	itemsQuery, args, err := sqlx.In(`
+		SELECT shipment_id, item_id, product_id, product_name, price, quantity, currency
+		FROM shipment_items
+		WHERE shipment_id IN (?)
+		ORDER BY shipment_id, item_id`,
+		shipmentIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build shipment items query: %w", err)
+	}
+
+	var itemRows []shipmentItemRow
+	err = r.client.SelectContext(r.ctx, &itemRows, itemsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find shipment items: %w", err)
+	}
+
+	items := make(map[string][]model.ShipmentItem, len(rows))
+	for _, itemRow := range itemRows {
+		item, err := itemRow.toShipmentItem()
+		if err != nil {
+			return nil, err
+		}
+		items[itemRow.ShipmentID] = append(items[itemRow.ShipmentID], item)
+	}
+
+	shipments := make([]model.Shipment, 0, len(rows))
+	for _, row := range rows {
+		shipment, err := row.toShipment(items[row.ID])
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, *shipment)
+	}
+	return shipments, nil
+}
+
+type shipmentRow struct {
+	ID             string       `db:"id"`
+	OrderID        string       `db:"order_id"`
+	CustomerID     string       `db:"customer_id"`
+	Status         int          `db:"status"`
+	Carrier        string       `db:"carrier"`
+	TrackingNumber string       `db:"tracking_number"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+	ShippedAt      sql.NullTime `db:"shipped_at"`
+	DeliveredAt    sql.NullTime `db:"delivered_at"`
+	Version        int          `db:"version"`
+}
+
+func (row *shipmentRow) toShipment(items []model.ShipmentItem) (*model.Shipment, error) {
+	shipmentID, err := uuid.Parse(row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shipment ID: %w", err)
+	}
+
+	orderID, err := uuid.Parse(row.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	customerID, err := uuid.Parse(row.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	shipment := &model.Shipment{
+		ID:             shipmentID,
+		OrderID:        orderID,
+		CustomerID:     customerID,
+		Status:         model.ShipmentStatus(row.Status),
+		Items:          items,
+		Carrier:        row.Carrier,
+		TrackingNumber: row.TrackingNumber,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+		Version:        row.Version,
+	}
+	if row.ShippedAt.Valid {
+		shipment.ShippedAt = &row.ShippedAt.Time
+	}
+	if row.DeliveredAt.Valid {
+		shipment.DeliveredAt = &row.DeliveredAt.Time
+	}
+	return shipment, nil
+}
+
+type shipmentItemRow struct {
+	ShipmentID  string `db:"shipment_id"`
+	ItemID      string `db:"item_id"`
+	ProductID   string `db:"product_id"`
+	ProductName string `db:"product_name"`
+	Price       string `db:"price"`
+	Quantity    int    `db:"quantity"`
+	Currency    string `db:"currency"`
+}
+
+func (row *shipmentItemRow) toShipmentItem() (model.ShipmentItem, error) {
+	itemID, err := uuid.Parse(row.ItemID)
+	if err != nil {
+		return model.ShipmentItem{}, fmt.Errorf("invalid item ID: %w", err)
+	}
+
+	productID, err := uuid.Parse(row.ProductID)
+	if err != nil {
+		return model.ShipmentItem{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	price, err := model.ParseMoney(row.Price, row.Currency)
+	if err != nil {
+		return model.ShipmentItem{}, fmt.Errorf("invalid shipment item price: %w", err)
+	}
+
+	return model.ShipmentItem{
+		ItemID:    itemID,
+		ProductID: productID,
+		Name:      row.ProductName,
+		Price:     price,
+		Quantity:  row.Quantity,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"order/pkg/domain/model"
+)
+
+// taxColumns - представление model.OrderTax в колонках *_total, tax_currency и tax_lines таблицы orders.
+// Встраивается в OrderRow, sqlx разворачивает его поля
+type taxColumns struct {
+	NetTotal    sql.NullString `db:"net_total"`
+	TaxTotal    sql.NullString `db:"tax_total"`
+	GrossTotal  sql.NullString `db:"gross_total"`
+	TaxCurrency sql.NullString `db:"tax_currency"`
+	TaxLines    sql.NullString `db:"tax_lines"`
+}
+
+// taxLineJSON - формат позиции в колонке tax_lines, суммы в валюте tax_currency
+type taxLineJSON struct {
+	ItemID  string `json:"item_id"`
+	Rate    int    `json:"rate"`
+	Pricing int    `json:"pricing"`
+	Net     string `json:"net"`
+	Tax     string `json:"tax"`
+	Gross   string `json:"gross"`
+}
+
+func toTaxColumns(tax *model.OrderTax) (taxColumns, error) {
+	if tax == nil {
+		return taxColumns{}, nil
+	}
+
+	lines := make([]taxLineJSON, 0, len(tax.Lines))
+	for _, line := range tax.Lines {
+		lines = append(lines, taxLineJSON{
+			ItemID:  line.ItemID.String(),
+			Rate:    int(line.Rate),
+			Pricing: int(line.Pricing),
+			Net:     line.Net.Decimal(),
+			Tax:     line.Tax.Decimal(),
+			Gross:   line.Gross.Decimal(),
+		})
+	}
+	serializedLines, err := json.Marshal(lines)
+	if err != nil {
+		return taxColumns{}, fmt.Errorf("failed to serialize tax lines: %w", err)
+	}
+	return taxColumns{
+		NetTotal:    sql.NullString{String: tax.Net.Decimal(), Valid: true},
+		TaxTotal:    sql.NullString{String: tax.Tax.Decimal(), Valid: true},
+		GrossTotal:  sql.NullString{String: tax.Gross.Decimal(), Valid: true},
+		TaxCurrency: sql.NullString{String: tax.Tax.Currency, Valid: true},
+		TaxLines:    sql.NullString{String: string(serializedLines), Valid: true},
+	}, nil
+}
+
+func (c taxColumns) toOrderTax() (*model.OrderTax, error) {
+	if !c.TaxTotal.Valid {
+		return nil, nil
+	}
+
+	currency := c.TaxCurrency.String
+	var tax model.OrderTax
+	var err error
+	if tax.Net, err = model.ParseMoney(c.NetTotal.String, currency); err != nil {
+		return nil, fmt.Errorf("invalid net total: %w", err)
+	}
+	if tax.Tax, err = model.ParseMoney(c.TaxTotal.String, currency); err != nil {
+		return nil, fmt.Errorf("invalid tax total: %w", err)
+	}
+	if tax.Gross, err = model.ParseMoney(c.GrossTotal.String, currency); err != nil {
+		return nil, fmt.Errorf("invalid gross total: %w", err)
+	}
+
+	var lines []taxLineJSON
+	if err = json.Unmarshal([]byte(c.TaxLines.String), &lines); err != nil {
+		return nil, fmt.Errorf("invalid tax lines: %w", err)
+	}
+	tax.Lines = make([]model.TaxLine, len(lines))
+	for i, line := range lines {
+		itemID, err := uuid.Parse(line.ItemID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tax line item ID: %w", err)
+		}
+		taxLine := model.TaxLine{
+			ItemID:  itemID,
+			Rate:    model.TaxRate(line.Rate),
+			Pricing: model.TaxPricing(line.Pricing),
+		}
+		if taxLine.Net, err = model.ParseMoney(line.Net, currency); err != nil {
+			return nil, fmt.Errorf("invalid tax line net: %w", err)
+		}
+		if taxLine.Tax, err = model.ParseMoney(line.Tax, currency); err != nil {
+			return nil, fmt.Errorf("invalid tax line tax: %w", err)
+		}
+		if taxLine.Gross, err = model.ParseMoney(line.Gross, currency); err != nil {
+			return nil, fmt.Errorf("invalid tax line gross: %w", err)
+		}
+		tax.Lines[i] = taxLine
+	}
+	return &tax, nil
+}
@@ -0,0 +1,22 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+)
+
+func NewTaxRuleQueryService(db *sqlx.DB) query.TaxRuleQueryService {
+	return &taxRuleQueryService{db: db}
+}
+
+type taxRuleQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *taxRuleQueryService) ListTaxRules(ctx context.Context) ([]model.TaxRule, error) {
+	return NewTaxRuleRepository(ctx, s.db).findAll()
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"order/pkg/domain/model"
+)
+
+type TaxRuleRepository struct {
+	ctx    context.Context
+	client ClientContext
+}
+
+func NewTaxRuleRepository(ctx context.Context, client ClientContext) *TaxRuleRepository {
+	return &TaxRuleRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+func (r *TaxRuleRepository) Store(rule *model.TaxRule) error {
+	_, err := r.client.ExecContext(r.ctx, `
+		INSERT INTO tax_rules (category, country, rate, pricing, rounding)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE rate = VALUES(rate), pricing = VALUES(pricing), rounding = VALUES(rounding)`,
+		rule.Category,
+		rule.Country,
+		int(rule.Rate),
+		int(rule.Pricing),
+		int(rule.Rounding),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store tax rule: %w", err)
+	}
+	return nil
+}
+
+func (r *TaxRuleRepository) Delete(category, country string) error {
+	result, err := r.client.ExecContext(r.ctx, "DELETE FROM tax_rules WHERE category = ? AND country = ?", category, country)
+	if err != nil {
+		return fmt.Errorf("failed to delete tax rule: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return model.ErrTaxRuleNotFound
+	}
+	return nil
+}
+
+func (r *TaxRuleRepository) FindByCountry(country string) ([]model.TaxRule, error) {
+	return r.find("WHERE country IN (?, '')", country)
+}
+
+func (r *TaxRuleRepository) findAll() ([]model.TaxRule, error) {
+	return r.find("")
+}
+
+func (r *TaxRuleRepository) find(where string, args ...interface{}) ([]model.TaxRule, error) {
+	var rows []TaxRuleRow
+	err := r.client.SelectContext(r.ctx, &rows, `
+		SELECT category, country, rate, pricing, rounding
+		FROM tax_rules `+where+`
+		ORDER BY country, category`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tax rules: %w", err)
+	}
+
+	rules := make([]model.TaxRule, len(rows))
+	for i, row := range rows {
+		rules[i] = model.TaxRule{
+			Category: row.Category,
+			Country:  row.Country,
+			Rate:     model.TaxRate(row.Rate),
+			Pricing:  model.TaxPricing(row.Pricing),
+			Rounding: model.TaxRounding(row.Rounding),
+		}
+	}
+	return rules, nil
+}
+
+type TaxRuleRow struct {
+	Category string `db:"category"`
+	Country  string `db:"country"`
+	Rate     int    `db:"rate"`
+	Pricing  int    `db:"pricing"`
+	Rounding int    `db:"rounding"`
+}
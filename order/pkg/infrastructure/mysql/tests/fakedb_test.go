@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeDB - минимальный драйвер database/sql, который записывает выполненные запросы,
+// транзакции и эмулирует именованные блокировки GET_LOCK/RELEASE_LOCK.
+// Результаты остальных SELECT отдаёт query, если он задан
+type fakeDB struct {
+	mu         sync.Mutex
+	statements []string
+	lockArgs   [][]driver.NamedValue
+	locks      map[string]*fakeConn
+	query      func(query string, args []driver.NamedValue) *fakeRows
+}
+
+func newFakeDB() (*fakeDB, *sqlx.DB) {
+	db := &fakeDB{locks: map[string]*fakeConn{}}
+	return db, sqlx.NewDb(sql.OpenDB(db), "mysql")
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: db}, nil
+}
+
+func (db *fakeDB) Driver() driver.Driver {
+	return nil
+}
+
+// holdLock захватывает блокировку от имени постороннего соединения
+func (db *fakeDB) holdLock(name string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.locks[name] = &fakeConn{db: db}
+}
+
+func (db *fakeDB) isLocked(name string) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	_, ok := db.locks[name]
+	return ok
+}
+
+func (db *fakeDB) recorded() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]string(nil), db.statements...)
+}
+
+func (db *fakeDB) record(statement string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.statements = append(db.statements, strings.Join(strings.Fields(statement), " "))
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.db.record("BEGIN")
+	return &fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+	if strings.HasPrefix(query, "DO RELEASE_LOCK") {
+		c.db.mu.Lock()
+		name := args[0].Value.(string)
+		if c.db.locks[name] == c {
+			delete(c.db.locks, name)
+		}
+		c.db.mu.Unlock()
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query)
+	if !strings.HasPrefix(query, "SELECT GET_LOCK") {
+		if c.db.query == nil {
+			return &fakeRows{}, nil
+		}
+		return c.db.query(query, args), nil
+	}
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.lockArgs = append(c.db.lockArgs, args)
+	name := args[0].Value.(string)
+	acquired := int64(1)
+	if holder, ok := c.db.locks[name]; ok && holder != c {
+		acquired = 0
+	} else {
+		c.db.locks[name] = c
+	}
+	return &fakeRows{columns: []string{"acquired"}, values: [][]driver.Value{{acquired}}}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (t *fakeTx) Commit() error {
+	t.db.record("COMMIT")
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.db.record("ROLLBACK")
+	return nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
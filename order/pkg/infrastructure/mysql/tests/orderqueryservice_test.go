@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"context"
+	"database/sql/driver"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/query"
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/mysql"
+)
+
+func TestOrderQueryServiceListOrders(t *testing.T) {
+	t.Run("Walk pages of orders created at the same time", func(t *testing.T) {
+		createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		table := newFakeOrdersTable(createdAt, createdAt, createdAt, createdAt, createdAt)
+		fake, db := newFakeDB()
+		fake.query = table.query
+		service := mysql.NewOrderQueryService(db)
+
+		var seen []uuid.UUID
+		var cursors []*query.OrderCursor
+		spec := query.ListOrdersSpec{PageSize: 2}
+		for range len(table.rows) {
+			page, err := service.ListOrders(context.Background(), spec)
+			require.NoError(t, err)
+			for _, order := range page.Orders {
+				seen = append(seen, order.ID)
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			cursors = append(cursors, page.NextCursor)
+			spec.After = page.NextCursor
+		}
+
+		require.Equal(t, table.sortedIDs(), seen)
+		require.Len(t, cursors, 2)
+		for i, cursor := range cursors {
+			require.Equal(t, seen[2*i+1], cursor.ID)
+			require.True(t, createdAt.Equal(cursor.CreatedAt))
+		}
+	})
+
+	t.Run("No next page when last page is exactly full", func(t *testing.T) {
+		createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		table := newFakeOrdersTable(createdAt, createdAt, createdAt.Add(time.Second), createdAt.Add(time.Second))
+		fake, db := newFakeDB()
+		fake.query = table.query
+		service := mysql.NewOrderQueryService(db)
+
+		first, err := service.ListOrders(context.Background(), query.ListOrdersSpec{PageSize: 2})
+		require.NoError(t, err)
+		require.NotNil(t, first.NextCursor)
+
+		second, err := service.ListOrders(context.Background(), query.ListOrdersSpec{PageSize: 2, After: first.NextCursor})
+		require.NoError(t, err)
+		require.Nil(t, second.NextCursor)
+		require.Len(t, second.Orders, 2)
+		for _, order := range second.Orders {
+			require.True(t, createdAt.Equal(order.CreatedAt))
+		}
+	})
+}
+
+// fakeOrdersTable выполняет запрос ListOrders над заказами в памяти: условие курсора
+// (created_at, id) < (?, ?), сортировка по убыванию и LIMIT читаются из аргументов запроса
+type fakeOrdersTable struct {
+	rows []mysql.OrderRow
+}
+
+func newFakeOrdersTable(createdAt ...time.Time) *fakeOrdersTable {
+	table := &fakeOrdersTable{}
+	customerID := uuid.Must(uuid.NewV7())
+	for _, at := range createdAt {
+		table.rows = append(table.rows, mysql.OrderRow{
+			ID:         uuid.Must(uuid.NewV7()).String(),
+			CustomerID: customerID.String(),
+			Status:     int(model.Open),
+			CreatedAt:  at,
+			UpdatedAt:  at,
+		})
+	}
+	slices.SortFunc(table.rows, func(a, b mysql.OrderRow) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	return table
+}
+
+func (t *fakeOrdersTable) sortedIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(t.rows))
+	for _, row := range t.rows {
+		ids = append(ids, uuid.MustParse(row.ID))
+	}
+	return ids
+}
+
+func (t *fakeOrdersTable) query(query string, args []driver.NamedValue) *fakeRows {
+	if !strings.Contains(query, "FROM orders") {
+		return &fakeRows{}
+	}
+
+	limit := int(args[len(args)-1].Value.(int64))
+	var afterCreatedAt time.Time
+	var afterID string
+	withCursor := strings.Contains(query, "created_at < ? OR (created_at = ? AND id < ?)")
+	if withCursor {
+		afterCreatedAt = args[len(args)-4].Value.(time.Time)
+		afterID = args[len(args)-2].Value.(string)
+	}
+
+	result := &fakeRows{columns: []string{"id", "customer_id", "status", "created_at", "updated_at"}}
+	for _, row := range t.rows {
+		if len(result.values) == limit {
+			break
+		}
+		if withCursor && !(row.CreatedAt.Before(afterCreatedAt) || (row.CreatedAt.Equal(afterCreatedAt) && row.ID < afterID)) {
+			continue
+		}
+		result.values = append(result.values, []driver.Value{row.ID, row.CustomerID, int64(row.Status), row.CreatedAt, row.UpdatedAt})
+	}
+	return result
+}
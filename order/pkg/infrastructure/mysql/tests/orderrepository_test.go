@@ -0,0 +1,300 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/domain/model"
+	"order/pkg/infrastructure/mysql"
+)
+
+func TestOrderRepositoryStore(t *testing.T) {
+	t.Run("Insert all items of new order in one statement", func(t *testing.T) {
+		client := &fakeClient{}
+		order := newOrder(3)
+		order.Version = 0
+
+		err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"INSERT INTO orders", "INSERT INTO order_items"}, client.statements())
+		require.Len(t, client.execs[1].args, 3*8)
+		require.Zero(t, client.selects)
+	})
+
+	t.Run("Insert only added item", func(t *testing.T) {
+		order := newOrder(3)
+		client := &fakeClient{items: toItemRows(order.Items)}
+		order.Items = append(order.Items, newItem())
+
+		err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"UPDATE orders", "INSERT INTO order_items"}, client.statements())
+		require.Len(t, client.execs[1].args, 8)
+	})
+
+	t.Run("Update only changed item", func(t *testing.T) {
+		order := newOrder(3)
+		client := &fakeClient{items: toItemRows(order.Items)}
+		order.Items[1].Quantity++
+
+		err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"UPDATE orders", "UPDATE order_items"}, client.statements())
+		require.Contains(t, client.execs[1].args, order.Items[1].ID.String())
+	})
+
+	t.Run("Delete only removed items", func(t *testing.T) {
+		order := newOrder(3)
+		client := &fakeClient{items: toItemRows(order.Items)}
+		removed := order.Items[0]
+		order.Items = order.Items[2:]
+
+		err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"UPDATE orders", "DELETE FROM order_items"}, client.statements())
+		require.Contains(t, client.execs[1].args, removed.ID.String())
+		require.Len(t, client.execs[1].args, 3)
+	})
+
+	t.Run("Skip items of unchanged order", func(t *testing.T) {
+		order := newOrder(3)
+		client := &fakeClient{items: toItemRows(order.Items)}
+
+		err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"UPDATE orders"}, client.statements())
+	})
+
+	t.Run("Fail on version conflict", func(t *testing.T) {
+		order := newOrder(3)
+		client := &fakeClient{items: toItemRows(order.Items), conflict: true}
+
+		err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+		require.ErrorIs(t, err, model.ErrVersionConflict)
+		require.Equal(t, []string{"UPDATE orders"}, client.statements())
+	})
+
+	t.Run("Query count does not grow with number of items", func(t *testing.T) {
+		for _, size := range []int{1, 100, 500} {
+			order := newOrder(size)
+			client := &fakeClient{items: toItemRows(order.Items)}
+			order.Items = append(order.Items, newItem())
+
+			err := mysql.NewOrderRepository(context.Background(), client).Store(order)
+
+			require.NoError(t, err)
+			// UPDATE orders, SELECT сохранённых позиций и один INSERT добавленной позиции
+			require.Equal(t, 3, client.queries(), size)
+		}
+	})
+}
+
+// BenchmarkOrderRepositoryStore сравнивает число запросов на одно сохранение (queries/op)
+// при записи только изменённых позиций и при прежней полной перезаписи позиций заказа.
+// fakeClient не ходит в MySQL, поэтому время операции ничего не говорит о стоимости
+// запросов в реальной базе
+func BenchmarkOrderRepositoryStore(b *testing.B) {
+	strategies := []struct {
+		name  string
+		store func(client *fakeClient, order *model.Order) error
+	}{
+		{
+			name: "ChangedItems",
+			store: func(client *fakeClient, order *model.Order) error {
+				return mysql.NewOrderRepository(context.Background(), client).Store(order)
+			},
+		},
+		{
+			name: "FullRewrite",
+			store: func(client *fakeClient, order *model.Order) error {
+				return storeFullRewrite(context.Background(), client, order)
+			},
+		},
+	}
+
+	for _, strategy := range strategies {
+		for _, size := range []int{100, 500} {
+			b.Run(fmt.Sprintf("%s/Items/%d", strategy.name, size), func(b *testing.B) {
+				order := newOrder(size)
+				persisted := toItemRows(order.Items)
+				order.Items = append(order.Items, newItem())
+				client := &fakeClient{items: persisted}
+
+				queries := 0
+				b.ResetTimer()
+				for range b.N {
+					order.Version = 1
+					if err := strategy.store(client, order); err != nil {
+						b.Fatal(err)
+					}
+					queries += client.queries()
+					client.execs, client.selects = client.execs[:0], 0
+				}
+				b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+			})
+		}
+	}
+}
+
+// storeFullRewrite повторяет прежнюю стратегию OrderRepository.Store: заказ обновляется,
+// все его позиции удаляются и вставляются заново по одному запросу на позицию, то есть 2+N запросов
+func storeFullRewrite(ctx context.Context, client mysql.ClientContext, order *model.Order) error {
+	_, err := client.ExecContext(ctx, `
+		UPDATE orders
+		SET customer_id = ?, status = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND version = ?`,
+		order.CustomerID.String(),
+		int(order.Status),
+		order.UpdatedAt,
+		order.ID.String(),
+		order.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ExecContext(ctx, "DELETE FROM order_items WHERE order_id = ?", order.ID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, item := range order.Items {
+		_, err = client.ExecContext(ctx, `
+			INSERT INTO order_items (id, order_id, product_id, product_name, category, price, currency, quantity)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			item.ID.String(),
+			order.ID.String(),
+			item.ProductID.String(),
+			item.Name,
+			item.Category,
+			item.Price.Decimal(),
+			item.Price.Currency,
+			item.Quantity,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newOrder(itemsCount int) *model.Order {
+	order := &model.Order{
+		ID:         uuid.Must(uuid.NewV7()),
+		CustomerID: uuid.Must(uuid.NewV7()),
+		Status:     model.Open,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		Version:    1,
+	}
+	for range itemsCount {
+		order.Items = append(order.Items, newItem())
+	}
+	return order
+}
+
+func newItem() model.Item {
+	return model.Item{
+		ID:        uuid.Must(uuid.NewV7()),
+		ProductID: uuid.Must(uuid.NewV7()),
+		Name:      "Keyboard",
+		Price:     model.Money{Amount: 9999, Currency: model.DefaultCurrency},
+		Quantity:  1,
+	}
+}
+
+func toItemRows(items []model.Item) []mysql.ItemRow {
+	rows := make([]mysql.ItemRow, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, mysql.ItemRow{
+			ID:          item.ID.String(),
+			ProductID:   item.ProductID.String(),
+			ProductName: item.Name,
+			Category:    item.Category,
+			Price:       item.Price.Decimal(),
+			Currency:    item.Price.Currency,
+			Quantity:    item.Quantity,
+		})
+	}
+	return rows
+}
+
+var _ mysql.ClientContext = &fakeClient{}
+
+type fakeExec struct {
+	query string
+	args  []interface{}
+}
+
+// fakeClient записывает выполненные запросы и отдаёт items как сохранённые позиции заказа
+type fakeClient struct {
+	items    []mysql.ItemRow
+	conflict bool
+
+	execs   []fakeExec
+	selects int
+}
+
+func (c *fakeClient) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.execs = append(c.execs, fakeExec{query: query, args: args})
+	if c.conflict {
+		return fakeResult(0), nil
+	}
+	return fakeResult(1), nil
+}
+
+func (c *fakeClient) GetContext(_ context.Context, _ interface{}, _ string, _ ...interface{}) error {
+	c.selects++
+	return sql.ErrNoRows
+}
+
+func (c *fakeClient) SelectContext(_ context.Context, dest interface{}, _ string, _ ...interface{}) error {
+	c.selects++
+	rows, ok := dest.(*[]mysql.ItemRow)
+	if !ok {
+		return fmt.Errorf("unexpected select destination %T", dest)
+	}
+	*rows = append((*rows)[:0], c.items...)
+	return nil
+}
+
+func (c *fakeClient) queries() int {
+	return len(c.execs) + c.selects
+}
+
+// statements возвращает начало каждого выполненного запроса, например "UPDATE orders"
+func (c *fakeClient) statements() []string {
+	result := make([]string, 0, len(c.execs))
+	for _, exec := range c.execs {
+		fields := strings.Fields(exec.query)
+		n := 2
+		if fields[0] != "UPDATE" {
+			n = 3
+		}
+		result = append(result, strings.Join(fields[:n], " "))
+	}
+	return result
+}
+
+type fakeResult int64
+
+func (r fakeResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r fakeResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
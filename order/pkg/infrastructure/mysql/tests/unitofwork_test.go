@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"order/pkg/application/service"
+	"order/pkg/infrastructure/event"
+	"order/pkg/infrastructure/mysql"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	t.Run("Commit on success", func(t *testing.T) {
+		fake, db := newFakeDB()
+
+		err := mysql.NewUnitOfWork(db, event.NewJSONSerializer()).Execute(context.Background(), func(provider service.RepositoryProvider) error {
+			_, err := provider.Inbox(context.Background()).MarkProcessed("event-1", "PaymentSucceeded")
+			return err
+		})
+
+		require.NoError(t, err)
+		statements := fake.recorded()
+		require.Equal(t, "BEGIN", statements[0])
+		require.Contains(t, statements[1], "INSERT IGNORE INTO inbox")
+		require.Equal(t, "COMMIT", statements[2])
+	})
+
+	t.Run("Rollback on error", func(t *testing.T) {
+		fake, db := newFakeDB()
+		errFailed := errors.New("failed")
+
+		err := mysql.NewUnitOfWork(db, event.NewJSONSerializer()).Execute(context.Background(), func(service.RepositoryProvider) error {
+			return errFailed
+		})
+
+		require.ErrorIs(t, err, errFailed)
+		require.Equal(t, []string{"BEGIN", "ROLLBACK"}, fake.recorded())
+	})
+
+	t.Run("Rollback and repanic on panic", func(t *testing.T) {
+		fake, db := newFakeDB()
+
+		require.PanicsWithValue(t, "boom", func() {
+			_ = mysql.NewUnitOfWork(db, event.NewJSONSerializer()).Execute(context.Background(), func(service.RepositoryProvider) error {
+				panic("boom")
+			})
+		})
+		require.Equal(t, []string{"BEGIN", "ROLLBACK"}, fake.recorded())
+	})
+}
+
+func TestLockableUnitOfWork(t *testing.T) {
+	t.Run("Release lock after commit", func(t *testing.T) {
+		fake, db := newFakeDB()
+		uow := mysql.NewLockableUnitOfWork(db, event.NewJSONSerializer(), time.Second)
+
+		err := uow.Execute(context.Background(), "order_1", func(service.RepositoryProvider) error {
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"SELECT GET_LOCK(?, ?)", "BEGIN", "COMMIT", "DO RELEASE_LOCK(?)"}, fake.recorded())
+		require.False(t, fake.isLocked("order_1"))
+	})
+
+	t.Run("Release lock after rollback", func(t *testing.T) {
+		fake, db := newFakeDB()
+		uow := mysql.NewLockableUnitOfWork(db, event.NewJSONSerializer(), time.Second)
+
+		err := uow.Execute(context.Background(), "order_1", func(service.RepositoryProvider) error {
+			return errors.New("failed")
+		})
+
+		require.Error(t, err)
+		require.Equal(t, []string{"SELECT GET_LOCK(?, ?)", "BEGIN", "ROLLBACK", "DO RELEASE_LOCK(?)"}, fake.recorded())
+		require.False(t, fake.isLocked("order_1"))
+	})
+
+	t.Run("Fail without transaction when lock is held", func(t *testing.T) {
+		fake, db := newFakeDB()
+		fake.holdLock("order_1")
+		uow := mysql.NewLockableUnitOfWork(db, event.NewJSONSerializer(), time.Second)
+		called := false
+
+		err := uow.Execute(context.Background(), "order_1", func(service.RepositoryProvider) error {
+			called = true
+			return nil
+		})
+
+		require.ErrorIs(t, err, service.ErrLockTimeout)
+		require.False(t, called)
+		require.Equal(t, []string{"SELECT GET_LOCK(?, ?)"}, fake.recorded())
+	})
+
+	t.Run("Round lock timeout up to whole seconds", func(t *testing.T) {
+		for _, tc := range []struct {
+			timeout  time.Duration
+			expected int64
+		}{
+			{timeout: 500 * time.Millisecond, expected: 1},
+			{timeout: time.Second, expected: 1},
+			{timeout: 1500 * time.Millisecond, expected: 2},
+			{timeout: 10 * time.Second, expected: 10},
+		} {
+			fake, db := newFakeDB()
+			uow := mysql.NewLockableUnitOfWork(db, event.NewJSONSerializer(), tc.timeout)
+
+			err := uow.Execute(context.Background(), "order_1", func(service.RepositoryProvider) error {
+				return nil
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, fake.lockArgs[0][1].Value, tc.timeout.String())
+		}
+	})
+}
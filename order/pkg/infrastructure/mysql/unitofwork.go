@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
+	"order/pkg/infrastructure/event"
+)
+
+func NewUnitOfWork(db *sqlx.DB, serializer event.Serializer) service.UnitOfWork {
+	return &unitOfWork{
+		db:         db,
+		serializer: serializer,
+	}
+}
+
+type unitOfWork struct {
+	db         *sqlx.DB
+	serializer event.Serializer
+}
+
+func (u *unitOfWork) Execute(ctx context.Context, f func(provider service.RepositoryProvider) error) error {
+	return commonmysql.ExecuteInTransaction(ctx, u.db, func(tx *sqlx.Tx) error {
+		return f(&repositoryProvider{tx: tx, serializer: u.serializer})
+	})
+}
+
+// NewLockableUnitOfWork сериализует транзакции по именованной блокировке MySQL (GET_LOCK)
+func NewLockableUnitOfWork(db *sqlx.DB, serializer event.Serializer, lockTimeout time.Duration) service.LockableUnitOfWork {
+	return &lockableUnitOfWork{
+		db:          db,
+		serializer:  serializer,
+		lockTimeout: lockTimeout,
+	}
+}
+
+type lockableUnitOfWork struct {
+	db          *sqlx.DB
+	serializer  event.Serializer
+	lockTimeout time.Duration
+}
+
+func (u *lockableUnitOfWork) Execute(ctx context.Context, lockName string, f func(provider service.RepositoryProvider) error) error {
+	return commonmysql.ExecuteWithLock(ctx, u.db, lockName, u.lockTimeout, service.ErrLockTimeout, func(tx *sqlx.Tx) error {
+		return f(&repositoryProvider{tx: tx, serializer: u.serializer})
+	})
+}
+
+type repositoryProvider struct {
+	tx         *sqlx.Tx
+	serializer event.Serializer
+}
+
+func (p *repositoryProvider) OrderRepository(ctx context.Context) model.OrderRepository {
+	return NewOrderRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) OrderStatusHistoryRepository(ctx context.Context) model.OrderStatusHistoryRepository {
+	return NewOrderStatusHistoryRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) CheckoutSagaRepository(ctx context.Context) model.CheckoutSagaRepository {
+	return NewCheckoutSagaRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) PromoCodeRepository(ctx context.Context) model.PromoCodeRepository {
+	return NewPromoCodeRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) TaxRuleRepository(ctx context.Context) model.TaxRuleRepository {
+	return NewTaxRuleRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) ReturnRepository(ctx context.Context) model.ReturnRepository {
+	return NewReturnRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) ReturnStatusHistoryRepository(ctx context.Context) model.ReturnStatusHistoryRepository {
+	return NewReturnStatusHistoryRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) ShipmentRepository(ctx context.Context) model.ShipmentRepository {
+	return NewShipmentRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) EventDispatcher(ctx context.Context) domainservice.EventDispatcher {
+	return commonmysql.NewEventDispatcher(ctx, p.tx, p.serializer)
+}
+
+func (p *repositoryProvider) Inbox(ctx context.Context) service.Inbox {
+	return commonmysql.NewInbox(ctx, p.tx)
+}
@@ -5,6 +5,11 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+
+	"order/pkg/application/query"
+	appservice "order/pkg/application/service"
+	"order/pkg/domain/model"
+	"order/pkg/domain/service"
 )
 
 type errorSet map[error]struct{}
@@ -22,9 +27,61 @@ func (s errorSet) Has(err error) bool {
 	return ok
 }
 
-var badRequestErrorCodes = newErrorSet()
+var badRequestErrorCodes = newErrorSet(
+	service.ErrInvalidQuantity,
+	service.ErrCancelReasonRequired,
+	model.ErrInvalidCurrency,
+	model.ErrInvalidAmount,
+	model.ErrCurrencyMismatch,
+	model.ErrInvalidPromoCode,
+	model.ErrInvalidDiscount,
+	model.ErrInvalidDeliveryAddress,
+	model.ErrInvalidShippingMethod,
+	model.ErrInvalidTaxRule,
+	model.ErrInvalidReturnItems,
+	service.ErrReturnQuantityExceeded,
+	service.ErrRejectReasonRequired,
+	model.ErrInvalidShipmentItems,
+	service.ErrShipmentQuantityExceeded,
+	service.ErrTrackingInfoRequired,
+	query.ErrInvalidCursor,
+)
+
+var notFoundErrorCodes = newErrorSet(
+	model.ErrOrderNotFound,
+	model.ErrItemNotFound,
+	model.ErrProductNotFound,
+	model.ErrCheckoutSagaNotFound,
+	model.ErrPromoCodeNotFound,
+	model.ErrTaxRuleNotFound,
+	model.ErrCustomerNotFound,
+	model.ErrReturnNotFound,
+	model.ErrShipmentNotFound,
+)
+
+var alreadyExistsErrorCodes = newErrorSet(
+	model.ErrPromoCodeExists,
+)
 
-var notFoundErrorCodes = newErrorSet()
+var failedPreconditionErrorCodes = newErrorSet(
+	service.ErrInvalidOrderStatus,
+	service.ErrInvalidStatusTransition,
+	service.ErrEmptyOrder,
+	service.ErrNothingToReorder,
+	service.ErrOrderAlreadyShipped,
+	service.ErrPromoCodeNotActive,
+	service.ErrPromoCodeUsageLimitReached,
+	model.ErrCustomerDeleted,
+	service.ErrOrderNotReturnable,
+	service.ErrInvalidReturnStatusTransition,
+	service.ErrOrderNotShippable,
+	service.ErrInvalidShipmentStatusTransition,
+)
+
+var abortedErrorCodes = newErrorSet(
+	appservice.ErrLockTimeout,
+	model.ErrVersionConflict,
+)
 
 var unauthorizedErrorCodes = newErrorSet()
 
@@ -43,6 +100,12 @@ func getGRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 	case isNotFoundError(cause):
 		return codes.NotFound
+	case isAlreadyExistsError(cause):
+		return codes.AlreadyExists
+	case isFailedPreconditionError(cause):
+		return codes.FailedPrecondition
+	case isAbortedError(cause):
+		return codes.Aborted
 	case isUnauthorizedError(cause):
 		return codes.Unauthenticated
 	case isPermissionDeniedError(cause):
@@ -68,7 +131,9 @@ func isWarnLevel(err error) bool {
 		codes.PermissionDenied,
 		codes.InvalidArgument,
 		codes.NotFound,
+		codes.AlreadyExists,
 		codes.FailedPrecondition,
+		codes.Aborted,
 		codes.Unauthenticated:
 		return true
 	default:
@@ -84,6 +149,18 @@ func isNotFoundError(cause error) bool {
 	return notFoundErrorCodes.Has(cause)
 }
 
+func isAlreadyExistsError(cause error) bool {
+	return alreadyExistsErrorCodes.Has(cause)
+}
+
+func isFailedPreconditionError(cause error) bool {
+	return failedPreconditionErrorCodes.Has(cause)
+}
+
+func isAbortedError(cause error) bool {
+	return abortedErrorCodes.Has(cause)
+}
+
 func isUnauthorizedError(cause error) bool {
 	return unauthorizedErrorCodes.Has(cause)
 }
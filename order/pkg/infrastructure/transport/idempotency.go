@@ -0,0 +1,33 @@
+package transport
+
+import (
+	api "order/api/server/orderinternal"
+)
+
+// IdempotentMethods - изменяющие вызовы, повтор которых с тем же idempotency-key
+// возвращает сохранённый ответ вместо повторного выполнения
+var IdempotentMethods = []string{
+	api.OrderInternalService_CreateOrder_FullMethodName,
+	api.OrderInternalService_DeleteOrder_FullMethodName,
+	api.OrderInternalService_RestoreOrder_FullMethodName,
+	api.OrderInternalService_SetStatus_FullMethodName,
+	api.OrderInternalService_CancelOrder_FullMethodName,
+	api.OrderInternalService_ReorderFrom_FullMethodName,
+	api.OrderInternalService_Checkout_FullMethodName,
+	api.OrderInternalService_AddItem_FullMethodName,
+	api.OrderInternalService_UpdateItemQuantity_FullMethodName,
+	api.OrderInternalService_DeleteItem_FullMethodName,
+	api.OrderInternalService_SetDelivery_FullMethodName,
+	api.OrderInternalService_CreatePromoCode_FullMethodName,
+	api.OrderInternalService_ApplyPromoCode_FullMethodName,
+	api.OrderInternalService_RemovePromoCode_FullMethodName,
+	api.OrderInternalService_SetTaxRule_FullMethodName,
+	api.OrderInternalService_DeleteTaxRule_FullMethodName,
+	api.OrderInternalService_RequestReturn_FullMethodName,
+	api.OrderInternalService_ApproveReturn_FullMethodName,
+	api.OrderInternalService_RejectReturn_FullMethodName,
+	api.OrderInternalService_ReceiveReturn_FullMethodName,
+	api.OrderInternalService_CreateShipment_FullMethodName,
+	api.OrderInternalService_ShipShipment_FullMethodName,
+	api.OrderInternalService_DeliverShipment_FullMethodName,
+}
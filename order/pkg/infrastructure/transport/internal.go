@@ -3,14 +3,61 @@ package transport
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	api "order/api/server/orderinternal"
+	"order/pkg/application/query"
+	"order/pkg/application/service"
+	"order/pkg/domain/model"
+	domainservice "order/pkg/domain/service"
 )
 
-func NewInternalAPI() api.OrderInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(
+	orderService service.OrderService,
+	orderQueryService query.OrderQueryService,
+	checkoutService service.CheckoutService,
+	checkoutQueryService query.CheckoutQueryService,
+	promoCodeService service.PromoCodeService,
+	promoCodeQueryService query.PromoCodeQueryService,
+	taxRuleService service.TaxRuleService,
+	taxRuleQueryService query.TaxRuleQueryService,
+	returnService service.ReturnService,
+	returnQueryService query.ReturnQueryService,
+	shipmentService service.ShipmentService,
+	shipmentQueryService query.ShipmentQueryService,
+) api.OrderInternalServiceServer {
+	return &internalAPI{
+		orderService:          orderService,
+		orderQueryService:     orderQueryService,
+		checkoutService:       checkoutService,
+		checkoutQueryService:  checkoutQueryService,
+		promoCodeService:      promoCodeService,
+		promoCodeQueryService: promoCodeQueryService,
+		taxRuleService:        taxRuleService,
+		taxRuleQueryService:   taxRuleQueryService,
+		returnService:         returnService,
+		returnQueryService:    returnQueryService,
+		shipmentService:       shipmentService,
+		shipmentQueryService:  shipmentQueryService,
+	}
 }
 
 type internalAPI struct {
+	orderService          service.OrderService
+	orderQueryService     query.OrderQueryService
+	checkoutService       service.CheckoutService
+	checkoutQueryService  query.CheckoutQueryService
+	promoCodeService      service.PromoCodeService
+	promoCodeQueryService query.PromoCodeQueryService
+	taxRuleService        service.TaxRuleService
+	taxRuleQueryService   query.TaxRuleQueryService
+	returnService         service.ReturnService
+	returnQueryService    query.ReturnQueryService
+	shipmentService       service.ShipmentService
+	shipmentQueryService  query.ShipmentQueryService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +65,967 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) CreateOrder(ctx context.Context, request *api.CreateOrderRequest) (*api.CreateOrderResponse, error) {
+	customerID, err := parseUUID(request.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery *model.Delivery
+	if request.Delivery != nil {
+		parsed, err := fromAPIDelivery(request.Delivery)
+		if err != nil {
+			return nil, err
+		}
+		delivery = &parsed
+	}
+
+	orderID, err := i.orderService.CreateOrder(ctx, customerID, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateOrderResponse{
+		OrderID: orderID.String(),
+	}, nil
+}
+
+func (i *internalAPI) DeleteOrder(ctx context.Context, request *api.DeleteOrderRequest) (*api.DeleteOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.DeleteOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteOrderResponse{}, nil
+}
+
+func (i *internalAPI) RestoreOrder(ctx context.Context, request *api.RestoreOrderRequest) (*api.RestoreOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.RestoreOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RestoreOrderResponse{}, nil
+}
+
+func (i *internalAPI) SetStatus(ctx context.Context, request *api.SetStatusRequest) (*api.SetStatusResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.ChangedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "changedBy is required")
+	}
+
+	err = i.orderService.SetStatus(ctx, orderID, model.OrderStatus(request.Status), request.ChangedBy, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SetStatusResponse{}, nil
+}
+
+func (i *internalAPI) CancelOrder(ctx context.Context, request *api.CancelOrderRequest) (*api.CancelOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.CancelOrder(ctx, orderID, request.CancelledBy, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CancelOrderResponse{}, nil
+}
+
+func (i *internalAPI) ReorderFrom(ctx context.Context, request *api.ReorderFromRequest) (*api.ReorderFromResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := i.orderService.ReorderFrom(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &api.ReorderFromResponse{
+		OrderID:       result.OrderID.String(),
+		DroppedItems:  make([]*api.Item, 0, len(result.Dropped)),
+		RepricedItems: make([]*api.RepricedItem, 0, len(result.Repriced)),
+	}
+	for _, item := range result.Dropped {
+		response.DroppedItems = append(response.DroppedItems, &api.Item{
+			ItemID:    item.ID.String(),
+			ProductID: item.ProductID.String(),
+			Name:      item.Name,
+			Price:     toAPIMoney(item.Price),
+			Quantity:  int32(item.Quantity),
+			Total:     toAPIMoney(item.Total()),
+		})
+	}
+	for _, item := range result.Repriced {
+		response.RepricedItems = append(response.RepricedItems, &api.RepricedItem{
+			ProductID: item.ProductID.String(),
+			Name:      item.Name,
+			OldPrice:  toAPIMoney(item.OldPrice),
+			NewPrice:  toAPIMoney(item.NewPrice),
+		})
+	}
+
+	return response, nil
+}
+
+func (i *internalAPI) GetOrder(ctx context.Context, request *api.GetOrderRequest) (*api.GetOrderResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := i.orderQueryService.FindOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiOrder, err := toAPIOrder(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetOrderResponse{
+		Order: apiOrder,
+	}, nil
+}
+
+func (i *internalAPI) GetOrderStatusHistory(
+	ctx context.Context,
+	request *api.GetOrderStatusHistoryRequest,
+) (*api.GetOrderStatusHistoryResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := i.orderQueryService.FindStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.StatusChange, 0, len(history))
+	for _, change := range history {
+		result = append(result, &api.StatusChange{
+			OldStatus: api.OrderStatus(change.OldStatus),
+			NewStatus: api.OrderStatus(change.NewStatus),
+			ChangedBy: change.ChangedBy,
+			Reason:    change.Reason,
+			ChangedAt: timestamppb.New(change.ChangedAt),
+		})
+	}
+
+	return &api.GetOrderStatusHistoryResponse{
+		History: result,
+	}, nil
+}
+
+func (i *internalAPI) ListOrders(ctx context.Context, request *api.ListOrdersRequest) (*api.ListOrdersResponse, error) {
+	spec := query.ListOrdersSpec{
+		PageSize: int(request.PageSize),
+	}
+	if request.CustomerID != "" {
+		customerID, err := parseUUID(request.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		spec.CustomerID = &customerID
+	}
+	for _, orderStatus := range request.Statuses {
+		spec.Statuses = append(spec.Statuses, model.OrderStatus(orderStatus))
+	}
+	if request.CreatedFrom != nil {
+		createdFrom := request.CreatedFrom.AsTime()
+		spec.CreatedFrom = &createdFrom
+	}
+	if request.CreatedTo != nil {
+		createdTo := request.CreatedTo.AsTime()
+		spec.CreatedTo = &createdTo
+	}
+	if request.PageToken != "" {
+		cursor, err := query.ParseOrderCursor(request.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		spec.After = cursor
+	}
+
+	page, err := i.orderQueryService.ListOrders(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &api.ListOrdersResponse{
+		Orders: make([]*api.Order, 0, len(page.Orders)),
+	}
+	for _, order := range page.Orders {
+		apiOrder, err := toAPIOrder(&order)
+		if err != nil {
+			return nil, err
+		}
+		response.Orders = append(response.Orders, apiOrder)
+	}
+	if page.NextCursor != nil {
+		response.NextPageToken = page.NextCursor.Encode()
+	}
+
+	return response, nil
+}
+
+func (i *internalAPI) Checkout(ctx context.Context, request *api.CheckoutRequest) (*api.CheckoutResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkoutID, err := i.checkoutService.Checkout(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkout, err := i.checkoutQueryService.FindCheckout(ctx, checkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CheckoutResponse{
+		Checkout: toAPICheckout(checkout),
+	}, nil
+}
+
+func (i *internalAPI) GetCheckout(ctx context.Context, request *api.GetCheckoutRequest) (*api.GetCheckoutResponse, error) {
+	checkoutID, err := parseUUID(request.CheckoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkout, err := i.checkoutQueryService.FindCheckout(ctx, checkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetCheckoutResponse{
+		Checkout: toAPICheckout(checkout),
+	}, nil
+}
+
+func (i *internalAPI) AddItem(ctx context.Context, request *api.AddItemRequest) (*api.AddItemResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemID, err := i.orderService.AddItem(ctx, orderID, productID, int(request.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AddItemResponse{
+		ItemID: itemID.String(),
+	}, nil
+}
+
+func (i *internalAPI) UpdateItemQuantity(
+	ctx context.Context,
+	request *api.UpdateItemQuantityRequest,
+) (*api.UpdateItemQuantityResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	itemID, err := parseUUID(request.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.UpdateItemQuantity(ctx, orderID, itemID, int(request.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateItemQuantityResponse{}, nil
+}
+
+func (i *internalAPI) DeleteItem(ctx context.Context, request *api.DeleteItemRequest) (*api.DeleteItemResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	itemID, err := parseUUID(request.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.DeleteItem(ctx, orderID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteItemResponse{}, nil
+}
+
+func (i *internalAPI) SetDelivery(ctx context.Context, request *api.SetDeliveryRequest) (*api.SetDeliveryResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Delivery == nil {
+		return nil, status.Error(codes.InvalidArgument, "delivery is required")
+	}
+
+	delivery, err := fromAPIDelivery(request.Delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.orderService.SetDelivery(ctx, orderID, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SetDeliveryResponse{}, nil
+}
+
+func (i *internalAPI) CreatePromoCode(
+	ctx context.Context,
+	request *api.CreatePromoCodeRequest,
+) (*api.CreatePromoCodeResponse, error) {
+	if request.PromoCode == nil {
+		return nil, status.Error(codes.InvalidArgument, "promoCode is required")
+	}
+
+	promoCode, err := fromAPIPromoCode(request.PromoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.promoCodeService.CreatePromoCode(ctx, promoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreatePromoCodeResponse{}, nil
+}
+
+func (i *internalAPI) GetPromoCode(ctx context.Context, request *api.GetPromoCodeRequest) (*api.GetPromoCodeResponse, error) {
+	promoCode, err := i.promoCodeQueryService.FindPromoCode(ctx, request.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetPromoCodeResponse{
+		PromoCode: toAPIPromoCode(promoCode),
+	}, nil
+}
+
+func (i *internalAPI) ApplyPromoCode(ctx context.Context, request *api.ApplyPromoCodeRequest) (*api.ApplyPromoCodeResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.promoCodeService.ApplyPromoCode(ctx, orderID, request.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ApplyPromoCodeResponse{}, nil
+}
+
+func (i *internalAPI) RemovePromoCode(ctx context.Context, request *api.RemovePromoCodeRequest) (*api.RemovePromoCodeResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.promoCodeService.RemovePromoCode(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RemovePromoCodeResponse{}, nil
+}
+
+func (i *internalAPI) SetTaxRule(ctx context.Context, request *api.SetTaxRuleRequest) (*api.SetTaxRuleResponse, error) {
+	if request.TaxRule == nil {
+		return nil, status.Error(codes.InvalidArgument, "taxRule is required")
+	}
+
+	err := i.taxRuleService.SetTaxRule(ctx, model.TaxRule{
+		Category: request.TaxRule.Category,
+		Country:  request.TaxRule.Country,
+		Rate:     model.TaxRate(request.TaxRule.Rate),
+		Pricing:  model.TaxPricing(request.TaxRule.Pricing),
+		Rounding: model.TaxRounding(request.TaxRule.Rounding),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SetTaxRuleResponse{}, nil
+}
+
+func (i *internalAPI) DeleteTaxRule(ctx context.Context, request *api.DeleteTaxRuleRequest) (*api.DeleteTaxRuleResponse, error) {
+	err := i.taxRuleService.DeleteTaxRule(ctx, request.Category, request.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteTaxRuleResponse{}, nil
+}
+
+func (i *internalAPI) ListTaxRules(ctx context.Context, _ *api.ListTaxRulesRequest) (*api.ListTaxRulesResponse, error) {
+	rules, err := i.taxRuleQueryService.ListTaxRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &api.ListTaxRulesResponse{
+		TaxRules: make([]*api.TaxRule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		response.TaxRules = append(response.TaxRules, &api.TaxRule{
+			Category: rule.Category,
+			Country:  rule.Country,
+			Rate:     int32(rule.Rate),
+			Pricing:  api.TaxPricing(rule.Pricing),
+			Rounding: api.TaxRounding(rule.Rounding),
+		})
+	}
+	return response, nil
+}
+
+func (i *internalAPI) RequestReturn(ctx context.Context, request *api.RequestReturnRequest) (*api.RequestReturnResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if request.RequestedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "requestedBy is required")
+	}
+
+	items := make([]domainservice.RequestedReturnItem, 0, len(request.Items))
+	for _, item := range request.Items {
+		itemID, err := parseUUID(item.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, domainservice.RequestedReturnItem{
+			ItemID:   itemID,
+			Quantity: int(item.Quantity),
+		})
+	}
+
+	returnID, err := i.returnService.RequestReturn(ctx, orderID, items, request.Reason, request.RequestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RequestReturnResponse{
+		ReturnID: returnID.String(),
+	}, nil
+}
+
+func (i *internalAPI) ApproveReturn(ctx context.Context, request *api.ApproveReturnRequest) (*api.ApproveReturnResponse, error) {
+	returnID, err := parseReturnChange(request.ReturnID, request.ChangedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.returnService.ApproveReturn(ctx, returnID, request.ChangedBy, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ApproveReturnResponse{}, nil
+}
+
+func (i *internalAPI) RejectReturn(ctx context.Context, request *api.RejectReturnRequest) (*api.RejectReturnResponse, error) {
+	returnID, err := parseReturnChange(request.ReturnID, request.ChangedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.returnService.RejectReturn(ctx, returnID, request.ChangedBy, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RejectReturnResponse{}, nil
+}
+
+func (i *internalAPI) ReceiveReturn(ctx context.Context, request *api.ReceiveReturnRequest) (*api.ReceiveReturnResponse, error) {
+	returnID, err := parseReturnChange(request.ReturnID, request.ChangedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.returnService.ReceiveReturn(ctx, returnID, request.ChangedBy, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ReceiveReturnResponse{}, nil
+}
+
+func (i *internalAPI) GetReturn(ctx context.Context, request *api.GetReturnRequest) (*api.GetReturnResponse, error) {
+	returnID, err := parseUUID(request.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := i.returnQueryService.FindReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetReturnResponse{
+		Return: toAPIReturn(ret),
+	}, nil
+}
+
+func (i *internalAPI) ListOrderReturns(
+	ctx context.Context,
+	request *api.ListOrderReturnsRequest,
+) (*api.ListOrderReturnsResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	returns, err := i.returnQueryService.ListOrderReturns(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.Return, 0, len(returns))
+	for idx := range returns {
+		result = append(result, toAPIReturn(&returns[idx]))
+	}
+
+	return &api.ListOrderReturnsResponse{
+		Returns: result,
+	}, nil
+}
+
+func (i *internalAPI) GetReturnStatusHistory(
+	ctx context.Context,
+	request *api.GetReturnStatusHistoryRequest,
+) (*api.GetReturnStatusHistoryResponse, error) {
+	returnID, err := parseUUID(request.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := i.returnQueryService.FindReturnStatusHistory(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.ReturnStatusChange, 0, len(history))
+	for _, change := range history {
+		result = append(result, &api.ReturnStatusChange{
+			OldStatus: api.ReturnStatus(change.OldStatus),
+			NewStatus: api.ReturnStatus(change.NewStatus),
+			ChangedBy: change.ChangedBy,
+			Reason:    change.Reason,
+			ChangedAt: timestamppb.New(change.ChangedAt),
+		})
+	}
+
+	return &api.GetReturnStatusHistoryResponse{
+		History: result,
+	}, nil
+}
+
+func (i *internalAPI) CreateShipment(ctx context.Context, request *api.CreateShipmentRequest) (*api.CreateShipmentResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domainservice.RequestedShipmentItem, 0, len(request.Items))
+	for _, item := range request.Items {
+		itemID, err := parseUUID(item.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, domainservice.RequestedShipmentItem{
+			ItemID:   itemID,
+			Quantity: int(item.Quantity),
+		})
+	}
+
+	shipmentID, err := i.shipmentService.CreateShipment(ctx, orderID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateShipmentResponse{
+		ShipmentID: shipmentID.String(),
+	}, nil
+}
+
+func (i *internalAPI) ShipShipment(ctx context.Context, request *api.ShipShipmentRequest) (*api.ShipShipmentResponse, error) {
+	shipmentID, err := parseUUID(request.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.shipmentService.ShipShipment(ctx, shipmentID, request.Carrier, request.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ShipShipmentResponse{}, nil
+}
+
+func (i *internalAPI) DeliverShipment(ctx context.Context, request *api.DeliverShipmentRequest) (*api.DeliverShipmentResponse, error) {
+	shipmentID, err := parseUUID(request.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.shipmentService.DeliverShipment(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeliverShipmentResponse{}, nil
+}
+
+func (i *internalAPI) GetShipment(ctx context.Context, request *api.GetShipmentRequest) (*api.GetShipmentResponse, error) {
+	shipmentID, err := parseUUID(request.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment, err := i.shipmentQueryService.FindShipment(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetShipmentResponse{
+		Shipment: toAPIShipment(shipment),
+	}, nil
+}
+
+func (i *internalAPI) ListOrderShipments(
+	ctx context.Context,
+	request *api.ListOrderShipmentsRequest,
+) (*api.ListOrderShipmentsResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := i.shipmentQueryService.ListOrderShipments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*api.Shipment, 0, len(shipments))
+	for idx := range shipments {
+		result = append(result, toAPIShipment(&shipments[idx]))
+	}
+
+	return &api.ListOrderShipmentsResponse{
+		Shipments: result,
+	}, nil
+}
+
+func parseReturnChange(returnID, changedBy string) (uuid.UUID, error) {
+	id, err := parseUUID(returnID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if changedBy == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "changedBy is required")
+	}
+	return id, nil
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q: %s", value, err)
+	}
+	return id, nil
+}
+
+func fromAPIMoney(money *api.Money) (model.Money, error) {
+	if money == nil {
+		return model.Money{}, status.Error(codes.InvalidArgument, "money is required")
+	}
+	return model.NewMoney(money.Amount, money.Currency)
+}
+
+func toAPIMoney(money model.Money) *api.Money {
+	return &api.Money{
+		Amount:   money.Amount,
+		Currency: money.Currency,
+	}
+}
+
+func toAPIOrder(order *query.Order) (*api.Order, error) {
+	items := make([]*api.Item, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &api.Item{
+			ItemID:    item.ID.String(),
+			ProductID: item.ProductID.String(),
+			Name:      item.Name,
+			Category:  item.Category,
+			Price:     toAPIMoney(item.Price),
+			Quantity:  int32(item.Quantity),
+			Total:     toAPIMoney(item.Total()),
+		})
+	}
+
+	subtotal, err := order.Subtotal()
+	if err != nil {
+		return nil, err
+	}
+	discount, err := order.Discount()
+	if err != nil {
+		return nil, err
+	}
+	shippingCost, err := order.ShippingCost()
+	if err != nil {
+		return nil, err
+	}
+	total, err := order.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.Order{
+		OrderID:      order.ID.String(),
+		CustomerID:   order.CustomerID.String(),
+		Status:       api.OrderStatus(order.Status),
+		Items:        items,
+		CreatedAt:    timestamppb.New(order.CreatedAt),
+		UpdatedAt:    timestamppb.New(order.UpdatedAt),
+		Subtotal:     toAPIMoney(subtotal),
+		Discount:     toAPIMoney(discount),
+		Total:        toAPIMoney(total),
+		ShippingCost: toAPIMoney(shippingCost),
+	}
+	if order.PromoCode != nil {
+		result.PromoCode = order.PromoCode.Code
+	}
+	if order.Delivery != nil {
+		result.Delivery = toAPIDelivery(order.Delivery)
+	}
+	if order.Tax != nil {
+		result.Tax = toAPIOrderTax(order.Tax)
+	}
+	return result, nil
+}
+
+func toAPIOrderTax(tax *model.OrderTax) *api.OrderTax {
+	lines := make([]*api.TaxLine, 0, len(tax.Lines))
+	for _, line := range tax.Lines {
+		lines = append(lines, &api.TaxLine{
+			ItemID:  line.ItemID.String(),
+			Rate:    int32(line.Rate),
+			Pricing: api.TaxPricing(line.Pricing),
+			Net:     toAPIMoney(line.Net),
+			Tax:     toAPIMoney(line.Tax),
+			Gross:   toAPIMoney(line.Gross),
+		})
+	}
+	return &api.OrderTax{
+		Net:   toAPIMoney(tax.Net),
+		Tax:   toAPIMoney(tax.Tax),
+		Gross: toAPIMoney(tax.Gross),
+		Lines: lines,
+	}
+}
+
+func fromAPIDelivery(delivery *api.Delivery) (model.Delivery, error) {
+	if delivery.Address == nil {
+		return model.Delivery{}, status.Error(codes.InvalidArgument, "delivery address is required")
+	}
+
+	return model.Delivery{
+		Address: model.DeliveryAddress{
+			Recipient:  delivery.Address.Recipient,
+			Phone:      delivery.Address.Phone,
+			Country:    delivery.Address.Country,
+			City:       delivery.Address.City,
+			Street:     delivery.Address.Street,
+			Building:   delivery.Address.Building,
+			Apartment:  delivery.Address.Apartment,
+			PostalCode: delivery.Address.PostalCode,
+			Comment:    delivery.Address.Comment,
+		},
+		Method: model.ShippingMethod(delivery.Method),
+	}, nil
+}
+
+func toAPIDelivery(delivery *model.Delivery) *api.Delivery {
+	return &api.Delivery{
+		Address: &api.DeliveryAddress{
+			Recipient:  delivery.Address.Recipient,
+			Phone:      delivery.Address.Phone,
+			Country:    delivery.Address.Country,
+			City:       delivery.Address.City,
+			Street:     delivery.Address.Street,
+			Building:   delivery.Address.Building,
+			Apartment:  delivery.Address.Apartment,
+			PostalCode: delivery.Address.PostalCode,
+			Comment:    delivery.Address.Comment,
+		},
+		Method: api.ShippingMethod(delivery.Method),
+	}
+}
+
+func fromAPIPromoCode(promoCode *api.PromoCode) (model.PromoCode, error) {
+	result := model.PromoCode{
+		Code:               promoCode.Code,
+		MaxUses:            int(promoCode.MaxUses),
+		MaxUsesPerCustomer: int(promoCode.MaxUsesPerCustomer),
+	}
+	switch discount := promoCode.Discount.(type) {
+	case *api.PromoCode_Percent:
+		result.Discount.Percent = int(discount.Percent)
+	case *api.PromoCode_Amount:
+		amount, err := fromAPIMoney(discount.Amount)
+		if err != nil {
+			return model.PromoCode{}, err
+		}
+		result.Discount.Amount = &amount
+	default:
+		return model.PromoCode{}, status.Error(codes.InvalidArgument, "discount is required")
+	}
+	if promoCode.ValidFrom != nil {
+		validFrom := promoCode.ValidFrom.AsTime()
+		result.ValidFrom = &validFrom
+	}
+	if promoCode.ValidTo != nil {
+		validTo := promoCode.ValidTo.AsTime()
+		result.ValidTo = &validTo
+	}
+	return result, nil
+}
+
+func toAPIPromoCode(promoCode *query.PromoCode) *api.PromoCode {
+	result := &api.PromoCode{
+		Code:               promoCode.Code,
+		MaxUses:            int32(promoCode.MaxUses),
+		MaxUsesPerCustomer: int32(promoCode.MaxUsesPerCustomer),
+		Uses:               int32(promoCode.Uses),
+		CreatedAt:          timestamppb.New(promoCode.CreatedAt),
+	}
+	if promoCode.Discount.Amount != nil {
+		result.Discount = &api.PromoCode_Amount{Amount: toAPIMoney(*promoCode.Discount.Amount)}
+	} else {
+		result.Discount = &api.PromoCode_Percent{Percent: int32(promoCode.Discount.Percent)}
+	}
+	if promoCode.ValidFrom != nil {
+		result.ValidFrom = timestamppb.New(*promoCode.ValidFrom)
+	}
+	if promoCode.ValidTo != nil {
+		result.ValidTo = timestamppb.New(*promoCode.ValidTo)
+	}
+	return result
+}
+
+func toAPICheckout(checkout *query.Checkout) *api.Checkout {
+	result := &api.Checkout{
+		CheckoutID:    checkout.ID.String(),
+		OrderID:       checkout.OrderID.String(),
+		CustomerID:    checkout.CustomerID.String(),
+		Amount:        toAPIMoney(checkout.Amount),
+		State:         api.CheckoutState(checkout.State),
+		FailureReason: checkout.FailureReason,
+		CreatedAt:     timestamppb.New(checkout.CreatedAt),
+		UpdatedAt:     timestamppb.New(checkout.UpdatedAt),
+	}
+	if checkout.PaymentID != uuid.Nil {
+		result.PaymentID = checkout.PaymentID.String()
+	}
+	return result
+}
+
+func toAPIReturn(ret *query.Return) *api.Return {
+	items := make([]*api.ReturnItem, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		items = append(items, &api.ReturnItem{
+			ItemID:    item.ItemID.String(),
+			ProductID: item.ProductID.String(),
+			Name:      item.Name,
+			Price:     toAPIMoney(item.Price),
+			Quantity:  int32(item.Quantity),
+			Refund:    toAPIMoney(item.Refund),
+		})
+	}
+
+	return &api.Return{
+		ReturnID:     ret.ID.String(),
+		OrderID:      ret.OrderID.String(),
+		CustomerID:   ret.CustomerID.String(),
+		Status:       api.ReturnStatus(ret.Status),
+		Items:        items,
+		Reason:       ret.Reason,
+		RefundAmount: toAPIMoney(ret.RefundAmount),
+		CreatedAt:    timestamppb.New(ret.CreatedAt),
+		UpdatedAt:    timestamppb.New(ret.UpdatedAt),
+	}
+}
+
+func toAPIShipment(shipment *query.Shipment) *api.Shipment {
+	items := make([]*api.ShipmentItem, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		items = append(items, &api.ShipmentItem{
+			ItemID:    item.ItemID.String(),
+			ProductID: item.ProductID.String(),
+			Name:      item.Name,
+			Price:     toAPIMoney(item.Price),
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	result := &api.Shipment{
+		ShipmentID:     shipment.ID.String(),
+		OrderID:        shipment.OrderID.String(),
+		CustomerID:     shipment.CustomerID.String(),
+		Status:         api.ShipmentStatus(shipment.Status),
+		Items:          items,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		CreatedAt:      timestamppb.New(shipment.CreatedAt),
+		UpdatedAt:      timestamppb.New(shipment.UpdatedAt),
+	}
+	if shipment.ShippedAt != nil {
+		result.ShippedAt = timestamppb.New(*shipment.ShippedAt)
+	}
+	if shipment.DeliveredAt != nil {
+		result.DeliveredAt = timestamppb.New(*shipment.DeliveredAt)
+	}
+	return result
+}
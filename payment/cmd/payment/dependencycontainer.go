@@ -1,16 +1,100 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	commonamqp "common/amqp"
+	"common/idempotency"
+	commonmysql "common/mysql"
+	"common/outbox"
+	"common/retention"
+
+	"payment/pkg/application/query"
+	appservice "payment/pkg/application/service"
+	"payment/pkg/infrastructure/event"
+	"payment/pkg/infrastructure/inbox"
+	"payment/pkg/infrastructure/mysql"
+	"payment/pkg/infrastructure/transport"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
+	logger *log.Logger,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	serializer := event.NewJSONSerializer()
+	uow := mysql.NewUnitOfWork(connContainer.db, serializer)
+	luow := mysql.NewLockableUnitOfWork(connContainer.db, serializer, config.LockTimeout)
+
+	idempotencyStorage := commonmysql.NewIdempotencyStorage(connContainer.db)
+
+	publisher, err := commonamqp.NewPublisher(connContainer.amqpConnection, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderEventConsumer, err := commonamqp.NewConsumer(
+		connContainer.amqpConnection,
+		commonamqp.ConsumerConfig{
+			Queue:             config.OrderEventsQueue,
+			Source:            "order",
+			EventTypes:        inbox.OrderEventTypes,
+			Prefetch:          config.ConsumerPrefetch,
+			RetryDelay:        config.ConsumerRetryDelay,
+			ReconnectDelay:    config.ConsumerReconnectDelay,
+			LagReportInterval: config.ConsumerLagReportInterval,
+		},
+		inbox.NewOrderEventHandler(appservice.NewOrderEventService(luow)),
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		db:                  connContainer.db,
+		PaymentService:      appservice.NewPaymentService(uow, luow),
+		PaymentQueryService: mysql.NewPaymentQueryService(connContainer.db),
+		OutboxRelay: outbox.NewRelay(
+			commonmysql.NewOutboxStorage(connContainer.db),
+			publisher,
+			logger,
+			config.OutboxRelayInterval,
+			config.OutboxBatchSize,
+		),
+		IdempotencyInterceptor: idempotency.NewInterceptor(
+			idempotencyStorage,
+			logger,
+			config.IdempotencyRetention,
+			config.IdempotencyPendingTimeout,
+			transport.IdempotentMethods,
+		),
+		IdempotencyCleaner: retention.NewCleaner(
+			idempotencyStorage,
+			logger.WithField("table", "idempotency_keys"),
+			config.IdempotencyCleanupInterval,
+			config.IdempotencyRetention,
+		),
+		InboxCleaner: retention.NewCleaner(
+			commonmysql.NewInboxStorage(connContainer.db),
+			logger.WithField("table", "inbox"),
+			config.InboxCleanupInterval,
+			config.InboxRetention,
+		),
+		OrderEventConsumer: orderEventConsumer,
 	}, nil
 }
 
 type dependencyContainer struct {
 	db *sqlx.DB
+
+	PaymentService      appservice.PaymentService
+	PaymentQueryService query.PaymentQueryService
+	OutboxRelay         *outbox.Relay
+	OrderEventConsumer  *commonamqp.Consumer
+	InboxCleaner        *retention.Cleaner
+
+	IdempotencyInterceptor *idempotency.Interceptor
+	IdempotencyCleaner     *retention.Cleaner
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
 
+	"common/idempotency"
+
 	api "payment/api/server/paymentinternal"
 	"payment/pkg/infrastructure/transport"
 )
@@ -30,10 +32,16 @@ func service(
 				return errors.Wrap(err, "failed to init connections")
 			}
 
-			container, err := newDependencyContainer(config, connContainer)
+			container, err := newDependencyContainer(config, logger, connContainer)
 			if err != nil {
 				return errors.Wrap(err, "failed to init dependencies")
 			}
+
+			go container.OutboxRelay.Run(c.Context)
+			go container.IdempotencyCleaner.Run(c.Context)
+			go container.OrderEventConsumer.Run(c.Context)
+			go container.InboxCleaner.Run(c.Context)
+
 			return startGRPCServer(c.Context, config, logger, container)
 		},
 	}
@@ -43,11 +51,14 @@ func startGRPCServer(
 	ctx context.Context,
 	config *config,
 	logger *log.Logger,
-	_ *dependencyContainer,
+	container *dependencyContainer,
 ) error {
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger)))
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(makeGrpcUnaryInterceptor(logger, container.IdempotencyInterceptor)))
 
-	api.RegisterPaymentInternalServiceServer(grpcServer, transport.NewInternalAPI())
+	api.RegisterPaymentInternalServiceServer(grpcServer, transport.NewInternalAPI(
+		container.PaymentService,
+		container.PaymentQueryService,
+	))
 
 	listener, err := net.Listen("tcp", config.ServeGRPCAddress)
 	if err != nil {
@@ -86,11 +97,16 @@ func shutdownGRPCServer(server *grpc.Server, logger *log.Logger) {
 	}
 }
 
-func makeGrpcUnaryInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
+func makeGrpcUnaryInterceptor(
+	logger *log.Logger,
+	idempotencyInterceptor *idempotency.Interceptor,
+) grpc.UnaryServerInterceptor {
 	loggerInterceptor := transport.MakeLoggerServerInterceptor(logger)
 	errorInterceptor := transport.ErrorInterceptor{Logger: logger}
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		resp, err = loggerInterceptor(ctx, req, info, handler)
+		resp, err = loggerInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return idempotencyInterceptor.Intercept(ctx, req, info, handler)
+		})
 		return resp, errorInterceptor.TranslateGRPCError(err)
 	}
 }
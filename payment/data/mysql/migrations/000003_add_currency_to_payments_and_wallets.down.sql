@@ -0,0 +1,5 @@
+ALTER TABLE wallets
+    DROP COLUMN `currency`;
+
+ALTER TABLE payments
+    DROP COLUMN `currency`;
\ No newline at end of file
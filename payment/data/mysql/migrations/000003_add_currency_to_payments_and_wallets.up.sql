@@ -0,0 +1,5 @@
+ALTER TABLE payments
+    ADD COLUMN `currency` CHAR(3) NOT NULL DEFAULT 'RUB' AFTER `amount`;
+
+ALTER TABLE wallets
+    ADD COLUMN `currency` CHAR(3) NOT NULL DEFAULT 'RUB' AFTER `balance`;
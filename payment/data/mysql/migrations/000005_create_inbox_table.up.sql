@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS inbox
+(
+    `event_id`     CHAR(36) NOT NULL,
+    `event_type`   VARCHAR(100) NOT NULL,
+    `processed_at` DATETIME(6) NOT NULL,
+    PRIMARY KEY (`event_id`),
+    INDEX `idx_processed_at` (`processed_at`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    `idempotency_key` VARCHAR(255) COLLATE utf8mb4_bin NOT NULL,
+    `method`          VARCHAR(255) NOT NULL,
+    `request_hash`    CHAR(64)     NOT NULL,
+    `response`        MEDIUMBLOB   NULL DEFAULT NULL,
+    `created_at`      DATETIME(6)  NOT NULL,
+    PRIMARY KEY (`idempotency_key`),
+    INDEX `idx_created_at` (`created_at`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
@@ -0,0 +1,6 @@
+ALTER TABLE payments
+    ADD COLUMN `refunded_amount` DECIMAL(10,2) NOT NULL DEFAULT 0.00 AFTER `currency`;
+
+UPDATE payments
+SET refunded_amount = amount
+WHERE status = 3;
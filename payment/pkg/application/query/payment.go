@@ -0,0 +1,25 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"payment/pkg/domain/model"
+)
+
+type Payment struct {
+	ID            uuid.UUID
+	OrderID       uuid.UUID
+	UserID        uuid.UUID
+	Amount        model.Money
+	Status        model.PaymentStatus
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type PaymentQueryService interface {
+	FindPayment(ctx context.Context, paymentID uuid.UUID) (*Payment, error)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"payment/pkg/domain/model"
+	domainservice "payment/pkg/domain/service"
+)
+
+// OrderEventService применяет события сервиса order. Каждое событие обрабатывается один раз:
+// его ID фиксируется в inbox в одной транзакции с изменением платежа. События одного заказа
+// обрабатываются под блокировкой его платежа, чтобы частичные возвраты и отмена
+// не вернули одну и ту же сумму дважды
+type OrderEventService interface {
+	// OrderCancelled возвращает невозвращённый остаток платежа за оплаченный заказ, если refundRequired
+	OrderCancelled(ctx context.Context, eventID string, orderID uuid.UUID, refundRequired bool) error
+	// OrderReturnRefundRequested возвращает часть платежа заказа за полученный возврат товаров
+	OrderReturnRefundRequested(ctx context.Context, eventID string, orderID uuid.UUID, refundAmount model.Money) error
+}
+
+func NewOrderEventService(luow LockableUnitOfWork) OrderEventService {
+	return &orderEventService{luow: luow}
+}
+
+type orderEventService struct {
+	luow LockableUnitOfWork
+}
+
+func (s *orderEventService) OrderCancelled(ctx context.Context, eventID string, orderID uuid.UUID, refundRequired bool) error {
+	return s.luow.Execute(ctx, orderPaymentLockName(orderID), func(provider RepositoryProvider) error {
+		isNew, err := provider.Inbox(ctx).MarkProcessed(eventID, "OrderCancelled")
+		if err != nil || !isNew || !refundRequired {
+			return err
+		}
+
+		return domainservice.NewPaymentService(
+			provider.PaymentRepository(ctx),
+			provider.EventDispatcher(ctx),
+		).RefundOrderPayment(orderID)
+	})
+}
+
+func (s *orderEventService) OrderReturnRefundRequested(ctx context.Context, eventID string, orderID uuid.UUID, refundAmount model.Money) error {
+	return s.luow.Execute(ctx, orderPaymentLockName(orderID), func(provider RepositoryProvider) error {
+		isNew, err := provider.Inbox(ctx).MarkProcessed(eventID, "OrderReturnRefundRequested")
+		if err != nil || !isNew {
+			return err
+		}
+
+		return domainservice.NewPaymentService(
+			provider.PaymentRepository(ctx),
+			provider.EventDispatcher(ctx),
+		).RefundReturn(orderID, refundAmount)
+	})
+}
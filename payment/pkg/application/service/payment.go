@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"payment/pkg/domain/model"
+	domainservice "payment/pkg/domain/service"
+)
+
+type PaymentService interface {
+	CreateWallet(ctx context.Context, userID uuid.UUID, initialBalance model.Money) (uuid.UUID, error)
+	InitiatePayment(ctx context.Context, orderID, userID uuid.UUID, amount model.Money) (uuid.UUID, error)
+	ProcessPayment(ctx context.Context, paymentID uuid.UUID) error
+	RefundPayment(ctx context.Context, paymentID uuid.UUID) error
+}
+
+func NewPaymentService(uow UnitOfWork, luow LockableUnitOfWork) PaymentService {
+	return &paymentService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type paymentService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+func (s *paymentService) CreateWallet(ctx context.Context, userID uuid.UUID, initialBalance model.Money) (walletID uuid.UUID, err error) {
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		walletID, err = s.domainService(ctx, provider).CreateWallet(userID, initialBalance)
+		return err
+	})
+	return walletID, err
+}
+
+// InitiatePayment выполняется под блокировкой заказа: проверка уже созданного платежа
+// и вставка нового иначе не атомарны, и параллельные запросы создали бы два платежа
+func (s *paymentService) InitiatePayment(ctx context.Context, orderID, userID uuid.UUID, amount model.Money) (paymentID uuid.UUID, err error) {
+	err = s.luow.Execute(ctx, orderPaymentLockName(orderID), func(provider RepositoryProvider) error {
+		paymentID, err = s.domainService(ctx, provider).InitiatePayment(orderID, userID, amount)
+		return err
+	})
+	return paymentID, err
+}
+
+func (s *paymentService) ProcessPayment(ctx context.Context, paymentID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ProcessPayment(paymentID)
+	})
+}
+
+func (s *paymentService) RefundPayment(ctx context.Context, paymentID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RefundPayment(paymentID)
+	})
+}
+
+func (s *paymentService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Payment {
+	return domainservice.NewPaymentService(provider.PaymentRepository(ctx), provider.EventDispatcher(ctx))
+}
+
+func orderPaymentLockName(orderID uuid.UUID) string {
+	return "payment_order_" + orderID.String()
+}
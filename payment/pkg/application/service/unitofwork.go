@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"payment/pkg/domain/model"
+	domainservice "payment/pkg/domain/service"
+)
+
+var (
+	ErrLockTimeout = errors.New("timed out waiting for lock")
+)
+
+type RepositoryProvider interface {
+	PaymentRepository(ctx context.Context) model.PaymentRepository
+	EventDispatcher(ctx context.Context) domainservice.EventDispatcher
+	Inbox(ctx context.Context) Inbox
+}
+
+// Inbox - учёт обработанных входящих событий для идемпотентной обработки повторных доставок
+type Inbox interface {
+	// MarkProcessed запоминает событие и возвращает false, если оно уже было обработано
+	MarkProcessed(eventID, eventType string) (bool, error)
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
+
+type LockableUnitOfWork interface {
+	Execute(ctx context.Context, lockName string, f func(provider RepositoryProvider) error) error
+}
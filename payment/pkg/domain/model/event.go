@@ -6,7 +6,7 @@ type PaymentInitiated struct {
 	PaymentID uuid.UUID
 	OrderID   uuid.UUID
 	UserID    uuid.UUID
-	Amount    float64
+	Amount    Money
 }
 
 func (e PaymentInitiated) Type() string {
@@ -33,3 +33,14 @@ type PaymentFailed struct {
 func (e PaymentFailed) Type() string {
 	return "PaymentFailed"
 }
+
+type PaymentRefunded struct {
+	PaymentID uuid.UUID
+	OrderID   uuid.UUID
+	UserID    uuid.UUID
+	Amount    Money
+}
+
+func (e PaymentRefunded) Type() string {
+	return "PaymentRefunded"
+}
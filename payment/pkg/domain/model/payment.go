@@ -10,6 +10,8 @@ import (
 var (
 	ErrPaymentNotFound = errors.New("payment not found")
 	ErrWalletNotFound  = errors.New("wallet not found")
+	// ErrVersionConflict - кошелёк изменили после того, как его прочитали, операцию можно повторить
+	ErrVersionConflict = errors.New("wallet was modified concurrently")
 )
 
 type PaymentStatus int
@@ -18,31 +20,40 @@ const (
 	Pending PaymentStatus = iota
 	Completed
 	Failed
+	Refunded
 )
 
 type Payment struct {
-	ID            uuid.UUID
-	OrderID       uuid.UUID
-	UserID        uuid.UUID
-	Amount        float64
-	Status        PaymentStatus
-	FailureReason *string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID      uuid.UUID
+	OrderID uuid.UUID
+	UserID  uuid.UUID
+	Amount  Money
+	// RefundedAmount - сколько уже возвращено на кошелёк, в том числе частично за возвраты товаров.
+	// Платёж переходит в Refunded, когда возвращена вся сумма
+	RefundedAmount Money
+	Status         PaymentStatus
+	FailureReason  *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type Wallet struct {
 	ID        uuid.UUID
 	UserID    uuid.UUID
-	Balance   float64
+	Balance   Money
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// Version не даёт двум параллельным операциям перезаписать баланс друг друга, 0 - кошелёк ещё не сохранён
+	Version int
 }
 
 type PaymentRepository interface {
 	NextID() (uuid.UUID, error)
 	StorePayment(payment *Payment) error
 	FindPayment(id uuid.UUID) (*Payment, error)
+	// FindPaymentByOrderID возвращает последний платёж заказа в указанном статусе
+	// или ErrPaymentNotFound, если такого платежа нет
+	FindPaymentByOrderID(orderID uuid.UUID, status PaymentStatus) (*Payment, error)
 	StoreWallet(wallet *Wallet) error
 	FindWalletByUserID(userID uuid.UUID) (*Wallet, error)
 }
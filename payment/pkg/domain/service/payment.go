@@ -11,6 +11,8 @@ import (
 
 var (
 	ErrPaymentAlreadyProcessed = errors.New("payment has already been processed")
+	ErrPaymentInProgress       = errors.New("order already has a pending payment with another amount")
+	ErrPaymentNotRefundable    = errors.New("only completed payment can be refunded")
 )
 
 type Event interface {
@@ -22,9 +24,17 @@ type EventDispatcher interface {
 }
 
 type Payment interface {
-	CreateWallet(userID uuid.UUID, initialBalance float64) (uuid.UUID, error)
-	InitiatePayment(orderID, userID uuid.UUID, amount float64) (uuid.UUID, error)
+	CreateWallet(userID uuid.UUID, initialBalance model.Money) (uuid.UUID, error)
+	InitiatePayment(orderID, userID uuid.UUID, amount model.Money) (uuid.UUID, error)
 	ProcessPayment(paymentID uuid.UUID) error
+	RefundPayment(paymentID uuid.UUID) error
+	// RefundOrderPayment возвращает остаток проведённого платежа отменённого заказа:
+	// суммы, уже возвращённые за возвраты товаров, повторно не возвращаются.
+	// Если проведённого платежа нет (не оплачен или уже возвращён), ничего не делает
+	RefundOrderPayment(orderID uuid.UUID) error
+	// RefundReturn возвращает на кошелёк часть платежа заказа за полученный возврат товаров.
+	// Сумма ограничивается невозвращённым остатком платежа. Если проведённого платежа нет, ничего не делает
+	RefundReturn(orderID uuid.UUID, amount model.Money) error
 }
 
 func NewPaymentService(repo model.PaymentRepository, dispatcher EventDispatcher) Payment {
@@ -39,7 +49,11 @@ type paymentService struct {
 	dispatcher EventDispatcher
 }
 
-func (s *paymentService) CreateWallet(userID uuid.UUID, initialBalance float64) (uuid.UUID, error) {
+func (s *paymentService) CreateWallet(userID uuid.UUID, initialBalance model.Money) (uuid.UUID, error) {
+	if initialBalance.IsNegative() {
+		return uuid.Nil, model.ErrInvalidAmount
+	}
+
 	walletID, err := s.repo.NextID()
 	if err != nil {
 		return uuid.Nil, err
@@ -55,8 +69,28 @@ func (s *paymentService) CreateWallet(userID uuid.UUID, initialBalance float64)
 	return walletID, s.repo.StoreWallet(wallet)
 }
 
-func (s *paymentService) InitiatePayment(orderID, userID uuid.UUID, amount float64) (uuid.UUID, error) {
-	if _, err := s.repo.FindWalletByUserID(userID); err != nil {
+func (s *paymentService) InitiatePayment(orderID, userID uuid.UUID, amount model.Money) (uuid.UUID, error) {
+	if amount.IsNegative() || amount.IsZero() {
+		return uuid.Nil, model.ErrInvalidAmount
+	}
+
+	wallet, err := s.repo.FindWalletByUserID(userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if wallet.Balance.Currency != amount.Currency {
+		return uuid.Nil, model.ErrCurrencyMismatch
+	}
+
+	// Повторный запрос на оплату заказа (например, ретрай после сбоя) возвращает уже созданный платёж
+	pendingPayment, err := s.repo.FindPaymentByOrderID(orderID, model.Pending)
+	if err == nil {
+		if pendingPayment.UserID != userID || pendingPayment.Amount != amount {
+			return uuid.Nil, ErrPaymentInProgress
+		}
+		return pendingPayment.ID, nil
+	}
+	if !errors.Is(err, model.ErrPaymentNotFound) {
 		return uuid.Nil, err
 	}
 
@@ -67,13 +101,14 @@ func (s *paymentService) InitiatePayment(orderID, userID uuid.UUID, amount float
 
 	currentTime := time.Now()
 	payment := &model.Payment{
-		ID:        paymentID,
-		OrderID:   orderID,
-		UserID:    userID,
-		Amount:    amount,
-		Status:    model.Pending,
-		CreatedAt: currentTime,
-		UpdatedAt: currentTime,
+		ID:             paymentID,
+		OrderID:        orderID,
+		UserID:         userID,
+		Amount:         amount,
+		RefundedAmount: model.Money{Currency: amount.Currency},
+		Status:         model.Pending,
+		CreatedAt:      currentTime,
+		UpdatedAt:      currentTime,
 	}
 
 	if err := s.repo.StorePayment(payment); err != nil {
@@ -103,7 +138,12 @@ func (s *paymentService) ProcessPayment(paymentID uuid.UUID) error {
 		return err
 	}
 
-	if wallet.Balance < payment.Amount {
+	insufficientFunds, err := wallet.Balance.LessThan(payment.Amount)
+	if err != nil {
+		return err
+	}
+
+	if insufficientFunds {
 		reason := "insufficient funds"
 		payment.Status = model.Failed
 		payment.FailureReason = &reason
@@ -119,7 +159,10 @@ func (s *paymentService) ProcessPayment(paymentID uuid.UUID) error {
 		})
 	}
 
-	wallet.Balance -= payment.Amount
+	wallet.Balance, err = wallet.Balance.Sub(payment.Amount)
+	if err != nil {
+		return err
+	}
 	wallet.UpdatedAt = time.Now()
 	if err := s.repo.StoreWallet(wallet); err != nil {
 		return err
@@ -138,3 +181,111 @@ func (s *paymentService) ProcessPayment(paymentID uuid.UUID) error {
 		UserID:    payment.UserID,
 	})
 }
+
+// RefundPayment возвращает деньги за проведённый платёж на кошелёк.
+// Повторный возврат уже возвращённого платежа ничего не делает
+func (s *paymentService) RefundPayment(paymentID uuid.UUID) error {
+	payment, err := s.repo.FindPayment(paymentID)
+	if err != nil {
+		return err
+	}
+
+	switch payment.Status {
+	case model.Refunded:
+		return nil
+	case model.Completed:
+	default:
+		return ErrPaymentNotRefundable
+	}
+
+	return s.refundRemainder(payment)
+}
+
+func (s *paymentService) RefundOrderPayment(orderID uuid.UUID) error {
+	payment, err := s.repo.FindPaymentByOrderID(orderID, model.Completed)
+	if errors.Is(err, model.ErrPaymentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.refundRemainder(payment)
+}
+
+func (s *paymentService) RefundReturn(orderID uuid.UUID, amount model.Money) error {
+	if amount.IsNegative() || amount.IsZero() {
+		return model.ErrInvalidAmount
+	}
+
+	payment, err := s.repo.FindPaymentByOrderID(orderID, model.Completed)
+	if errors.Is(err, model.ErrPaymentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	remainder, err := payment.Amount.Sub(payment.RefundedAmount)
+	if err != nil {
+		return err
+	}
+	exceedsRemainder, err := remainder.LessThan(amount)
+	if err != nil {
+		return err
+	}
+	if exceedsRemainder {
+		amount = remainder
+	}
+
+	return s.refund(payment, amount)
+}
+
+func (s *paymentService) refundRemainder(payment *model.Payment) error {
+	remainder, err := payment.Amount.Sub(payment.RefundedAmount)
+	if err != nil {
+		return err
+	}
+
+	return s.refund(payment, remainder)
+}
+
+// refund возвращает amount проведённого платежа на кошелёк. Когда возвращена вся сумма, платёж становится Refunded
+func (s *paymentService) refund(payment *model.Payment, amount model.Money) error {
+	if amount.IsZero() {
+		return nil
+	}
+
+	wallet, err := s.repo.FindWalletByUserID(payment.UserID)
+	if err != nil {
+		return err
+	}
+
+	wallet.Balance, err = wallet.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	wallet.UpdatedAt = time.Now()
+	if err := s.repo.StoreWallet(wallet); err != nil {
+		return err
+	}
+
+	payment.RefundedAmount, err = payment.RefundedAmount.Add(amount)
+	if err != nil {
+		return err
+	}
+	if payment.RefundedAmount == payment.Amount {
+		payment.Status = model.Refunded
+	}
+	payment.UpdatedAt = time.Now()
+	if err := s.repo.StorePayment(payment); err != nil {
+		return err
+	}
+
+	return s.dispatcher.Dispatch(model.PaymentRefunded{
+		PaymentID: payment.ID,
+		OrderID:   payment.OrderID,
+		UserID:    payment.UserID,
+		Amount:    amount,
+	})
+}
@@ -34,11 +34,11 @@ func setup() testFixture {
 func TestPaymentService(t *testing.T) {
 	userID := uuid.Must(uuid.NewV7())
 	orderID := uuid.Must(uuid.NewV7())
-	paymentAmount := 99.99
+	paymentAmount := rub(9999)
 
 	t.Run("Initiate payment", func(t *testing.T) {
 		f := setup()
-		_, _ = f.paymentService.CreateWallet(userID, 200.00)
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
 
 		paymentID, err := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
 
@@ -51,7 +51,7 @@ func TestPaymentService(t *testing.T) {
 
 	t.Run("Process successful payment", func(t *testing.T) {
 		f := setup()
-		initialBalance := 200.00
+		initialBalance := rub(20000)
 		_, _ = f.paymentService.CreateWallet(userID, initialBalance)
 		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
 		f.eventDispatcher.events = nil
@@ -61,14 +61,14 @@ func TestPaymentService(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, model.Completed, f.repo.paymentStore[paymentID].Status)
 		userWallet, _ := f.repo.FindWalletByUserID(userID)
-		require.Equal(t, initialBalance-paymentAmount, userWallet.Balance)
+		require.Equal(t, rub(10001), userWallet.Balance)
 		require.Len(t, f.eventDispatcher.events, 1)
 		require.Equal(t, model.PaymentCompleted{}.Type(), f.eventDispatcher.events[0].Type())
 	})
 
 	t.Run("Process failed payment due to insufficient funds", func(t *testing.T) {
 		f := setup()
-		initialBalance := 50.00
+		initialBalance := rub(5000)
 		_, _ = f.paymentService.CreateWallet(userID, initialBalance)
 		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
 		f.eventDispatcher.events = nil
@@ -86,7 +86,7 @@ func TestPaymentService(t *testing.T) {
 
 	t.Run("Fail to process already completed payment", func(t *testing.T) {
 		f := setup()
-		_, _ = f.paymentService.CreateWallet(userID, 200.00)
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
 		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
 		_ = f.paymentService.ProcessPayment(paymentID)
 		f.eventDispatcher.events = nil
@@ -96,6 +96,179 @@ func TestPaymentService(t *testing.T) {
 		require.ErrorIs(t, err, service.ErrPaymentAlreadyProcessed)
 		require.Empty(t, f.eventDispatcher.events)
 	})
+
+	t.Run("Debit wallet without rounding errors", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(30))
+		for range 3 {
+			paymentID, err := f.paymentService.InitiatePayment(orderID, userID, rub(10))
+			require.NoError(t, err)
+			require.NoError(t, f.paymentService.ProcessPayment(paymentID))
+		}
+
+		userWallet, _ := f.repo.FindWalletByUserID(userID)
+		require.True(t, userWallet.Balance.IsZero())
+	})
+
+	t.Run("Return pending payment on repeated initiation", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		f.eventDispatcher.events = nil
+
+		repeatedPaymentID, err := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+
+		require.NoError(t, err)
+		require.Equal(t, paymentID, repeatedPaymentID)
+		require.Len(t, f.repo.paymentStore, 1)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to initiate payment with another amount while one is pending", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
+		_, _ = f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+
+		_, err := f.paymentService.InitiatePayment(orderID, userID, rub(100))
+
+		require.ErrorIs(t, err, service.ErrPaymentInProgress)
+	})
+
+	t.Run("Refund completed payment", func(t *testing.T) {
+		f := setup()
+		initialBalance := rub(20000)
+		_, _ = f.paymentService.CreateWallet(userID, initialBalance)
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		_ = f.paymentService.ProcessPayment(paymentID)
+		f.eventDispatcher.events = nil
+
+		err := f.paymentService.RefundPayment(paymentID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Refunded, f.repo.paymentStore[paymentID].Status)
+		userWallet, _ := f.repo.FindWalletByUserID(userID)
+		require.Equal(t, initialBalance, userWallet.Balance)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, model.PaymentRefunded{}.Type(), f.eventDispatcher.events[0].Type())
+
+		require.NoError(t, f.paymentService.RefundPayment(paymentID))
+		require.Len(t, f.eventDispatcher.events, 1)
+	})
+
+	t.Run("Refund payment of cancelled order", func(t *testing.T) {
+		f := setup()
+		initialBalance := rub(20000)
+		_, _ = f.paymentService.CreateWallet(userID, initialBalance)
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		_ = f.paymentService.ProcessPayment(paymentID)
+		f.eventDispatcher.events = nil
+
+		err := f.paymentService.RefundOrderPayment(orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Refunded, f.repo.paymentStore[paymentID].Status)
+		userWallet, _ := f.repo.FindWalletByUserID(userID)
+		require.Equal(t, initialBalance, userWallet.Balance)
+
+		require.NoError(t, f.paymentService.RefundOrderPayment(orderID))
+		require.Len(t, f.eventDispatcher.events, 1)
+	})
+
+	t.Run("Skip refund of unpaid order", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		f.eventDispatcher.events = nil
+
+		err := f.paymentService.RefundOrderPayment(orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Pending, f.repo.paymentStore[paymentID].Status)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Fail to refund pending payment", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+
+		err := f.paymentService.RefundPayment(paymentID)
+
+		require.ErrorIs(t, err, service.ErrPaymentNotRefundable)
+	})
+
+	t.Run("Refund part of payment for received return", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		_ = f.paymentService.ProcessPayment(paymentID)
+		f.eventDispatcher.events = nil
+
+		err := f.paymentService.RefundReturn(orderID, rub(3000))
+
+		require.NoError(t, err)
+		payment := f.repo.paymentStore[paymentID]
+		require.Equal(t, model.Completed, payment.Status)
+		require.Equal(t, rub(3000), payment.RefundedAmount)
+		userWallet, _ := f.repo.FindWalletByUserID(userID)
+		require.Equal(t, rub(20000-9999+3000), userWallet.Balance)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, rub(3000), f.eventDispatcher.events[0].(model.PaymentRefunded).Amount)
+	})
+
+	t.Run("Refund only remainder when order with returns is cancelled", func(t *testing.T) {
+		f := setup()
+		initialBalance := rub(20000)
+		_, _ = f.paymentService.CreateWallet(userID, initialBalance)
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		_ = f.paymentService.ProcessPayment(paymentID)
+		require.NoError(t, f.paymentService.RefundReturn(orderID, rub(3000)))
+		f.eventDispatcher.events = nil
+
+		err := f.paymentService.RefundOrderPayment(orderID)
+
+		require.NoError(t, err)
+		require.Equal(t, model.Refunded, f.repo.paymentStore[paymentID].Status)
+		userWallet, _ := f.repo.FindWalletByUserID(userID)
+		require.Equal(t, initialBalance, userWallet.Balance)
+		require.Len(t, f.eventDispatcher.events, 1)
+		require.Equal(t, rub(9999-3000), f.eventDispatcher.events[0].(model.PaymentRefunded).Amount)
+
+		require.NoError(t, f.paymentService.RefundReturn(orderID, rub(1000)))
+		userWallet, _ = f.repo.FindWalletByUserID(userID)
+		require.Equal(t, initialBalance, userWallet.Balance)
+	})
+
+	t.Run("Limit return refund to not refunded remainder", func(t *testing.T) {
+		f := setup()
+		initialBalance := rub(20000)
+		_, _ = f.paymentService.CreateWallet(userID, initialBalance)
+		paymentID, _ := f.paymentService.InitiatePayment(orderID, userID, paymentAmount)
+		_ = f.paymentService.ProcessPayment(paymentID)
+		require.NoError(t, f.paymentService.RefundReturn(orderID, rub(9000)))
+
+		err := f.paymentService.RefundReturn(orderID, rub(3000))
+
+		require.NoError(t, err)
+		require.Equal(t, model.Refunded, f.repo.paymentStore[paymentID].Status)
+		require.Equal(t, paymentAmount, f.repo.paymentStore[paymentID].RefundedAmount)
+		userWallet, _ := f.repo.FindWalletByUserID(userID)
+		require.Equal(t, initialBalance, userWallet.Balance)
+	})
+
+	t.Run("Fail to initiate payment in another currency", func(t *testing.T) {
+		f := setup()
+		_, _ = f.paymentService.CreateWallet(userID, rub(20000))
+
+		_, err := f.paymentService.InitiatePayment(orderID, userID, model.Money{Amount: 100, Currency: "USD"})
+
+		require.ErrorIs(t, err, model.ErrCurrencyMismatch)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+}
+
+func rub(amount int64) model.Money {
+	return model.Money{Amount: amount, Currency: model.DefaultCurrency}
 }
 
 var _ model.PaymentRepository = &mockPaymentRepository{}
@@ -121,6 +294,15 @@ func (m *mockPaymentRepository) FindPayment(id uuid.UUID) (*model.Payment, error
 	return nil, model.ErrPaymentNotFound
 }
 
+func (m *mockPaymentRepository) FindPaymentByOrderID(orderID uuid.UUID, status model.PaymentStatus) (*model.Payment, error) {
+	for _, payment := range m.paymentStore {
+		if payment.OrderID == orderID && payment.Status == status {
+			return payment, nil
+		}
+	}
+	return nil, model.ErrPaymentNotFound
+}
+
 func (m *mockPaymentRepository) StoreWallet(wallet *model.Wallet) error {
 	m.walletStore[wallet.ID] = wallet
 	return nil
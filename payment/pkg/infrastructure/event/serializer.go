@@ -0,0 +1,18 @@
+package event
+
+import (
+	commonevent "common/event"
+	"payment/pkg/domain/model"
+	"payment/pkg/domain/service"
+)
+
+type Serializer = commonevent.Serializer[service.Event]
+
+func NewJSONSerializer() Serializer {
+	return commonevent.NewJSONSerializer[service.Event](
+		model.PaymentInitiated{},
+		model.PaymentCompleted{},
+		model.PaymentFailed{},
+		model.PaymentRefunded{},
+	)
+}
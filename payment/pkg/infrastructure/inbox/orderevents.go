@@ -0,0 +1,69 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	commoninbox "common/inbox"
+
+	"payment/pkg/application/service"
+	"payment/pkg/domain/model"
+)
+
+// OrderEventTypes - события сервиса order, на которые подписан сервис payment
+var OrderEventTypes = []string{
+	"OrderCancelled",
+	"OrderReturnRefundRequested",
+}
+
+// Поля события повторяют order/pkg/domain/model/event.go
+type orderCancelled struct {
+	OrderID        uuid.UUID
+	RefundRequired bool
+}
+
+type orderReturnRefundRequested struct {
+	OrderID      uuid.UUID
+	RefundAmount model.Money
+}
+
+func NewOrderEventHandler(orderEventService service.OrderEventService) commoninbox.Handler {
+	return &orderEventHandler{orderEventService: orderEventService}
+}
+
+type orderEventHandler struct {
+	orderEventService service.OrderEventService
+}
+
+func (h *orderEventHandler) Handle(ctx context.Context, message commoninbox.Message) error {
+	switch message.Type {
+	case "OrderCancelled":
+		event, err := deserialize[orderCancelled](message)
+		if err != nil {
+			return err
+		}
+		return h.orderEventService.OrderCancelled(ctx, message.ID, event.OrderID, event.RefundRequired)
+	case "OrderReturnRefundRequested":
+		event, err := deserialize[orderReturnRefundRequested](message)
+		if err != nil {
+			return err
+		}
+		return h.orderEventService.OrderReturnRefundRequested(ctx, message.ID, event.OrderID, event.RefundAmount)
+	default:
+		return fmt.Errorf("%w: unexpected event type %q", commoninbox.ErrInvalidMessage, message.Type)
+	}
+}
+
+func deserialize[T any](message commoninbox.Message) (T, error) {
+	var event T
+	if message.ID == "" {
+		return event, fmt.Errorf("%w: event %s has no ID", commoninbox.ErrInvalidMessage, message.Type)
+	}
+	if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+		return event, fmt.Errorf("%w: failed to deserialize event %s: %s", commoninbox.ErrInvalidMessage, message.ID, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"payment/pkg/application/query"
+	"payment/pkg/domain/model"
+)
+
+func NewPaymentQueryService(db *sqlx.DB) query.PaymentQueryService {
+	return &paymentQueryService{db: db}
+}
+
+type paymentQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *paymentQueryService) FindPayment(ctx context.Context, paymentID uuid.UUID) (*query.Payment, error) {
+	paymentQuery := `
+		SELECT id, order_id, user_id, amount, currency, status, failure_reason, created_at, updated_at
+		FROM payments
+		WHERE id = ?
+	`
+
+	var row PaymentRow
+	err := s.db.GetContext(ctx, &row, paymentQuery, paymentID.String())
+	if err == sql.ErrNoRows {
+		return nil, model.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	amount, err := model.ParseMoney(row.Amount, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment amount: %w", err)
+	}
+
+	return &query.Payment{
+		ID:            paymentID,
+		OrderID:       uuid.MustParse(row.OrderID),
+		UserID:        uuid.MustParse(row.UserID),
+		Amount:        amount,
+		Status:        model.PaymentStatus(row.Status),
+		FailureReason: row.FailureReason.String,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}, nil
+}
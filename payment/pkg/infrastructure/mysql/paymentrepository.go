@@ -1,22 +1,27 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
+	commonmysql "common/mysql"
 	"payment/pkg/domain/model"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 type PaymentRepository struct {
-	db *sqlx.DB
+	ctx    context.Context
+	client ClientContext
 }
 
-func NewPaymentRepository(db *sqlx.DB) *PaymentRepository {
-	return &PaymentRepository{db: db}
+func NewPaymentRepository(ctx context.Context, client ClientContext) *PaymentRepository {
+	return &PaymentRepository{
+		ctx:    ctx,
+		client: client,
+	}
 }
 
 func (r *PaymentRepository) NextID() (uuid.UUID, error) {
@@ -25,12 +30,14 @@ func (r *PaymentRepository) NextID() (uuid.UUID, error) {
 
 func (r *PaymentRepository) StorePayment(payment *model.Payment) error {
 	query := `
-		INSERT INTO payments (id, order_id, user_id, amount, status, failure_reason, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO payments (id, order_id, user_id, amount, currency, refunded_amount, status, failure_reason, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 			order_id = VALUES(order_id),
 			user_id = VALUES(user_id),
 			amount = VALUES(amount),
+			currency = VALUES(currency),
+			refunded_amount = VALUES(refunded_amount),
 			status = VALUES(status),
 			failure_reason = VALUES(failure_reason),
 			updated_at = VALUES(updated_at)
@@ -41,11 +48,13 @@ func (r *PaymentRepository) StorePayment(payment *model.Payment) error {
 		failureReason = payment.FailureReason
 	}
 
-	_, err := r.db.Exec(query,
+	_, err := r.client.ExecContext(r.ctx, query,
 		payment.ID.String(),
 		payment.OrderID.String(),
 		payment.UserID.String(),
-		payment.Amount,
+		payment.Amount.Decimal(),
+		payment.Amount.Currency,
+		payment.RefundedAmount.Decimal(),
 		int(payment.Status),
 		failureReason,
 		payment.CreatedAt,
@@ -60,14 +69,14 @@ func (r *PaymentRepository) StorePayment(payment *model.Payment) error {
 
 func (r *PaymentRepository) FindPayment(id uuid.UUID) (*model.Payment, error) {
 	query := `
-		SELECT id, order_id, user_id, amount, status, failure_reason, created_at, updated_at
+		SELECT id, order_id, user_id, amount, currency, refunded_amount, status, failure_reason, created_at, updated_at
 		FROM payments
 		WHERE id = ?
 	`
 
 	var payment PaymentRow
 
-	err := r.db.Get(&payment, query, id.String())
+	err := r.client.GetContext(r.ctx, &payment, query, id.String())
 	if err == sql.ErrNoRows {
 		return nil, model.ErrPaymentNotFound
 	}
@@ -75,41 +84,79 @@ func (r *PaymentRepository) FindPayment(id uuid.UUID) (*model.Payment, error) {
 		return nil, fmt.Errorf("failed to find payment: %w", err)
 	}
 
-	return r.rowToPayment(&payment), nil
+	return r.rowToPayment(&payment)
 }
 
-func (r *PaymentRepository) StoreWallet(wallet *model.Wallet) error {
+func (r *PaymentRepository) FindPaymentByOrderID(orderID uuid.UUID, status model.PaymentStatus) (*model.Payment, error) {
 	query := `
-		INSERT INTO wallets (id, user_id, balance, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			balance = VALUES(balance),
-			updated_at = VALUES(updated_at)
+		SELECT id, order_id, user_id, amount, currency, refunded_amount, status, failure_reason, created_at, updated_at
+		FROM payments
+		WHERE order_id = ? AND status = ?
+		ORDER BY created_at DESC
+		LIMIT 1
 	`
 
-	_, err := r.db.Exec(query,
-		wallet.ID.String(),
-		wallet.UserID.String(),
-		wallet.Balance,
-		wallet.CreatedAt,
-		wallet.UpdatedAt,
-	)
+	var payment PaymentRow
+	err := r.client.GetContext(r.ctx, &payment, query, orderID.String(), int(status))
+	if err == sql.ErrNoRows {
+		return nil, model.ErrPaymentNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("failed to store wallet: %w", err)
+		return nil, fmt.Errorf("failed to find payment of order: %w", err)
+	}
+
+	return r.rowToPayment(&payment)
+}
+
+// StoreWallet сохраняет кошелёк, проверяя, что с момента чтения его версия не изменилась.
+// При расхождении версий возвращает model.ErrVersionConflict
+func (r *PaymentRepository) StoreWallet(wallet *model.Wallet) error {
+	if wallet.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO wallets (id, user_id, balance, currency, created_at, updated_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, 1)`,
+			wallet.ID.String(),
+			wallet.UserID.String(),
+			wallet.Balance.Decimal(),
+			wallet.Balance.Currency,
+			wallet.CreatedAt,
+			wallet.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store wallet: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE wallets
+			SET balance = ?, currency = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			wallet.Balance.Decimal(),
+			wallet.Balance.Currency,
+			wallet.UpdatedAt,
+			wallet.ID.String(),
+			wallet.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store wallet: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
 	}
+	wallet.Version++
 
 	return nil
 }
 
 func (r *PaymentRepository) FindWalletByUserID(userID uuid.UUID) (*model.Wallet, error) {
 	query := `
-		SELECT id, user_id, balance, created_at, updated_at
+		SELECT id, user_id, balance, currency, created_at, updated_at, version
 		FROM wallets
 		WHERE user_id = ?
 	`
 
 	var wallet WalletRow
-	err := r.db.Get(&wallet, query, userID.String())
+	err := r.client.GetContext(r.ctx, &wallet, query, userID.String())
 	if err == sql.ErrNoRows {
 		return nil, model.ErrWalletNotFound
 	}
@@ -117,27 +164,31 @@ func (r *PaymentRepository) FindWalletByUserID(userID uuid.UUID) (*model.Wallet,
 		return nil, fmt.Errorf("failed to find wallet: %w", err)
 	}
 
-	return r.rowToWallet(&wallet), nil
+	return r.rowToWallet(&wallet)
 }
 
 // GetPaymentsByOrderID получает платежи по ID заказа
 func (r *PaymentRepository) GetPaymentsByOrderID(orderID uuid.UUID) ([]*model.Payment, error) {
 	query := `
-		SELECT id, order_id, user_id, amount, status, failure_reason, created_at, updated_at
+		SELECT id, order_id, user_id, amount, currency, refunded_amount, status, failure_reason, created_at, updated_at
 		FROM payments
 		WHERE order_id = ?
 		ORDER BY created_at DESC
 	`
 
 	var payments []PaymentRow
-	err := r.db.Select(&payments, query, orderID.String())
+	err := r.client.SelectContext(r.ctx, &payments, query, orderID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payments by order: %w", err)
 	}
 
 	result := make([]*model.Payment, len(payments))
 	for i, paymentRow := range payments {
-		result[i] = r.rowToPayment(&paymentRow)
+		payment, err := r.rowToPayment(&paymentRow)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = payment
 	}
 
 	return result, nil
@@ -156,7 +207,7 @@ func (r *PaymentRepository) UpdatePaymentStatus(id uuid.UUID, status model.Payme
 		failureReasonValue = failureReason
 	}
 
-	_, err := r.db.Exec(query, int(status), failureReasonValue, id.String())
+	_, err := r.client.ExecContext(r.ctx, query, int(status), failureReasonValue, id.String())
 	if err != nil {
 		return fmt.Errorf("failed to update payment status: %w", err)
 	}
@@ -165,14 +216,14 @@ func (r *PaymentRepository) UpdatePaymentStatus(id uuid.UUID, status model.Payme
 }
 
 // UpdateWalletBalance обновляет баланс кошелька
-func (r *PaymentRepository) UpdateWalletBalance(userID uuid.UUID, newBalance float64) error {
+func (r *PaymentRepository) UpdateWalletBalance(userID uuid.UUID, newBalance model.Money) error {
 	query := `
 		UPDATE wallets 
-		SET balance = ?, updated_at = NOW()
+		SET balance = ?, currency = ?, updated_at = NOW(), version = version + 1
 		WHERE user_id = ?
 	`
 
-	_, err := r.db.Exec(query, newBalance, userID.String())
+	_, err := r.client.ExecContext(r.ctx, query, newBalance.Decimal(), newBalance.Currency, userID.String())
 	if err != nil {
 		return fmt.Errorf("failed to update wallet balance: %w", err)
 	}
@@ -181,25 +232,29 @@ func (r *PaymentRepository) UpdateWalletBalance(userID uuid.UUID, newBalance flo
 }
 
 type PaymentRow struct {
-	ID            string         `db:"id"`
-	OrderID       string         `db:"order_id"`
-	UserID        string         `db:"user_id"`
-	Amount        float64        `db:"amount"`
-	Status        int            `db:"status"`
-	FailureReason sql.NullString `db:"failure_reason"`
-	CreatedAt     time.Time      `db:"created_at"`
-	UpdatedAt     time.Time      `db:"updated_at"`
+	ID             string         `db:"id"`
+	OrderID        string         `db:"order_id"`
+	UserID         string         `db:"user_id"`
+	Amount         string         `db:"amount"`
+	Currency       string         `db:"currency"`
+	RefundedAmount string         `db:"refunded_amount"`
+	Status         int            `db:"status"`
+	FailureReason  sql.NullString `db:"failure_reason"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
 }
 
 type WalletRow struct {
 	ID        string    `db:"id"`
 	UserID    string    `db:"user_id"`
-	Balance   float64   `db:"balance"`
+	Balance   string    `db:"balance"`
+	Currency  string    `db:"currency"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
+	Version   int       `db:"version"`
 }
 
-func (r *PaymentRepository) rowToPayment(row *PaymentRow) *model.Payment {
+func (r *PaymentRepository) rowToPayment(row *PaymentRow) (*model.Payment, error) {
 	paymentID, _ := uuid.Parse(row.ID)
 	orderID, _ := uuid.Parse(row.OrderID)
 	userID, _ := uuid.Parse(row.UserID)
@@ -210,27 +265,43 @@ func (r *PaymentRepository) rowToPayment(row *PaymentRow) *model.Payment {
 		failureReason = &failureReasonStr
 	}
 
-	return &model.Payment{
-		ID:            paymentID,
-		OrderID:       orderID,
-		UserID:        userID,
-		Amount:        row.Amount,
-		Status:        model.PaymentStatus(row.Status),
-		FailureReason: failureReason,
-		CreatedAt:     row.CreatedAt,
-		UpdatedAt:     row.UpdatedAt,
+	amount, err := model.ParseMoney(row.Amount, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment amount: %w", err)
+	}
+	refundedAmount, err := model.ParseMoney(row.RefundedAmount, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment refunded amount: %w", err)
 	}
+
+	return &model.Payment{
+		ID:             paymentID,
+		OrderID:        orderID,
+		UserID:         userID,
+		Amount:         amount,
+		RefundedAmount: refundedAmount,
+		Status:         model.PaymentStatus(row.Status),
+		FailureReason:  failureReason,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}, nil
 }
 
-func (r *PaymentRepository) rowToWallet(row *WalletRow) *model.Wallet {
+func (r *PaymentRepository) rowToWallet(row *WalletRow) (*model.Wallet, error) {
 	walletID, _ := uuid.Parse(row.ID)
 	userID, _ := uuid.Parse(row.UserID)
 
+	balance, err := model.ParseMoney(row.Balance, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet balance: %w", err)
+	}
+
 	return &model.Wallet{
 		ID:        walletID,
 		UserID:    userID,
-		Balance:   row.Balance,
+		Balance:   balance,
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
-	}
+		Version:   row.Version,
+	}, nil
 }
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"common/mysql/mysqltest"
+	"payment/pkg/domain/model"
+	"payment/pkg/infrastructure/mysql"
+)
+
+func TestPaymentRepositoryStoreWallet(t *testing.T) {
+	newWallet := func(version int) *model.Wallet {
+		return &model.Wallet{
+			ID:        uuid.New(),
+			UserID:    uuid.New(),
+			Balance:   model.Money{Amount: 10000, Currency: model.DefaultCurrency},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Version:   version,
+		}
+	}
+
+	t.Run("Insert new wallet with first version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		wallet := newWallet(0)
+
+		err := mysql.NewPaymentRepository(context.Background(), client).StoreWallet(wallet)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "INSERT INTO wallets")
+		require.Equal(t, 1, wallet.Version)
+	})
+
+	t.Run("Update wallet read at current version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		wallet := newWallet(3)
+
+		err := mysql.NewPaymentRepository(context.Background(), client).StoreWallet(wallet)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "WHERE id = ? AND version = ?")
+		require.Equal(t, 3, client.Execs[0].Args[len(client.Execs[0].Args)-1])
+		require.Equal(t, 4, wallet.Version)
+	})
+
+	t.Run("Fail on version conflict", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 0}
+		wallet := newWallet(3)
+
+		err := mysql.NewPaymentRepository(context.Background(), client).StoreWallet(wallet)
+
+		require.ErrorIs(t, err, model.ErrVersionConflict)
+		require.Equal(t, 3, wallet.Version)
+	})
+}
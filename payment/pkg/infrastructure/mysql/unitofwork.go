@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+
+	"payment/pkg/application/service"
+	"payment/pkg/domain/model"
+	domainservice "payment/pkg/domain/service"
+	"payment/pkg/infrastructure/event"
+)
+
+func NewUnitOfWork(db *sqlx.DB, serializer event.Serializer) service.UnitOfWork {
+	return &unitOfWork{
+		db:         db,
+		serializer: serializer,
+	}
+}
+
+type unitOfWork struct {
+	db         *sqlx.DB
+	serializer event.Serializer
+}
+
+func (u *unitOfWork) Execute(ctx context.Context, f func(provider service.RepositoryProvider) error) error {
+	return commonmysql.ExecuteInTransaction(ctx, u.db, func(tx *sqlx.Tx) error {
+		return f(&repositoryProvider{tx: tx, serializer: u.serializer})
+	})
+}
+
+// NewLockableUnitOfWork сериализует транзакции по именованной блокировке MySQL (GET_LOCK)
+func NewLockableUnitOfWork(db *sqlx.DB, serializer event.Serializer, lockTimeout time.Duration) service.LockableUnitOfWork {
+	return &lockableUnitOfWork{
+		db:          db,
+		serializer:  serializer,
+		lockTimeout: lockTimeout,
+	}
+}
+
+type lockableUnitOfWork struct {
+	db          *sqlx.DB
+	serializer  event.Serializer
+	lockTimeout time.Duration
+}
+
+func (u *lockableUnitOfWork) Execute(ctx context.Context, lockName string, f func(provider service.RepositoryProvider) error) error {
+	return commonmysql.ExecuteWithLock(ctx, u.db, lockName, u.lockTimeout, service.ErrLockTimeout, func(tx *sqlx.Tx) error {
+		return f(&repositoryProvider{tx: tx, serializer: u.serializer})
+	})
+}
+
+type repositoryProvider struct {
+	tx         *sqlx.Tx
+	serializer event.Serializer
+}
+
+func (p *repositoryProvider) PaymentRepository(ctx context.Context) model.PaymentRepository {
+	return NewPaymentRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) EventDispatcher(ctx context.Context) domainservice.EventDispatcher {
+	return commonmysql.NewEventDispatcher(ctx, p.tx, p.serializer)
+}
+
+func (p *repositoryProvider) Inbox(ctx context.Context) service.Inbox {
+	return commonmysql.NewInbox(ctx, p.tx)
+}
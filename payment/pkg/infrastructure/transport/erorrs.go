@@ -5,6 +5,10 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+
+	appservice "payment/pkg/application/service"
+	"payment/pkg/domain/model"
+	"payment/pkg/domain/service"
 )
 
 type errorSet map[error]struct{}
@@ -22,9 +26,27 @@ func (s errorSet) Has(err error) bool {
 	return ok
 }
 
-var badRequestErrorCodes = newErrorSet()
+var badRequestErrorCodes = newErrorSet(
+	model.ErrInvalidAmount,
+	model.ErrInvalidCurrency,
+	model.ErrCurrencyMismatch,
+)
+
+var notFoundErrorCodes = newErrorSet(
+	model.ErrPaymentNotFound,
+	model.ErrWalletNotFound,
+)
 
-var notFoundErrorCodes = newErrorSet()
+var failedPreconditionErrorCodes = newErrorSet(
+	service.ErrPaymentAlreadyProcessed,
+	service.ErrPaymentInProgress,
+	service.ErrPaymentNotRefundable,
+)
+
+var abortedErrorCodes = newErrorSet(
+	appservice.ErrLockTimeout,
+	model.ErrVersionConflict,
+)
 
 var unauthorizedErrorCodes = newErrorSet()
 
@@ -43,6 +65,10 @@ func getGRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 	case isNotFoundError(cause):
 		return codes.NotFound
+	case isFailedPreconditionError(cause):
+		return codes.FailedPrecondition
+	case isAbortedError(cause):
+		return codes.Aborted
 	case isUnauthorizedError(cause):
 		return codes.Unauthenticated
 	case isPermissionDeniedError(cause):
@@ -69,6 +95,7 @@ func isWarnLevel(err error) bool {
 		codes.InvalidArgument,
 		codes.NotFound,
 		codes.FailedPrecondition,
+		codes.Aborted,
 		codes.Unauthenticated:
 		return true
 	default:
@@ -84,6 +111,14 @@ func isNotFoundError(cause error) bool {
 	return notFoundErrorCodes.Has(cause)
 }
 
+func isFailedPreconditionError(cause error) bool {
+	return failedPreconditionErrorCodes.Has(cause)
+}
+
+func isAbortedError(cause error) bool {
+	return abortedErrorCodes.Has(cause)
+}
+
 func isUnauthorizedError(cause error) bool {
 	return unauthorizedErrorCodes.Has(cause)
 }
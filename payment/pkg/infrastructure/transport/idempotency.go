@@ -0,0 +1,14 @@
+package transport
+
+import (
+	api "payment/api/server/paymentinternal"
+)
+
+// IdempotentMethods - изменяющие вызовы, повтор которых с тем же idempotency-key
+// возвращает сохранённый ответ вместо повторного выполнения
+var IdempotentMethods = []string{
+	api.PaymentInternalService_CreateWallet_FullMethodName,
+	api.PaymentInternalService_InitiatePayment_FullMethodName,
+	api.PaymentInternalService_ProcessPayment_FullMethodName,
+	api.PaymentInternalService_RefundPayment_FullMethodName,
+}
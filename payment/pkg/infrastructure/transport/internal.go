@@ -3,14 +3,29 @@ package transport
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	api "payment/api/server/paymentinternal"
+	"payment/pkg/application/query"
+	"payment/pkg/application/service"
+	"payment/pkg/domain/model"
 )
 
-func NewInternalAPI() api.PaymentInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(
+	paymentService service.PaymentService,
+	paymentQueryService query.PaymentQueryService,
+) api.PaymentInternalServiceServer {
+	return &internalAPI{
+		paymentService:      paymentService,
+		paymentQueryService: paymentQueryService,
+	}
 }
 
 type internalAPI struct {
+	paymentService      service.PaymentService
+	paymentQueryService query.PaymentQueryService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +33,120 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) CreateWallet(ctx context.Context, request *api.CreateWalletRequest) (*api.CreateWalletResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	initialBalance, err := fromAPIMoney(request.InitialBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	walletID, err := i.paymentService.CreateWallet(ctx, userID, initialBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateWalletResponse{
+		WalletID: walletID.String(),
+	}, nil
+}
+
+func (i *internalAPI) InitiatePayment(ctx context.Context, request *api.InitiatePaymentRequest) (*api.InitiatePaymentResponse, error) {
+	orderID, err := parseUUID(request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := fromAPIMoney(request.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentID, err := i.paymentService.InitiatePayment(ctx, orderID, userID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.InitiatePaymentResponse{
+		PaymentID: paymentID.String(),
+	}, nil
+}
+
+func (i *internalAPI) ProcessPayment(ctx context.Context, request *api.ProcessPaymentRequest) (*api.ProcessPaymentResponse, error) {
+	paymentID, err := parseUUID(request.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.paymentService.ProcessPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.ProcessPaymentResponse{}, nil
+}
+
+func (i *internalAPI) RefundPayment(ctx context.Context, request *api.RefundPaymentRequest) (*api.RefundPaymentResponse, error) {
+	paymentID, err := parseUUID(request.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.paymentService.RefundPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.RefundPaymentResponse{}, nil
+}
+
+func (i *internalAPI) GetPayment(ctx context.Context, request *api.GetPaymentRequest) (*api.GetPaymentResponse, error) {
+	paymentID, err := parseUUID(request.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := i.paymentQueryService.FindPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetPaymentResponse{
+		Payment: &api.Payment{
+			PaymentID:     payment.ID.String(),
+			OrderID:       payment.OrderID.String(),
+			UserID:        payment.UserID.String(),
+			Amount:        toAPIMoney(payment.Amount),
+			Status:        api.PaymentStatus(payment.Status),
+			FailureReason: payment.FailureReason,
+		},
+	}, nil
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q: %s", value, err)
+	}
+	return id, nil
+}
+
+func fromAPIMoney(money *api.Money) (model.Money, error) {
+	if money == nil {
+		return model.Money{}, status.Error(codes.InvalidArgument, "money is required")
+	}
+	return model.NewMoney(money.Amount, money.Currency)
+}
+
+func toAPIMoney(money model.Money) *api.Money {
+	return &api.Money{
+		Amount:   money.Amount,
+		Currency: money.Currency,
+	}
+}
@@ -9,6 +9,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"common/amqp"
 )
 
 type multiCloser struct {
@@ -47,7 +49,6 @@ func newConnectionsContainer(
 		if err != nil {
 			return fmt.Errorf("failed to init DB for migrations: %w", err)
 		}
-		defer db.Close()
 
 		if err = applyMigrations(db.DB, pathToMigrations); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
@@ -56,6 +57,13 @@ func newConnectionsContainer(
 		multiCloser.Add(db)
 		container.db = db
 
+		amqpConnection, err := amqp.Dial(config.AMQPURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to AMQP: %w", err)
+		}
+		multiCloser.Add(amqpConnection)
+		container.amqpConnection = amqpConnection
+
 		testConnection, err := grpc.NewClient(
 			config.TestGRPCAddress,
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -75,6 +83,7 @@ func newConnectionsContainer(
 
 type connectionsContainer struct {
 	db             *sqlx.DB
+	amqpConnection *amqp.Connection
 	testConnection grpc.ClientConnInterface
 }
 
@@ -1,16 +1,70 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"common/amqp"
+	"common/idempotency"
+	commonmysql "common/mysql"
+	"common/outbox"
+	"common/retention"
+
+	"product/pkg/application/query"
+	appservice "product/pkg/application/service"
+	"product/pkg/infrastructure/event"
+	"product/pkg/infrastructure/mysql"
+	"product/pkg/infrastructure/transport"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
+	logger *log.Logger,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	uow := mysql.NewUnitOfWork(connContainer.db, event.NewJSONSerializer())
+
+	idempotencyStorage := commonmysql.NewIdempotencyStorage(connContainer.db)
+
+	publisher, err := amqp.NewPublisher(connContainer.amqpConnection, appID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		db:                  connContainer.db,
+		ProductService:      appservice.NewProductService(uow),
+		ProductQueryService: mysql.NewProductQueryService(connContainer.db),
+		OutboxRelay: outbox.NewRelay(
+			commonmysql.NewOutboxStorage(connContainer.db),
+			publisher,
+			logger,
+			config.OutboxRelayInterval,
+			config.OutboxBatchSize,
+		),
+		IdempotencyInterceptor: idempotency.NewInterceptor(
+			idempotencyStorage,
+			logger,
+			config.IdempotencyRetention,
+			config.IdempotencyPendingTimeout,
+			transport.IdempotentMethods,
+		),
+		IdempotencyCleaner: retention.NewCleaner(
+			idempotencyStorage,
+			logger.WithField("table", "idempotency_keys"),
+			config.IdempotencyCleanupInterval,
+			config.IdempotencyRetention,
+		),
 	}, nil
 }
 
 type dependencyContainer struct {
 	db *sqlx.DB
+
+	ProductService      appservice.ProductService
+	ProductQueryService query.ProductQueryService
+	OutboxRelay         *outbox.Relay
+
+	IdempotencyInterceptor *idempotency.Interceptor
+	IdempotencyCleaner     *retention.Cleaner
 }
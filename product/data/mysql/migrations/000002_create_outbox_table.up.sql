@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS outbox
+(
+    `id`         BIGINT NOT NULL AUTO_INCREMENT,
+    `event_id`   CHAR(36) NOT NULL,
+    `event_type` VARCHAR(100) NOT NULL,
+    `payload`    JSON NOT NULL,
+    `created_at` DATETIME(6) NOT NULL,
+    `sent_at`    DATETIME(6) NULL DEFAULT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_event_id` (`event_id`),
+    INDEX `idx_sent_at` (`sent_at`)
+) ENGINE = InnoDB
+  CHARACTER SET = utf8mb4
+  COLLATE utf8mb4_unicode_ci;
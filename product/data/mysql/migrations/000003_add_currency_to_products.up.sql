@@ -0,0 +1,2 @@
+ALTER TABLE products
+    ADD COLUMN `currency` CHAR(3) NOT NULL DEFAULT 'RUB' AFTER `price`;
@@ -0,0 +1,2 @@
+ALTER TABLE products
+    ADD COLUMN `category` VARCHAR(64) NOT NULL DEFAULT '' AFTER `name`;
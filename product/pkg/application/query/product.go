@@ -0,0 +1,21 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+)
+
+type Product struct {
+	ID       uuid.UUID
+	Name     string
+	Category string
+	Price    model.Money
+}
+
+type ProductQueryService interface {
+	// FindProduct возвращает только неудалённые продукты
+	FindProduct(ctx context.Context, productID uuid.UUID) (*Product, error)
+}
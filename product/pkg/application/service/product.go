@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"product/pkg/domain/model"
+	domainservice "product/pkg/domain/service"
+)
+
+type ProductService interface {
+	CreateProduct(ctx context.Context, name, category string, price model.Money) (uuid.UUID, error)
+	UpdateProduct(ctx context.Context, productID uuid.UUID, name, category string, price model.Money) error
+	DeleteProduct(ctx context.Context, productID uuid.UUID) error
+}
+
+func NewProductService(uow UnitOfWork) ProductService {
+	return &productService{
+		uow: uow,
+	}
+}
+
+type productService struct {
+	uow UnitOfWork
+}
+
+func (s *productService) CreateProduct(ctx context.Context, name, category string, price model.Money) (productID uuid.UUID, err error) {
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		productID, err = s.domainService(ctx, provider).CreateProduct(name, category, price)
+		return err
+	})
+	return productID, err
+}
+
+func (s *productService) UpdateProduct(ctx context.Context, productID uuid.UUID, name, category string, price model.Money) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).UpdateProduct(productID, name, category, price)
+	})
+}
+
+func (s *productService) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteProduct(productID)
+	})
+}
+
+func (s *productService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.Product {
+	return domainservice.NewProductService(provider.ProductRepository(ctx), provider.EventDispatcher(ctx))
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"product/pkg/domain/model"
+	domainservice "product/pkg/domain/service"
+)
+
+type RepositoryProvider interface {
+	ProductRepository(ctx context.Context) model.ProductRepository
+	EventDispatcher(ctx context.Context) domainservice.EventDispatcher
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
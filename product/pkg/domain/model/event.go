@@ -5,7 +5,8 @@ import "github.com/google/uuid"
 type ProductCreated struct {
 	ProductID uuid.UUID
 	Name      string
-	Price     float64
+	Category  string
+	Price     Money
 }
 
 func (e ProductCreated) Type() string {
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"common/money"
+)
+
+// Money и связанные с ним ошибки общие для всех сервисов, см. common/money
+type Money = money.Money
+
+const DefaultCurrency = money.DefaultCurrency
+
+var (
+	ErrCurrencyMismatch = money.ErrCurrencyMismatch
+	ErrInvalidCurrency  = money.ErrInvalidCurrency
+	ErrInvalidAmount    = money.ErrInvalidAmount
+)
+
+func NewMoney(amount int64, currency string) (Money, error) {
+	return money.NewMoney(amount, currency)
+}
+
+func ParseMoney(value, currency string) (Money, error) {
+	return money.ParseMoney(value, currency)
+}
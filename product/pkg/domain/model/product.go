@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,15 +11,22 @@ import (
 var (
 	ErrProductNotFound   = errors.New("product not found")
 	ErrProductNameExists = errors.New("product name already exists")
+	ErrInvalidCategory   = errors.New("invalid product category")
+	// ErrVersionConflict - продукт изменили после того, как его прочитали, операцию можно повторить
+	ErrVersionConflict = errors.New("product was modified concurrently")
 )
 
 type Product struct {
-	ID        uuid.UUID
-	Name      string
-	Price     float64
+	ID   uuid.UUID
+	Name string
+	// Category - налоговая категория товара, пустая у товаров без категории
+	Category  string
+	Price     Money
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
+	// Version не даёт параллельным правкам цены и названия затереть друг друга, 0 - товар ещё не сохранён
+	Version int
 }
 
 type ProductRepository interface {
@@ -28,3 +36,15 @@ type ProductRepository interface {
 	FindByName(name string) (*Product, error)
 	Delete(id uuid.UUID) error
 }
+
+const maxCategoryLength = 64
+
+// NormalizeCategory приводит категорию к нижнему регистру без пробелов по краям,
+// чтобы заказы сопоставляли её с налоговыми правилами без учёта написания
+func NormalizeCategory(category string) (string, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if len(category) > maxCategoryLength {
+		return "", ErrInvalidCategory
+	}
+	return category, nil
+}
@@ -17,8 +17,8 @@ type EventDispatcher interface {
 }
 
 type Product interface {
-	CreateProduct(name string, price float64) (uuid.UUID, error)
-	UpdateProduct(productID uuid.UUID, name string, price float64) error
+	CreateProduct(name, category string, price model.Money) (uuid.UUID, error)
+	UpdateProduct(productID uuid.UUID, name, category string, price model.Money) error
 	DeleteProduct(productID uuid.UUID) error
 }
 
@@ -34,7 +34,14 @@ type productService struct {
 	dispatcher EventDispatcher
 }
 
-func (s *productService) CreateProduct(name string, price float64) (uuid.UUID, error) {
+func (s *productService) CreateProduct(name, category string, price model.Money) (uuid.UUID, error) {
+	if price.IsNegative() {
+		return uuid.Nil, model.ErrInvalidAmount
+	}
+	category, err := model.NormalizeCategory(category)
+	if err != nil {
+		return uuid.Nil, err
+	}
 	if _, err := s.repo.FindByName(name); err == nil {
 		return uuid.Nil, model.ErrProductNameExists
 	}
@@ -48,6 +55,7 @@ func (s *productService) CreateProduct(name string, price float64) (uuid.UUID, e
 	product := &model.Product{
 		ID:        productID,
 		Name:      name,
+		Category:  category,
 		Price:     price,
 		CreatedAt: currentTime,
 		UpdatedAt: currentTime,
@@ -60,11 +68,20 @@ func (s *productService) CreateProduct(name string, price float64) (uuid.UUID, e
 	return productID, s.dispatcher.Dispatch(model.ProductCreated{
 		ProductID: productID,
 		Name:      name,
+		Category:  category,
 		Price:     price,
 	})
 }
 
-func (s *productService) UpdateProduct(productID uuid.UUID, name string, price float64) error {
+func (s *productService) UpdateProduct(productID uuid.UUID, name, category string, price model.Money) error {
+	if price.IsNegative() {
+		return model.ErrInvalidAmount
+	}
+	category, err := model.NormalizeCategory(category)
+	if err != nil {
+		return err
+	}
+
 	product, err := s.repo.Find(productID)
 	if err != nil {
 		return err
@@ -77,6 +94,7 @@ func (s *productService) UpdateProduct(productID uuid.UUID, name string, price f
 		product.Name = name
 	}
 
+	product.Category = category
 	product.Price = price
 	product.UpdatedAt = time.Now()
 
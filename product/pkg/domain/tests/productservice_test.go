@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -31,12 +32,12 @@ func setup() testFixture {
 
 func TestProductService(t *testing.T) {
 	name := "Digital Book"
-	price := 19.99
+	price := rub(1999)
 
 	t.Run("Create product", func(t *testing.T) {
 		f := setup()
 
-		productID, err := f.productService.CreateProduct(name, price)
+		productID, err := f.productService.CreateProduct(name, "", price)
 
 		require.NoError(t, err)
 		require.NotNil(t, f.repo.store[productID])
@@ -48,7 +49,7 @@ func TestProductService(t *testing.T) {
 
 	t.Run("Delete product", func(t *testing.T) {
 		f := setup()
-		productID, _ := f.productService.CreateProduct(name, price)
+		productID, _ := f.productService.CreateProduct(name, "", price)
 		f.eventDispatcher.events = nil
 
 		err := f.productService.DeleteProduct(productID)
@@ -61,12 +62,12 @@ func TestProductService(t *testing.T) {
 
 	t.Run("Update product", func(t *testing.T) {
 		f := setup()
-		productID, _ := f.productService.CreateProduct(name, price)
+		productID, _ := f.productService.CreateProduct(name, "", price)
 		f.eventDispatcher.events = nil
 
 		newName := "Digital Course"
-		newPrice := 49.99
-		err := f.productService.UpdateProduct(productID, newName, newPrice)
+		newPrice := rub(4999)
+		err := f.productService.UpdateProduct(productID, newName, "", newPrice)
 
 		require.NoError(t, err)
 		require.Equal(t, newName, f.repo.store[productID].Name)
@@ -77,10 +78,10 @@ func TestProductService(t *testing.T) {
 
 	t.Run("Fail to create product with duplicate name", func(t *testing.T) {
 		f := setup()
-		_, _ = f.productService.CreateProduct(name, price)
+		_, _ = f.productService.CreateProduct(name, "", price)
 		f.eventDispatcher.events = nil
 
-		_, err := f.productService.CreateProduct(name, 100.00)
+		_, err := f.productService.CreateProduct(name, "", rub(10000))
 
 		require.ErrorIs(t, err, model.ErrProductNameExists)
 		require.Empty(t, f.eventDispatcher.events)
@@ -88,15 +89,48 @@ func TestProductService(t *testing.T) {
 
 	t.Run("Fail to update product to a duplicate name", func(t *testing.T) {
 		f := setup()
-		productID, _ := f.productService.CreateProduct("Product A", 10.0)
-		_, _ = f.productService.CreateProduct("Product B", 20.0)
+		productID, _ := f.productService.CreateProduct("Product A", "", rub(1000))
+		_, _ = f.productService.CreateProduct("Product B", "", rub(2000))
 		f.eventDispatcher.events = nil
 
-		err := f.productService.UpdateProduct(productID, "Product B", 30.0)
+		err := f.productService.UpdateProduct(productID, "Product B", "", rub(3000))
 
 		require.ErrorIs(t, err, model.ErrProductNameExists)
 		require.Empty(t, f.eventDispatcher.events)
 	})
+
+	t.Run("Fail to create product with negative price", func(t *testing.T) {
+		f := setup()
+
+		_, err := f.productService.CreateProduct(name, "", rub(-1))
+
+		require.ErrorIs(t, err, model.ErrInvalidAmount)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+
+	t.Run("Normalize category", func(t *testing.T) {
+		f := setup()
+
+		productID, err := f.productService.CreateProduct(name, "  Books ", price)
+
+		require.NoError(t, err)
+		require.Equal(t, "books", f.repo.store[productID].Category)
+		created := f.eventDispatcher.events[0].(model.ProductCreated)
+		require.Equal(t, "books", created.Category)
+	})
+
+	t.Run("Fail to create product with too long category", func(t *testing.T) {
+		f := setup()
+
+		_, err := f.productService.CreateProduct(name, strings.Repeat("a", 65), price)
+
+		require.ErrorIs(t, err, model.ErrInvalidCategory)
+		require.Empty(t, f.eventDispatcher.events)
+	})
+}
+
+func rub(amount int64) model.Money {
+	return model.Money{Amount: amount, Currency: model.DefaultCurrency}
 }
 
 var _ model.ProductRepository = &mockProductRepository{}
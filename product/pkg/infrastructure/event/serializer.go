@@ -0,0 +1,17 @@
+package event
+
+import (
+	commonevent "common/event"
+	"product/pkg/domain/model"
+	"product/pkg/domain/service"
+)
+
+type Serializer = commonevent.Serializer[service.Event]
+
+func NewJSONSerializer() Serializer {
+	return commonevent.NewJSONSerializer[service.Event](
+		model.ProductCreated{},
+		model.ProductUpdated{},
+		model.ProductDeleted{},
+	)
+}
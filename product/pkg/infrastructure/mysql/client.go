@@ -0,0 +1,8 @@
+package mysql
+
+import (
+	commonmysql "common/mysql"
+)
+
+// ClientContext - общий интерфейс для *sqlx.DB, *sqlx.Conn и *sqlx.Tx
+type ClientContext = commonmysql.ClientContext
@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"product/pkg/application/query"
+	"product/pkg/domain/model"
+)
+
+func NewProductQueryService(db *sqlx.DB) query.ProductQueryService {
+	return &productQueryService{db: db}
+}
+
+type productQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *productQueryService) FindProduct(ctx context.Context, productID uuid.UUID) (*query.Product, error) {
+	productQuery := `
+		SELECT id, name, category, price, currency, created_at, updated_at, deleted_at
+		FROM products
+		WHERE id = ? AND deleted_at IS NULL
+	`
+
+	var row ProductRow
+	err := s.db.GetContext(ctx, &row, productQuery, productID.String())
+	if err == sql.ErrNoRows {
+		return nil, model.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product: %w", err)
+	}
+
+	price, err := model.ParseMoney(row.Price, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product price: %w", err)
+	}
+
+	return &query.Product{
+		ID:       productID,
+		Name:     row.Name,
+		Category: row.Category,
+		Price:    price,
+	}, nil
+}
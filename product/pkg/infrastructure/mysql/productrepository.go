@@ -1,63 +1,87 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
 	"time"
 
+	commonmysql "common/mysql"
 	"product/pkg/domain/model"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 type ProductRepository struct {
-	db *sqlx.DB
+	ctx    context.Context
+	client ClientContext
 }
 
-func NewProductRepository(db *sqlx.DB) *ProductRepository {
-	return &ProductRepository{db: db}
+func NewProductRepository(ctx context.Context, client ClientContext) *ProductRepository {
+	return &ProductRepository{
+		ctx:    ctx,
+		client: client,
+	}
 }
 
 func (r *ProductRepository) NextID() (uuid.UUID, error) {
 	return uuid.NewUUID()
 }
 
+// Store сохраняет продукт, проверяя, что с момента чтения его версия не изменилась.
+// При расхождении версий возвращает model.ErrVersionConflict
 func (r *ProductRepository) Store(product *model.Product) error {
-	query := `
-		INSERT INTO products (id, name, price, created_at, updated_at, deleted_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			name = VALUES(name),
-			price = VALUES(price),
-			updated_at = VALUES(updated_at),
-			deleted_at = VALUES(deleted_at)
-	`
-
 	deletedAt := (*time.Time)(nil)
 	if product.DeletedAt != nil {
 		deletedAt = product.DeletedAt
 	}
 
-	_, err := r.db.Exec(query,
-		product.ID.String(),
-		product.Name,
-		product.Price,
-		product.CreatedAt,
-		product.UpdatedAt,
-		deletedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to store product: %w", err)
-	}
+	if product.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO products (id, name, category, price, currency, created_at, updated_at, deleted_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			product.ID.String(),
+			product.Name,
+			product.Category,
+			product.Price.Decimal(),
+			product.Price.Currency,
+			product.CreatedAt,
+			product.UpdatedAt,
+			deletedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store product: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE products
+			SET name = ?, category = ?, price = ?, currency = ?, updated_at = ?, deleted_at = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			product.Name,
+			product.Category,
+			product.Price.Decimal(),
+			product.Price.Currency,
+			product.UpdatedAt,
+			deletedAt,
+			product.ID.String(),
+			product.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store product: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
+	}
+	product.Version++
 
 	return nil
 }
 
 func (r *ProductRepository) Find(id uuid.UUID) (*model.Product, error) {
 	query := `
-		SELECT id, name, price, created_at, updated_at, deleted_at
+		SELECT id, name, category, price, currency, created_at, updated_at, deleted_at, version
 		FROM products
 		WHERE id = ?
 	`
@@ -65,7 +89,7 @@ func (r *ProductRepository) Find(id uuid.UUID) (*model.Product, error) {
 	var product ProductRow
 	var _ sql.NullTime
 
-	err := r.db.Get(&product, query, id.String())
+	err := r.client.GetContext(r.ctx, &product, query, id.String())
 	if err == sql.ErrNoRows {
 		return nil, model.ErrProductNotFound
 	}
@@ -73,12 +97,12 @@ func (r *ProductRepository) Find(id uuid.UUID) (*model.Product, error) {
 		return nil, fmt.Errorf("failed to find product: %w", err)
 	}
 
-	return r.rowToProduct(&product), nil
+	return r.rowToProduct(&product)
 }
 
 func (r *ProductRepository) FindByName(name string) (*model.Product, error) {
 	query := `
-		SELECT id, name, price, created_at, updated_at, deleted_at
+		SELECT id, name, category, price, currency, created_at, updated_at, deleted_at, version
 		FROM products
 		WHERE name = ? AND deleted_at IS NULL
 	`
@@ -86,7 +110,7 @@ func (r *ProductRepository) FindByName(name string) (*model.Product, error) {
 	var product ProductRow
 	var _ sql.NullTime
 
-	err := r.db.Get(&product, query, name)
+	err := r.client.GetContext(r.ctx, &product, query, name)
 	if err == sql.ErrNoRows {
 		return nil, model.ErrProductNotFound
 	}
@@ -94,17 +118,17 @@ func (r *ProductRepository) FindByName(name string) (*model.Product, error) {
 		return nil, fmt.Errorf("failed to find product by name: %w", err)
 	}
 
-	return r.rowToProduct(&product), nil
+	return r.rowToProduct(&product)
 }
 
 func (r *ProductRepository) Delete(id uuid.UUID) error {
 	query := `
 		UPDATE products 
-		SET deleted_at = NOW(), updated_at = NOW()
+		SET deleted_at = NOW(), updated_at = NOW(), version = version + 1
 		WHERE id = ?
 	`
 
-	_, err := r.db.Exec(query, id.String())
+	_, err := r.client.ExecContext(r.ctx, query, id.String())
 	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
@@ -116,7 +140,7 @@ func (r *ProductRepository) Delete(id uuid.UUID) error {
 func (r *ProductRepository) CheckProductNameExists(name string) (bool, error) {
 	query := "SELECT COUNT(*) FROM products WHERE name = ? AND deleted_at IS NULL"
 	var count int
-	err := r.db.Get(&count, query, name)
+	err := r.client.GetContext(r.ctx, &count, query, name)
 	if err != nil {
 		return false, fmt.Errorf("failed to check product name exists: %w", err)
 	}
@@ -126,21 +150,25 @@ func (r *ProductRepository) CheckProductNameExists(name string) (bool, error) {
 // GetAllActiveProducts получает все активные продукты
 func (r *ProductRepository) GetAllActiveProducts() ([]*model.Product, error) {
 	query := `
-		SELECT id, name, price, created_at, updated_at, deleted_at
+		SELECT id, name, category, price, currency, created_at, updated_at, deleted_at, version
 		FROM products
 		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 
 	var products []ProductRow
-	err := r.db.Select(&products, query)
+	err := r.client.SelectContext(r.ctx, &products, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all active products: %w", err)
 	}
 
 	result := make([]*model.Product, len(products))
 	for i, productRow := range products {
-		result[i] = r.rowToProduct(&productRow)
+		product, err := r.rowToProduct(&productRow)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = product
 	}
 
 	return result, nil
@@ -161,21 +189,25 @@ func (r *ProductRepository) GetProductsByIDs(ids []uuid.UUID) ([]*model.Product,
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, name, price, created_at, updated_at, deleted_at
+		SELECT id, name, category, price, currency, created_at, updated_at, deleted_at, version
 		FROM products
 		WHERE id IN (%s) AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`, strings.Join(placeholders, ", "))
 
 	var products []ProductRow
-	err := r.db.Select(&products, query, args...)
+	err := r.client.SelectContext(r.ctx, &products, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products by IDs: %w", err)
 	}
 
 	result := make([]*model.Product, len(products))
 	for i, productRow := range products {
-		result[i] = r.rowToProduct(&productRow)
+		product, err := r.rowToProduct(&productRow)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = product
 	}
 
 	return result, nil
@@ -184,13 +216,16 @@ func (r *ProductRepository) GetProductsByIDs(ids []uuid.UUID) ([]*model.Product,
 type ProductRow struct {
 	ID        string       `db:"id"`
 	Name      string       `db:"name"`
-	Price     float64      `db:"price"`
+	Category  string       `db:"category"`
+	Price     string       `db:"price"`
+	Currency  string       `db:"currency"`
 	CreatedAt time.Time    `db:"created_at"`
 	UpdatedAt time.Time    `db:"updated_at"`
 	DeletedAt sql.NullTime `db:"deleted_at"`
+	Version   int          `db:"version"`
 }
 
-func (r *ProductRepository) rowToProduct(row *ProductRow) *model.Product {
+func (r *ProductRepository) rowToProduct(row *ProductRow) (*model.Product, error) {
 	productID, _ := uuid.Parse(row.ID)
 
 	deletedAt := (*time.Time)(nil)
@@ -198,12 +233,19 @@ func (r *ProductRepository) rowToProduct(row *ProductRow) *model.Product {
 		deletedAt = &row.DeletedAt.Time
 	}
 
+	price, err := model.ParseMoney(row.Price, row.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product price: %w", err)
+	}
+
 	return &model.Product{
 		ID:        productID,
 		Name:      row.Name,
-		Price:     row.Price,
+		Category:  row.Category,
+		Price:     price,
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
 		DeletedAt: deletedAt,
-	}
+		Version:   row.Version,
+	}, nil
 }
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"common/mysql/mysqltest"
+	"product/pkg/domain/model"
+	"product/pkg/infrastructure/mysql"
+)
+
+func TestProductRepositoryStore(t *testing.T) {
+	newProduct := func(version int) *model.Product {
+		return &model.Product{
+			ID:        uuid.New(),
+			Name:      "Keyboard",
+			Price:     model.Money{Amount: 9999, Currency: model.DefaultCurrency},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Version:   version,
+		}
+	}
+
+	t.Run("Insert new product with first version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		product := newProduct(0)
+
+		err := mysql.NewProductRepository(context.Background(), client).Store(product)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "INSERT INTO products")
+		require.Equal(t, 1, product.Version)
+	})
+
+	t.Run("Update product read at current version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		product := newProduct(2)
+
+		err := mysql.NewProductRepository(context.Background(), client).Store(product)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "WHERE id = ? AND version = ?")
+		require.Equal(t, 2, client.Execs[0].Args[len(client.Execs[0].Args)-1])
+		require.Equal(t, 3, product.Version)
+	})
+
+	t.Run("Fail on version conflict", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 0}
+		product := newProduct(2)
+
+		err := mysql.NewProductRepository(context.Background(), client).Store(product)
+
+		require.ErrorIs(t, err, model.ErrVersionConflict)
+		require.Equal(t, 2, product.Version)
+	})
+}
@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	commonmysql "common/mysql"
+
+	"product/pkg/application/service"
+	"product/pkg/domain/model"
+	domainservice "product/pkg/domain/service"
+	"product/pkg/infrastructure/event"
+)
+
+func NewUnitOfWork(db *sqlx.DB, serializer event.Serializer) service.UnitOfWork {
+	return &unitOfWork{
+		db:         db,
+		serializer: serializer,
+	}
+}
+
+type unitOfWork struct {
+	db         *sqlx.DB
+	serializer event.Serializer
+}
+
+func (u *unitOfWork) Execute(ctx context.Context, f func(provider service.RepositoryProvider) error) error {
+	return commonmysql.ExecuteInTransaction(ctx, u.db, func(tx *sqlx.Tx) error {
+		return f(&repositoryProvider{tx: tx, serializer: u.serializer})
+	})
+}
+
+type repositoryProvider struct {
+	tx         *sqlx.Tx
+	serializer event.Serializer
+}
+
+func (p *repositoryProvider) ProductRepository(ctx context.Context) model.ProductRepository {
+	return NewProductRepository(ctx, p.tx)
+}
+
+func (p *repositoryProvider) EventDispatcher(ctx context.Context) domainservice.EventDispatcher {
+	return commonmysql.NewEventDispatcher(ctx, p.tx, p.serializer)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+
+	"product/pkg/domain/model"
 )
 
 type errorSet map[error]struct{}
@@ -22,9 +24,20 @@ func (s errorSet) Has(err error) bool {
 	return ok
 }
 
-var badRequestErrorCodes = newErrorSet()
+var badRequestErrorCodes = newErrorSet(
+	model.ErrProductNameExists,
+	model.ErrInvalidAmount,
+	model.ErrInvalidCurrency,
+	model.ErrInvalidCategory,
+)
 
-var notFoundErrorCodes = newErrorSet()
+var notFoundErrorCodes = newErrorSet(
+	model.ErrProductNotFound,
+)
+
+var abortedErrorCodes = newErrorSet(
+	model.ErrVersionConflict,
+)
 
 var unauthorizedErrorCodes = newErrorSet()
 
@@ -43,6 +56,8 @@ func getGRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 	case isNotFoundError(cause):
 		return codes.NotFound
+	case isAbortedError(cause):
+		return codes.Aborted
 	case isUnauthorizedError(cause):
 		return codes.Unauthenticated
 	case isPermissionDeniedError(cause):
@@ -69,6 +84,7 @@ func isWarnLevel(err error) bool {
 		codes.InvalidArgument,
 		codes.NotFound,
 		codes.FailedPrecondition,
+		codes.Aborted,
 		codes.Unauthenticated:
 		return true
 	default:
@@ -84,6 +100,10 @@ func isNotFoundError(cause error) bool {
 	return notFoundErrorCodes.Has(cause)
 }
 
+func isAbortedError(cause error) bool {
+	return abortedErrorCodes.Has(cause)
+}
+
 func isUnauthorizedError(cause error) bool {
 	return unauthorizedErrorCodes.Has(cause)
 }
@@ -0,0 +1,13 @@
+package transport
+
+import (
+	api "product/api/server/productinternal"
+)
+
+// IdempotentMethods - изменяющие вызовы, повтор которых с тем же idempotency-key
+// возвращает сохранённый ответ вместо повторного выполнения
+var IdempotentMethods = []string{
+	api.ProductInternalService_CreateProduct_FullMethodName,
+	api.ProductInternalService_UpdateProduct_FullMethodName,
+	api.ProductInternalService_DeleteProduct_FullMethodName,
+}
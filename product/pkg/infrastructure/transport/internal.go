@@ -3,14 +3,29 @@ package transport
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	api "product/api/server/productinternal"
+	"product/pkg/application/query"
+	"product/pkg/application/service"
+	"product/pkg/domain/model"
 )
 
-func NewInternalAPI() api.ProductInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(
+	productService service.ProductService,
+	productQueryService query.ProductQueryService,
+) api.ProductInternalServiceServer {
+	return &internalAPI{
+		productService:      productService,
+		productQueryService: productQueryService,
+	}
 }
 
 type internalAPI struct {
+	productService      service.ProductService
+	productQueryService query.ProductQueryService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +33,94 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) CreateProduct(ctx context.Context, request *api.CreateProductRequest) (*api.CreateProductResponse, error) {
+	price, err := fromAPIMoney(request.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := i.productService.CreateProduct(ctx, request.Name, request.Category, price)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateProductResponse{
+		ProductID: productID.String(),
+	}, nil
+}
+
+func (i *internalAPI) UpdateProduct(ctx context.Context, request *api.UpdateProductRequest) (*api.UpdateProductResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	price, err := fromAPIMoney(request.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.productService.UpdateProduct(ctx, productID, request.Name, request.Category, price)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateProductResponse{}, nil
+}
+
+func (i *internalAPI) DeleteProduct(ctx context.Context, request *api.DeleteProductRequest) (*api.DeleteProductResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.productService.DeleteProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.DeleteProductResponse{}, nil
+}
+
+func (i *internalAPI) GetProduct(ctx context.Context, request *api.GetProductRequest) (*api.GetProductResponse, error) {
+	productID, err := parseUUID(request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := i.productQueryService.FindProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetProductResponse{
+		Product: &api.Product{
+			ProductID: product.ID.String(),
+			Name:      product.Name,
+			Price:     toAPIMoney(product.Price),
+			Category:  product.Category,
+		},
+	}, nil
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q: %s", value, err)
+	}
+	return id, nil
+}
+
+func fromAPIMoney(money *api.Money) (model.Money, error) {
+	if money == nil {
+		return model.Money{}, status.Error(codes.InvalidArgument, "money is required")
+	}
+	return model.NewMoney(money.Amount, money.Currency)
+}
+
+func toAPIMoney(money model.Money) *api.Money {
+	return &api.Money{
+		Amount:   money.Amount,
+		Currency: money.Currency,
+	}
+}
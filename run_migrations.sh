@@ -58,8 +58,8 @@ echo ""
 echo "🎉 Все миграции выполнены успешно!"
 echo ""
 echo "📊 Список таблиц в базах данных:"
-echo "   • order_microservice: orders, order_items"
-echo "   • user_microservice: users"
-echo "   • payment_microservice: payments, wallets"
-echo "   • product_microservice: products"
-echo "   • notification_microservice: notifications, recipients"
\ No newline at end of file
+echo "   • order_microservice: orders, order_items, outbox"
+echo "   • user_microservice: users, outbox"
+echo "   • payment_microservice: payments, wallets, outbox"
+echo "   • product_microservice: products, outbox"
+echo "   • notification_microservice: notifications, recipients, outbox"
\ No newline at end of file
@@ -21,6 +21,9 @@ local gosources = [
     "pkg",
 ];
 
+// Общий модуль инфраструктуры лежит рядом с сервисами и подключается в go.mod через replace => ../common
+local commonsources = "../common";
+
 {
     // Function that generate project build definitions, including code generating, app compilation and e.t.c
     project(appIDs, protos):: {
@@ -38,11 +41,18 @@ local gosources = [
                 env: {
                     GOCACHE: "/app/cache/go-build",
                 },
-                copy: copyFrom(
-                    'gosources',
-                    '/app',
-                    '/app'
-                ),
+                copy: [
+                    copyFrom(
+                        'gosources',
+                        '/app',
+                        '/app'
+                    ),
+                    copyFrom(
+                        'gosources',
+                        '/common',
+                        '/common'
+                    ),
+                ],
             },
         } + {
             [appID]: {
@@ -63,7 +73,7 @@ local gosources = [
             gosources: {
                 from: "scratch",
                 workdir: "/app",
-                copy: [copy(source, source) for source in gosources]
+                copy: [copy(source, source) for source in gosources] + [copy(commonsources, "/common")]
             },
 
             generate: ['generategrpc'],
@@ -105,6 +115,11 @@ local gosources = [
                         '/app',
                         '/app'
                     ),
+                    copyFrom(
+                        'gosources',
+                        '/common',
+                        '/common'
+                    ),
                 ],
                 command: "golangci-lint run",
             },
@@ -1,16 +1,49 @@
 package main
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"common/amqp"
+	commonmysql "common/mysql"
+	"common/outbox"
+
+	"user/pkg/application/query"
+	appservice "user/pkg/application/service"
+	"user/pkg/infrastructure/event"
+	"user/pkg/infrastructure/mysql"
+)
 
 func newDependencyContainer(
-	_ *config,
+	config *config,
+	logger *log.Logger,
 	connContainer *connectionsContainer,
 ) (*dependencyContainer, error) {
+	uow := mysql.NewUnitOfWork(connContainer.db, event.NewJSONSerializer())
+
+	publisher, err := amqp.NewPublisher(connContainer.amqpConnection, appID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dependencyContainer{
-		db: connContainer.db,
+		db:               connContainer.db,
+		UserService:      appservice.NewUserService(uow),
+		UserQueryService: mysql.NewUserQueryService(connContainer.db),
+		OutboxRelay: outbox.NewRelay(
+			commonmysql.NewOutboxStorage(connContainer.db),
+			publisher,
+			logger,
+			config.OutboxRelayInterval,
+			config.OutboxBatchSize,
+		),
 	}, nil
 }
 
 type dependencyContainer struct {
 	db *sqlx.DB
+
+	UserService      appservice.UserService
+	UserQueryService query.UserQueryService
+	OutboxRelay      *outbox.Relay
 }
@@ -0,0 +1,22 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	ID        uuid.UUID
+	Login     string
+	Email     string
+	Tg        *string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+type UserQueryService interface {
+	// FindUser возвращает и удалённых пользователей, у них заполнен DeletedAt
+	FindUser(ctx context.Context, userID uuid.UUID) (*User, error)
+}
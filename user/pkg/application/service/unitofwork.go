@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"user/pkg/domain/model"
+	domainservice "user/pkg/domain/service"
+)
+
+type RepositoryProvider interface {
+	UserRepository(ctx context.Context) model.UserRepository
+	EventDispatcher(ctx context.Context) domainservice.EventDispatcher
+}
+
+type UnitOfWork interface {
+	Execute(ctx context.Context, f func(provider RepositoryProvider) error) error
+}
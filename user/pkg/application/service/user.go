@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	domainservice "user/pkg/domain/service"
+)
+
+type UserService interface {
+	CreateUser(ctx context.Context, login, email string, tg *string) (uuid.UUID, error)
+	UpdateUser(ctx context.Context, userID uuid.UUID, login, email string, tg *string) error
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewUserService(uow UnitOfWork) UserService {
+	return &userService{
+		uow: uow,
+	}
+}
+
+type userService struct {
+	uow UnitOfWork
+}
+
+func (s *userService) CreateUser(ctx context.Context, login, email string, tg *string) (userID uuid.UUID, err error) {
+	err = s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		userID, err = s.domainService(ctx, provider).CreateUser(login, email, tg)
+		return err
+	})
+	return userID, err
+}
+
+func (s *userService) UpdateUser(ctx context.Context, userID uuid.UUID, login, email string, tg *string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).UpdateUser(userID, login, email, tg)
+	})
+}
+
+func (s *userService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteUser(userID)
+	})
+}
+
+func (s *userService) domainService(ctx context.Context, provider RepositoryProvider) domainservice.User {
+	return domainservice.NewUserService(provider.UserRepository(ctx), provider.EventDispatcher(ctx))
+}
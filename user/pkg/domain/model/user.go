@@ -11,6 +11,8 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrLoginExists  = errors.New("login already exists")
 	ErrEmailExists  = errors.New("email already exists")
+	// ErrVersionConflict - пользователя изменили после того, как его прочитали, операцию можно повторить
+	ErrVersionConflict = errors.New("user was modified concurrently")
 )
 
 type User struct {
@@ -21,6 +23,8 @@ type User struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
+	// Version не даёт параллельным изменениям логина и контактов затереть друг друга, 0 - пользователь ещё не сохранён
+	Version int
 }
 
 type UserRepository interface {
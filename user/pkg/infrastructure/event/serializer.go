@@ -0,0 +1,17 @@
+package event
+
+import (
+	commonevent "common/event"
+	"user/pkg/domain/model"
+	"user/pkg/domain/service"
+)
+
+type Serializer = commonevent.Serializer[service.Event]
+
+func NewJSONSerializer() Serializer {
+	return commonevent.NewJSONSerializer[service.Event](
+		model.UserCreated{},
+		model.UserUpdated{},
+		model.UserDeleted{},
+	)
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"common/mysql/mysqltest"
+	"user/pkg/domain/model"
+	"user/pkg/infrastructure/mysql"
+)
+
+func TestUserRepositoryStore(t *testing.T) {
+	newUser := func(version int) *model.User {
+		return &model.User{
+			ID:        uuid.New(),
+			Login:     "john",
+			Email:     "john@example.com",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Version:   version,
+		}
+	}
+
+	t.Run("Insert new user with first version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		user := newUser(0)
+
+		err := mysql.NewUserRepository(context.Background(), client).Store(user)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "INSERT INTO users")
+		require.Equal(t, 1, user.Version)
+	})
+
+	t.Run("Update user read at current version", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 1}
+		user := newUser(5)
+
+		err := mysql.NewUserRepository(context.Background(), client).Store(user)
+
+		require.NoError(t, err)
+		require.Contains(t, client.Execs[0].Query, "WHERE id = ? AND version = ?")
+		require.Equal(t, 5, client.Execs[0].Args[len(client.Execs[0].Args)-1])
+		require.Equal(t, 6, user.Version)
+	})
+
+	t.Run("Fail on version conflict", func(t *testing.T) {
+		client := &mysqltest.Client{RowsAffected: 0}
+		user := newUser(5)
+
+		err := mysql.NewUserRepository(context.Background(), client).Store(user)
+
+		require.ErrorIs(t, err, model.ErrVersionConflict)
+		require.Equal(t, 5, user.Version)
+	})
+}
@@ -0,0 +1,34 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"user/pkg/application/query"
+)
+
+func NewUserQueryService(db *sqlx.DB) query.UserQueryService {
+	return &userQueryService{db: db}
+}
+
+type userQueryService struct {
+	db *sqlx.DB
+}
+
+func (s *userQueryService) FindUser(ctx context.Context, userID uuid.UUID) (*query.User, error) {
+	user, err := NewUserRepository(ctx, s.db).Find(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.User{
+		ID:        user.ID,
+		Login:     user.Login,
+		Email:     user.Email,
+		Tg:        user.Tg,
+		CreatedAt: user.CreatedAt,
+		DeletedAt: user.DeletedAt,
+	}, nil
+}
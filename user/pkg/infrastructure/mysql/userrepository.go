@@ -1,40 +1,36 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
+	commonmysql "common/mysql"
 	"user/pkg/domain/model"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 )
 
 type UserRepository struct {
-	db *sqlx.DB
+	ctx    context.Context
+	client ClientContext
 }
 
-func NewUserRepository(db *sqlx.DB) *UserRepository {
-	return &UserRepository{db: db}
+func NewUserRepository(ctx context.Context, client ClientContext) *UserRepository {
+	return &UserRepository{
+		ctx:    ctx,
+		client: client,
+	}
 }
 
 func (r *UserRepository) NextID() (uuid.UUID, error) {
 	return uuid.NewUUID()
 }
 
+// Store сохраняет пользователя, проверяя, что с момента чтения его версия не изменилась.
+// При расхождении версий возвращает model.ErrVersionConflict
 func (r *UserRepository) Store(user *model.User) error {
-	query := `
-		INSERT INTO users (id, login, email, tg, created_at, updated_at, deleted_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			login = VALUES(login),
-			email = VALUES(email),
-			tg = VALUES(tg),
-			updated_at = VALUES(updated_at),
-			deleted_at = VALUES(deleted_at)
-	`
-
 	deletedAt := (*time.Time)(nil)
 	if user.DeletedAt != nil {
 		deletedAt = user.DeletedAt
@@ -45,32 +41,56 @@ func (r *UserRepository) Store(user *model.User) error {
 		tg = user.Tg
 	}
 
-	_, err := r.db.Exec(query,
-		user.ID.String(),
-		user.Login,
-		user.Email,
-		tg,
-		user.CreatedAt,
-		user.UpdatedAt,
-		deletedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to store user: %w", err)
-	}
+	if user.Version == 0 {
+		_, err := r.client.ExecContext(r.ctx, `
+			INSERT INTO users (id, login, email, tg, created_at, updated_at, deleted_at, version)
+			VALUES (?, ?, ?, ?, ?, ?, ?, 1)`,
+			user.ID.String(),
+			user.Login,
+			user.Email,
+			tg,
+			user.CreatedAt,
+			user.UpdatedAt,
+			deletedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store user: %w", err)
+		}
+	} else {
+		result, err := r.client.ExecContext(r.ctx, `
+			UPDATE users
+			SET login = ?, email = ?, tg = ?, updated_at = ?, deleted_at = ?, version = version + 1
+			WHERE id = ? AND version = ?`,
+			user.Login,
+			user.Email,
+			tg,
+			user.UpdatedAt,
+			deletedAt,
+			user.ID.String(),
+			user.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store user: %w", err)
+		}
+		if err = commonmysql.CheckVersionedUpdate(result, model.ErrVersionConflict); err != nil {
+			return err
+		}
+	}
+	user.Version++
 
 	return nil
 }
 
 func (r *UserRepository) Find(id uuid.UUID) (*model.User, error) {
 	query := `
-		SELECT id, login, email, tg, created_at, updated_at, deleted_at
+		SELECT id, login, email, tg, created_at, updated_at, deleted_at, version
 		FROM users
 		WHERE id = ?
 	`
 
 	var user UserRow
 
-	err := r.db.Get(&user, query, id.String())
+	err := r.client.GetContext(r.ctx, &user, query, id.String())
 	if err == sql.ErrNoRows {
 		return nil, model.ErrUserNotFound
 	}
@@ -83,14 +103,14 @@ func (r *UserRepository) Find(id uuid.UUID) (*model.User, error) {
 
 func (r *UserRepository) FindByLogin(login string) (*model.User, error) {
 	query := `
-		SELECT id, login, email, tg, created_at, updated_at, deleted_at
+		SELECT id, login, email, tg, created_at, updated_at, deleted_at, version
 		FROM users
 		WHERE login = ?
 	`
 
 	var user UserRow
 
-	err := r.db.Get(&user, query, login)
+	err := r.client.GetContext(r.ctx, &user, query, login)
 	if err == sql.ErrNoRows {
 		return nil, model.ErrUserNotFound
 	}
@@ -103,14 +123,14 @@ func (r *UserRepository) FindByLogin(login string) (*model.User, error) {
 
 func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
 	query := `
-		SELECT id, login, email, tg, created_at, updated_at, deleted_at
+		SELECT id, login, email, tg, created_at, updated_at, deleted_at, version
 		FROM users
 		WHERE email = ?
 	`
 
 	var user UserRow
 
-	err := r.db.Get(&user, query, email)
+	err := r.client.GetContext(r.ctx, &user, query, email)
 	if err == sql.ErrNoRows {
 		return nil, model.ErrUserNotFound
 	}
@@ -123,7 +143,7 @@ func (r *UserRepository) FindByEmail(email string) (*model.User, error) {
 
 func (r *UserRepository) Delete(id uuid.UUID) error {
 	query := "DELETE FROM users WHERE id = ?"
-	_, err := r.db.Exec(query, id.String())
+	_, err := r.client.ExecContext(r.ctx, query, id.String())
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -134,7 +154,7 @@ func (r *UserRepository) Delete(id uuid.UUID) error {
 func (r *UserRepository) CheckLoginExists(login string) (bool, error) {
 	query := "SELECT COUNT(*) FROM users WHERE login = ? AND deleted_at IS NULL"
 	var count int
-	err := r.db.Get(&count, query, login)
+	err := r.client.GetContext(r.ctx, &count, query, login)
 	if err != nil {
 		return false, fmt.Errorf("failed to check login exists: %w", err)
 	}
@@ -144,7 +164,7 @@ func (r *UserRepository) CheckLoginExists(login string) (bool, error) {
 func (r *UserRepository) CheckEmailExists(email string) (bool, error) {
 	query := "SELECT COUNT(*) FROM users WHERE email = ? AND deleted_at IS NULL"
 	var count int
-	err := r.db.Get(&count, query, email)
+	err := r.client.GetContext(r.ctx, &count, query, email)
 	if err != nil {
 		return false, fmt.Errorf("failed to check email exists: %w", err)
 	}
@@ -153,14 +173,14 @@ func (r *UserRepository) CheckEmailExists(email string) (bool, error) {
 
 func (r *UserRepository) GetAllActiveUsers() ([]*model.User, error) {
 	query := `
-		SELECT id, login, email, tg, created_at, updated_at, deleted_at
+		SELECT id, login, email, tg, created_at, updated_at, deleted_at, version
 		FROM users
 		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 
 	var users []UserRow
-	err := r.db.Select(&users, query)
+	err := r.client.SelectContext(r.ctx, &users, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all active users: %w", err)
 	}
@@ -181,6 +201,7 @@ type UserRow struct {
 	CreatedAt time.Time      `db:"created_at"`
 	UpdatedAt time.Time      `db:"updated_at"`
 	DeletedAt sql.NullTime   `db:"deleted_at"`
+	Version   int            `db:"version"`
 }
 
 func (r *UserRepository) rowToUser(row *UserRow) *model.User {
@@ -205,5 +226,6 @@ func (r *UserRepository) rowToUser(row *UserRow) *model.User {
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
 		DeletedAt: deletedAt,
+		Version:   row.Version,
 	}
 }
@@ -3,14 +3,23 @@ package transport
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	api "user/api/server/userinternal"
+	"user/pkg/application/query"
 )
 
-func NewInternalAPI() api.UserInternalServiceServer {
-	return &internalAPI{}
+func NewInternalAPI(userQueryService query.UserQueryService) api.UserInternalServiceServer {
+	return &internalAPI{
+		userQueryService: userQueryService,
+	}
 }
 
 type internalAPI struct {
+	userQueryService query.UserQueryService
 }
 
 func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResponse, error) {
@@ -18,3 +27,38 @@ func (i *internalAPI) Ping(_ context.Context, _ *api.PingRequest) (*api.PingResp
 		Message: "pong",
 	}, nil
 }
+
+func (i *internalAPI) GetUser(ctx context.Context, request *api.GetUserRequest) (*api.GetUserResponse, error) {
+	userID, err := parseUUID(request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := i.userQueryService.FindUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUser := &api.User{
+		UserID:    user.ID.String(),
+		Login:     user.Login,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+	if user.Tg != nil {
+		apiUser.Tg = *user.Tg
+	}
+	if user.DeletedAt != nil {
+		apiUser.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+
+	return &api.GetUserResponse{User: apiUser}, nil
+}
+
+func parseUUID(value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid uuid %q: %s", value, err)
+	}
+	return id, nil
+}